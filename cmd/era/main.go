@@ -17,6 +17,7 @@
 package main
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/internal/era"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/flags"
@@ -268,9 +270,11 @@ func checkAccumulator(e *era.Era) error {
 	//   4) the starting total difficulty value is correct
 	//   5) the accumulator is correct by recomputing it locally, which verifies
 	//      the blocks are all correct (via hash)
+	//   6) the blob sidecars, if archived, match the blob transactions of the
+	//      block and their versioned hashes
 	//
-	// The attributes 1), 2), and 3) are checked for each block. 4) and 5) require
-	// accumulation across the entire set and are verified at the end.
+	// The attributes 1), 2), 3) and 6) are checked for each block. 4) and 5)
+	// require accumulation across the entire set and are verified at the end.
 	for it.Next() {
 		// 1) next() walks the block index, so we're able to implicitly verify it.
 		if it.Error() != nil {
@@ -290,6 +294,16 @@ func checkAccumulator(e *era.Era) error {
 		if rr != block.ReceiptHash() {
 			return fmt.Errorf("receipt root in block %d mismatch: want %s, got %s", block.NumberU64(), block.ReceiptHash(), rr)
 		}
+		// 6) check the blob sidecars against the blob transactions.
+		sidecars, err := it.BlobSidecars()
+		if err != nil {
+			return fmt.Errorf("error reading blob sidecars of block %d: %w", it.Number(), err)
+		}
+		if sidecars != nil {
+			if err := checkBlobSidecars(block, sidecars); err != nil {
+				return fmt.Errorf("blob sidecars in block %d mismatch: %w", block.NumberU64(), err)
+			}
+		}
 		hashes = append(hashes, block.Hash())
 		td.Add(td, block.Difficulty())
 		tds = append(tds, new(big.Int).Set(td))
@@ -305,6 +319,46 @@ func checkAccumulator(e *era.Era) error {
 	return nil
 }
 
+// checkBlobSidecars verifies the sidecars belong to the blob transactions of
+// the block in order, and that the blob commitments match the versioned hashes
+// of the transactions.
+func checkBlobSidecars(block *types.Block, sidecars types.BlobSidecars) error {
+	var (
+		hasher = sha256.New()
+		next   int
+	)
+	for i, tx := range block.Transactions() {
+		if tx.Type() != types.BlobTxType {
+			continue
+		}
+		if next >= len(sidecars) {
+			return fmt.Errorf("missing sidecar of transaction %d", i)
+		}
+		sidecar := sidecars[next]
+		next++
+
+		if err := sidecar.SanityCheck(block.Number(), block.Hash()); err != nil {
+			return err
+		}
+		if sidecar.TxHash != tx.Hash() || sidecar.TxIndex != uint64(i) {
+			return fmt.Errorf("sidecar of transaction %d (%s) mismatches, have transaction %d (%s)", i, tx.Hash(), sidecar.TxIndex, sidecar.TxHash)
+		}
+		hashes := tx.BlobHashes()
+		if len(sidecar.Commitments) != len(hashes) {
+			return fmt.Errorf("transaction %d has %d blob hashes, sidecar has %d commitments", i, len(hashes), len(sidecar.Commitments))
+		}
+		for j, vhash := range hashes {
+			if computed := kzg4844.CalcBlobHashV1(hasher, &sidecar.Commitments[j]); computed != vhash {
+				return fmt.Errorf("transaction %d blob %d: computed hash %s mismatches versioned hash %s", i, j, computed, vhash)
+			}
+		}
+	}
+	if next != len(sidecars) {
+		return fmt.Errorf("%d sidecars for %d blob transactions", len(sidecars), next)
+	}
+	return nil
+}
+
 // readHashes reads a file of newline-delimited hashes.
 func readHashes(f string) ([]common.Hash, error) {
 	b, err := os.ReadFile(f)
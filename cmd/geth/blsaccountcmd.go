@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/google/uuid"
@@ -27,6 +28,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/vote"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/internal/flags"
 	"github.com/ethereum/go-ethereum/signer/core"
@@ -213,6 +216,51 @@ Generate ownership proof for the selected BLS account from the BLS wallet. The p
 					},
 				},
 			},
+			{
+				Name:      "slashing-protection",
+				Usage:     "Manage the slashing protection history of BLS keys",
+				ArgsUsage: "",
+				Category:  "BLS ACCOUNT COMMANDS",
+				Description: `
+
+Export or import the slashing protection history of the BLS keys in an interchange
+format modeled on EIP-3076. The history records the highest source and target block
+number each BLS key has voted for.
+
+Before moving a vote key to another host, stop the old node, export the history
+there and import it on the new host before starting it, the new node refuses to
+sign any vote conflicting with the imported history.`,
+				Subcommands: []*cli.Command{
+					{
+						Name:      "export",
+						Usage:     "Export the slashing protection history",
+						Action:    blsSlashingProtectionExport,
+						ArgsUsage: "<file>",
+						Category:  "BLS ACCOUNT COMMANDS",
+						Flags: slices.Concat([]cli.Flag{
+							utils.VoteJournalDirFlag,
+						}, utils.NetworkFlags, utils.DatabaseFlags),
+						Description: `
+	geth bls slashing-protection export <file>
+
+Export the slashing protection history of the votes in the vote journal, merged
+with the history imported before, into <file>.`,
+					},
+					{
+						Name:      "import",
+						Usage:     "Import the slashing protection history",
+						Action:    blsSlashingProtectionImport,
+						ArgsUsage: "<file>",
+						Category:  "BLS ACCOUNT COMMANDS",
+						Flags:     slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
+						Description: `
+	geth bls slashing-protection import <file>
+
+Import the slashing protection history exported from another host. The history
+must be exported from the same chain.`,
+					},
+				},
+			},
 		},
 	}
 )
@@ -665,3 +713,78 @@ func blsAccountGenerateProof(ctx *cli.Context) error {
 
 	return nil
 }
+
+// blsSlashingProtectionExport exports the slashing protection history of the BLS
+// keys into the interchange file.
+func blsSlashingProtectionExport(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true, false)
+	defer db.Close()
+
+	genesisHash := rawdb.ReadCanonicalHash(db, 0)
+	if genesisHash == (common.Hash{}) {
+		utils.Fatalf("Failed to load genesis block hash.")
+	}
+
+	var journal *vote.VoteJournal
+	journalPath := stack.ResolvePath(stack.Config().VoteJournalDir)
+	if _, err := os.Stat(journalPath); err == nil {
+		if journal, err = vote.NewVoteJournal(journalPath); err != nil {
+			utils.Fatalf("Open vote journal failed: %v.", err)
+		}
+	} else if !os.IsNotExist(err) {
+		utils.Fatalf("Check vote journal %s failed: %v.", journalPath, err)
+	}
+
+	protection, err := vote.ExportSlashingProtection(db, journal, genesisHash)
+	if err != nil {
+		utils.Fatalf("Export slashing protection history failed: %v.", err)
+	}
+	data, err := json.MarshalIndent(protection, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(ctx.Args().First(), data, 0600); err != nil {
+		utils.Fatalf("Write slashing protection history failed: %v.", err)
+	}
+	fmt.Printf("Exported slashing protection history of %d BLS keys.\n", len(protection.Data))
+	return nil
+}
+
+// blsSlashingProtectionImport imports the slashing protection history of the BLS
+// keys from the interchange file.
+func blsSlashingProtectionImport(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	data, err := os.ReadFile(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Read slashing protection history failed: %v.", err)
+	}
+	protection := new(vote.SlashingProtection)
+	if err := json.Unmarshal(data, protection); err != nil {
+		utils.Fatalf("Decode slashing protection history failed: %v.", err)
+	}
+
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, false, false)
+	defer db.Close()
+
+	genesisHash := rawdb.ReadCanonicalHash(db, 0)
+	if genesisHash == (common.Hash{}) {
+		utils.Fatalf("Failed to load genesis block hash.")
+	}
+	imported, err := vote.ImportSlashingProtection(db, protection, genesisHash)
+	if err != nil {
+		utils.Fatalf("Import slashing protection history failed: %v.", err)
+	}
+	fmt.Printf("Imported slashing protection history of %d BLS keys.\n", imported)
+	return nil
+}
@@ -180,7 +180,7 @@ be gzipped.`,
 		),
 		Description: `
 The import-history command will import blocks and their corresponding receipts
-from Era archives.
+from Era archives, along with the blob sidecars of the blocks archived with them.
 `,
 	}
 	exportHistoryCommand = &cli.Command{
@@ -191,7 +191,8 @@ from Era archives.
 		Flags:     slices.Concat(utils.DatabaseFlags),
 		Description: `
 The export-history command will export blocks and their corresponding receipts
-into Era archives. Eras are typically packaged in steps of 8192 blocks.
+into Era archives. Eras are typically packaged in steps of 8192 blocks. The blob
+sidecars of the blocks still within the blob retention window are exported too.
 `,
 	}
 	importPreimagesCommand = &cli.Command{
@@ -18,6 +18,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -33,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/parlia"
 	"github.com/ethereum/go-ethereum/console/prompt"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
@@ -83,6 +85,7 @@ Remove blockchain and state databases`,
 			dbPutCmd,
 			dbGetSlotsCmd,
 			dbDumpFreezerIndex,
+			dbVerifyAncientCmd,
 			dbImportCmd,
 			dbExportCmd,
 			dbMetadataCmd,
@@ -94,6 +97,8 @@ Remove blockchain and state databases`,
 			dbTrieGetCmd,
 			dbTrieDeleteCmd,
 			dbInspectHistoryCmd,
+			dbExportEvidenceCmd,
+			dbPruneParliaSnapshotsCmd,
 		},
 	}
 	dbInspectCmd = &cli.Command{
@@ -288,6 +293,25 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		Usage: "Inspect the ancientStore information",
 		Description: `This commands will read current offset from kvdb, which is the current offset and starting BlockNumber
 of ancientStore, will also displays the reserved number of blocks in ancientStore `,
+	}
+	dbVerifyAncientCmd = &cli.Command{
+		Action: verifyAncient,
+		Name:   "verify-ancient",
+		Usage:  "Verify the integrity of the chain freezer",
+		Flags: slices.Concat([]cli.Flag{
+			&cli.BoolFlag{
+				Name:  "repair",
+				Usage: "truncate all the tables to the last consistent block",
+			},
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command walks all the chain freezer tables, checking the consistency between
+the index and data files of each table, the linkage of the hashes and headers, and that the
+number of receipts matches the number of transactions in the bodies. The result is printed
+as JSON, the command fails if any inconsistency is found.
+
+With --repair, all the tables are truncated to the last block consistent across the freezer.
+The truncated blocks must be synced again; if they are no longer available in the key-value
+store, the node will refuse to start because of the gap in the chain.`,
 	}
 	dbInspectHistoryCmd = &cli.Command{
 		Action:    inspectHistory,
@@ -311,6 +335,40 @@ of ancientStore, will also displays the reserved number of blocks in ancientStor
 		}, utils.NetworkFlags, utils.DatabaseFlags),
 		Description: "This command queries the history of the account or storage slot within the specified block range",
 	}
+	dbExportEvidenceCmd = &cli.Command{
+		Action:    exportEvidence,
+		Name:      "export-evidence",
+		Usage:     "Export the double sign evidence detected by the double sign monitor",
+		ArgsUsage: "[OPTIONAL <dumpfile>]",
+		Flags: slices.Concat([]cli.Flag{
+			&cli.Uint64Flag{
+				Name:  "start",
+				Usage: "block number of the range start",
+			},
+			&cli.Uint64Flag{
+				Name:  "end",
+				Usage: "block number of the range end(included), zero means no upper limit",
+			},
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command exports the double sign evidence as JSON lines, one pair of conflicting
+headers per line, to the dumpfile or to stdout. The RLP encoded headers can be submitted
+directly to the slash indicator contract.`,
+	}
+	dbPruneParliaSnapshotsCmd = &cli.Command{
+		Action: pruneParliaSnapshots,
+		Name:   "prune-parlia-snapshots",
+		Usage:  "Delete the stale parlia checkpoint snapshots",
+		Flags: slices.Concat([]cli.Flag{
+			&cli.Uint64Flag{
+				Name:     "keep",
+				Usage:    "number of most recent checkpoint snapshots to keep",
+				Required: true,
+			},
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command deletes the parlia checkpoint snapshots stored in the database, but the
+given number of most recent ones. The snapshots kept are rewritten in the compact encoding
+if they are stored in the legacy JSON one.`,
+	}
 )
 
 func removeDB(ctx *cli.Context) error {
@@ -1063,6 +1121,28 @@ func freezerInspect(ctx *cli.Context) error {
 	return rawdb.InspectFreezerTable(ancient, freezer, table, start, end, stack.CheckIfMultiDataBase())
 }
 
+func verifyAncient(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	ancient := stack.ResolveAncient("chaindata", ctx.String(utils.AncientFlag.Name))
+	stack.Close()
+
+	report, err := rawdb.VerifyChainFreezer(ancient, ctx.Bool("repair"))
+	if report != nil {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if !report.Consistent() && !report.Repaired {
+		return errors.New("chain freezer is inconsistent")
+	}
+	return nil
+}
+
 func importLDBdata(ctx *cli.Context) error {
 	start := 0
 	switch ctx.NArg() {
@@ -1470,3 +1550,60 @@ func inspectHistory(ctx *cli.Context) error {
 	}
 	return inspectStorage(triedb, start, end, address, slot, ctx.Bool("raw"))
 }
+
+func exportEvidence(ctx *cli.Context) error {
+	if ctx.NArg() > 1 {
+		return fmt.Errorf("max 1 argument: %v", ctx.Command.ArgsUsage)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true, false)
+	defer db.Close()
+
+	start, end := ctx.Uint64("start"), ctx.Uint64("end")
+	if end == 0 {
+		end = math.MaxUint64
+	}
+	if start > end {
+		return fmt.Errorf("invalid range [%d, %d]", start, end)
+	}
+	out := os.Stdout
+	if ctx.NArg() == 1 {
+		fh, err := os.OpenFile(ctx.Args().First(), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+		if err != nil {
+			return err
+		}
+		defer fh.Close()
+		out = fh
+	}
+	evidences := rawdb.ReadDoubleSignEvidences(db, start, end)
+	enc := json.NewEncoder(out)
+	for _, evidence := range evidences {
+		if err := enc.Encode(evidence); err != nil {
+			return err
+		}
+	}
+	log.Info("Exported double sign evidence", "count", len(evidences))
+	return nil
+}
+
+func pruneParliaSnapshots(ctx *cli.Context) error {
+	keep := ctx.Uint64("keep")
+	if keep == 0 {
+		return errors.New("at least one snapshot must be kept")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, false, false)
+	defer db.Close()
+
+	start := time.Now()
+	deleted, err := parlia.PruneSnapshots(db, int(keep))
+	if err != nil {
+		return err
+	}
+	log.Info("Pruned parlia snapshots", "deleted", deleted, "kept", keep, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
@@ -94,6 +94,7 @@ var (
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
 		utils.TxPoolOverflowPoolSlotsFlag,
+		utils.TxPoolOverflowPoolJournalFlag,
 		utils.TxPoolLifetimeFlag,
 		utils.TxPoolReannounceTimeFlag,
 		utils.BlobPoolDataDirFlag,
@@ -109,6 +110,7 @@ var (
 		utils.TransactionHistoryFlag,
 		utils.BlockHistoryFlag,
 		utils.StateHistoryFlag,
+		utils.StateIndexingFlag,
 		utils.PathDBSyncFlag,
 		utils.JournalFileFlag,
 		utils.LightServeFlag,       // deprecated
@@ -122,6 +124,7 @@ var (
 		utils.LegacyWhitelistFlag, // deprecated
 		utils.BloomFilterSizeFlag,
 		utils.TriesInMemoryFlag,
+		utils.ParliaSnapshotRetentionFlag,
 		utils.CacheFlag,
 		utils.CacheDatabaseFlag,
 		utils.CacheTrieFlag,
@@ -181,12 +184,22 @@ var (
 		utils.VotingEnabledFlag,
 		utils.DisableVoteAttestationFlag,
 		utils.EnableMaliciousVoteMonitorFlag,
+		utils.MaliciousVoteSubmitterFlag,
 		utils.BLSPasswordFileFlag,
 		utils.BLSWalletDirFlag,
+		utils.BLSRemoteSignerFlag,
+		utils.BLSRemoteSignerPubKeyFlag,
+		utils.BLSRemoteSignerCertFlag,
+		utils.BLSRemoteSignerKeyFlag,
+		utils.BLSRemoteSignerCAFlag,
+		utils.BLSRemoteSignerInsecureFlag,
 		utils.VoteJournalDirFlag,
 		utils.LogDebugFlag,
 		utils.LogBacktraceAtFlag,
 		utils.BlobExtraReserveFlag,
+		utils.RemoteDBPublishFlag,
+		utils.RemoteDBReplicaFlag,
+		utils.RemoteDBCacheFlag,
 		// utils.BeaconApiFlag,
 		// utils.BeaconApiHeaderFlag,
 		// utils.BeaconThresholdFlag,
@@ -224,6 +237,7 @@ var (
 		utils.RPCGlobalGasCapFlag,
 		utils.RPCGlobalEVMTimeoutFlag,
 		utils.RPCGlobalTxFeeCapFlag,
+		utils.RPCPrivateTxEndpointsFlag,
 		utils.AllowUnprotectedTxs,
 		utils.BatchRequestLimit,
 		utils.BatchResponseMaxSize,
@@ -291,6 +305,8 @@ func init() {
 		// See snapshot.go
 		snapshotCommand,
 		blsCommand,
+		// See mevcmd.go
+		mevCommand,
 		// See verkle.go
 		verkleCommand,
 	}
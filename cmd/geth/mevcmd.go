@@ -0,0 +1,183 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	bidJournalFlag = &cli.StringFlag{
+		Name:     "journal",
+		Usage:    "Path of the bid journal recorded by the node (Eth.Miner.Mev.Journal)",
+		Required: true,
+	}
+	bidHashFlag = &cli.StringFlag{
+		Name:  "bid",
+		Usage: "Hash of the only bid to replay, all the bids of the block by default",
+	}
+
+	mevCommand = &cli.Command{
+		Name:  "mev",
+		Usage: "A set of commands based on the MEV bids",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "replay",
+				Usage:     "Replay the journaled bids of a block against the local state",
+				ArgsUsage: "<number>",
+				Action:    replayBids,
+				Flags:     slices.Concat([]cli.Flag{bidJournalFlag, bidHashFlag}, utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth mev replay --journal <path> <number>
+executes again the bids received for the block of the given number, as recorded
+in the bid journal, on top of the local state of their parent. Each bid is
+printed as a JSON line holding the outcome recorded when it was simulated and
+the outcome of the replay, followed by the block chosen for sealing.
+
+The replay does not merge the transactions of the pool into the bids, so the
+rewards of the bids which were greedily merged are lower than the recorded ones.
+The state of the parent must be available, which requires an archive node for
+the blocks older than the state history.
+`,
+			},
+		},
+	}
+)
+
+// bidReplayReport is the output of the replay of a bid.
+type bidReplayReport struct {
+	BidHash    common.Hash            `json:"bidHash"`
+	Builder    common.Address         `json:"builder"`
+	ReceivedAt int64                  `json:"receivedAt"`
+	Rejection  string                 `json:"rejection,omitempty"` // Error returned to the builder on arrival
+	Recorded   *miner.BidJournalEntry `json:"recorded,omitempty"`  // Last simulation, nil if never simulated
+	Replayed   *miner.BidReplay       `json:"replayed,omitempty"`
+	Winner     bool                   `json:"winner"`
+}
+
+// bidWinnerReport is the output of the block chosen for sealing.
+type bidWinnerReport struct {
+	ParentHash  common.Hash    `json:"parentHash"`
+	BidHash     common.Hash    `json:"bidHash"`
+	Builder     common.Address `json:"builder"`
+	BlockReward *big.Int       `json:"blockReward,omitempty"`
+	LocalReward *big.Int       `json:"localReward,omitempty"`
+}
+
+func replayBids(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("required arguments: %v", ctx.Command.ArgsUsage)
+	}
+	number, err := strconv.ParseUint(ctx.Args().First(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid block number: %v", err)
+	}
+	entries, err := miner.ReadBidJournal(ctx.String(bidJournalFlag.Name), number)
+	if err != nil {
+		return err
+	}
+	var only common.Hash
+	if ctx.IsSet(bidHashFlag.Name) {
+		only = common.HexToHash(ctx.String(bidHashFlag.Name))
+	}
+	// Index the last simulation of each bid and of each parent, the bids never
+	// simulated are replayed on the header of another bid of the same parent.
+	var (
+		simulations = make(map[common.Hash]*miner.BidJournalEntry)
+		headers     = make(map[common.Hash]*miner.BidJournalEntry)
+		winners     = make(map[common.Hash]*miner.BidJournalEntry)
+	)
+	for _, entry := range entries {
+		switch entry.Kind {
+		case miner.BidJournalSimulated:
+			simulations[entry.BidHash] = entry
+			if entry.Header != nil {
+				headers[entry.ParentHash] = entry
+			}
+		case miner.BidJournalWinner:
+			winners[entry.ParentHash] = entry
+		}
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, _ := utils.MakeChain(ctx, stack, true)
+	defer chain.Stop()
+
+	var (
+		encoder  = json.NewEncoder(os.Stdout)
+		replayed = make(map[common.Hash]bool)
+	)
+	for _, entry := range entries {
+		if entry.Kind != miner.BidJournalReceived || entry.Args == nil || replayed[entry.BidHash] {
+			continue
+		}
+		if only != (common.Hash{}) && entry.BidHash != only {
+			continue
+		}
+		replayed[entry.BidHash] = true
+
+		report := &bidReplayReport{
+			BidHash:    entry.BidHash,
+			Builder:    entry.Builder,
+			ReceivedAt: entry.Time,
+			Rejection:  entry.Error,
+			Recorded:   simulations[entry.BidHash],
+		}
+		if winner := winners[entry.ParentHash]; winner != nil {
+			report.Winner = winner.BidHash == entry.BidHash
+		}
+		base := report.Recorded
+		if base == nil || base.Header == nil {
+			base = headers[entry.ParentHash]
+		}
+		if base == nil {
+			log.Warn("No simulation recorded on the parent of the bid", "bid", entry.BidHash, "parent", entry.ParentHash)
+		} else {
+			report.Replayed, err = miner.ReplayBid(chain, entry.Args, base.Header, base.Commission)
+			if err != nil {
+				return fmt.Errorf("failed to replay bid %x: %v", entry.BidHash, err)
+			}
+		}
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	}
+	for parent, winner := range winners {
+		if err := encoder.Encode(&bidWinnerReport{
+			ParentHash:  parent,
+			BidHash:     winner.BidHash,
+			Builder:     winner.Builder,
+			BlockReward: winner.BlockReward,
+			LocalReward: winner.LocalReward,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -176,6 +178,30 @@ as the backend data source, making this command a lot faster.
 
 The argument is interpreted as block number or hash. If none is provided, the latest
 block is used.
+`,
+			},
+			{
+				Name:      "diff",
+				Usage:     "Compare two states and print out the changed accounts",
+				ArgsUsage: "<rootA> <rootB>",
+				Action:    diffState,
+				Flags: slices.Concat([]cli.Flag{
+					&cli.StringSliceFlag{
+						Name:  "address",
+						Usage: "Only compare the given accounts (repeatable)",
+					},
+					utils.StartKeyFlag,
+					utils.DumpLimitFlag,
+					utils.TriesInMemoryFlag,
+				}, utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth snapshot diff <rootA> <rootB>
+will walk the two states in parallel and print out the accounts created, deleted
+or modified from rootA to rootB as JSON lines, along with the changed balance,
+nonce, code hash and storage slots.
+
+The snapshot is used as the data source if both states are covered by it,
+otherwise the tries are iterated instead.
 `,
 			},
 			{
@@ -693,6 +719,76 @@ func dumpState(ctx *cli.Context) error {
 	return nil
 }
 
+// diffState compares two states and prints out the changed accounts.
+func diffState(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return fmt.Errorf("expected 2 arguments (state roots), got %d", ctx.NArg())
+	}
+	rootA, err := parseRoot(ctx.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	rootB, err := parseRoot(ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	conf := &state.DiffConfig{
+		Max: ctx.Uint64(utils.DumpLimitFlag.Name),
+	}
+	for _, addr := range ctx.StringSlice("address") {
+		if !common.IsHexAddress(addr) {
+			return fmt.Errorf("invalid address: %s", addr)
+		}
+		conf.Addresses = append(conf.Addresses, common.HexToAddress(addr))
+	}
+	startArg := common.FromHex(ctx.String(utils.StartKeyFlag.Name))
+	switch len(startArg) {
+	case 0, 32:
+		conf.Start = common.BytesToHash(startArg).Bytes()
+	case 20:
+		conf.Start = crypto.Keccak256(startArg)
+	default:
+		return fmt.Errorf("invalid start argument: %x. 20 or 32 hex-encoded bytes required", startArg)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true, false)
+	defer db.Close()
+
+	triedb := utils.MakeTrieDatabase(ctx, stack, db, true, true, false)
+	defer triedb.Close()
+
+	var snaptree *snapshot.Tree
+	if headBlock := rawdb.ReadHeadBlock(db); headBlock != nil {
+		snapConfig := snapshot.Config{
+			CacheSize:  256,
+			Recovery:   false,
+			NoBuild:    true,
+			AsyncBuild: false,
+		}
+		triesInMemory := ctx.Uint64(utils.TriesInMemoryFlag.Name)
+		snaptree, err = snapshot.New(snapConfig, db, triedb, headBlock.Root(), int(triesInMemory), false)
+		if err != nil {
+			log.Warn("Snapshot is unavailable, iterating the tries", "err", err)
+			snaptree = nil
+		}
+	}
+	enc := json.NewEncoder(os.Stdout)
+	next, err := state.NewStateDiffer(triedb, snaptree).Diff(context.Background(), rootA, rootB, conf, func(diff *state.AccountDiff) error {
+		return enc.Encode(diff)
+	})
+	if err != nil {
+		return err
+	}
+	if next != nil {
+		return enc.Encode(struct {
+			Next hexutil.Bytes `json:"next"`
+		}{next})
+	}
+	return nil
+}
+
 // snapshotExportPreimages dumps the preimage data to a flat file.
 func snapshotExportPreimages(ctx *cli.Context) error {
 	if ctx.NArg() < 1 {
@@ -305,6 +305,13 @@ func ImportHistory(chain *core.BlockChain, db ethdb.Database, dir string, networ
 				if err != nil {
 					return fmt.Errorf("error reading receipts %d: %w", it.Number(), err)
 				}
+				sidecars, err := it.BlobSidecars()
+				if err != nil {
+					return fmt.Errorf("error reading blob sidecars %d: %w", it.Number(), err)
+				}
+				if sidecars != nil {
+					block = block.WithSidecars(sidecars)
+				}
 				if status, err := chain.HeaderChain().InsertHeaderChain([]*types.Header{block.Header()}, start, forker); err != nil {
 					return fmt.Errorf("error inserting header %d: %w", it.Number(), err)
 				} else if status != core.CanonStatTy {
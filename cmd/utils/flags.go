@@ -123,6 +123,22 @@ var (
 		Usage:    "URL for remote database",
 		Category: flags.LoggingCategory,
 	}
+	RemoteDBPublishFlag = &cli.BoolFlag{
+		Name:     "remotedb.publish",
+		Usage:    "Publish the database updates to read replicas via debug_subscribe",
+		Category: flags.EthCategory,
+	}
+	RemoteDBReplicaFlag = &cli.StringFlag{
+		Name:     "remotedb.replica",
+		Usage:    "Follow the database of the primary node at the given URL as a read replica, without syncing or processing blocks",
+		Category: flags.EthCategory,
+	}
+	RemoteDBCacheFlag = &cli.IntFlag{
+		Name:     "remotedb.cache",
+		Usage:    "Number of database entries cached by the read replica",
+		Value:    ethconfig.Defaults.DatabaseReplicaCache,
+		Category: flags.EthCategory,
+	}
 	DBEngineFlag = &cli.StringFlag{
 		Name:     "db.engine",
 		Usage:    "Backing database implementation to use ('pebble' or 'leveldb')",
@@ -267,6 +283,12 @@ var (
 		Value:    128,
 		Category: flags.PerfCategory,
 	}
+	ParliaSnapshotRetentionFlag = &cli.Uint64Flag{
+		Name:     "parlia.snapshots.keep",
+		Usage:    "Number of parlia checkpoint snapshots kept on disk, the older ones are deleted (0 = keep all)",
+		Value:    ethconfig.Defaults.ParliaSnapshotRetention,
+		Category: flags.PerfCategory,
+	}
 	TriesVerifyModeFlag = &cli.StringFlag{
 		Name: "tries-verify-mode",
 		Usage: `tries verify mode:
@@ -373,6 +395,12 @@ var (
 		Value:    ethconfig.Defaults.StateHistory,
 		Category: flags.StateCategory,
 	}
+	StateIndexingFlag = &cli.BoolFlag{
+		Name:     "history.stateindexing",
+		Usage:    "Index the state histories to serve historic states within the retained window (path scheme only)",
+		Value:    ethconfig.Defaults.StateIndexing,
+		Category: flags.StateCategory,
+	}
 	TransactionHistoryFlag = &cli.Uint64Flag{
 		Name:     "history.transactions",
 		Usage:    "Number of recent blocks to maintain transactions index for (default = about one year, 0 = entire chain)",
@@ -502,6 +530,12 @@ var (
 		Value:    ethconfig.Defaults.TxPool.OverflowPoolSlots,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolOverflowPoolJournalFlag = &cli.StringFlag{
+		Name:     "txpool.overflowpooljournal",
+		Usage:    "Disk journal for overflow pool transactions to survive node restarts (disabled if empty)",
+		Value:    ethconfig.Defaults.TxPool.OverflowPoolJournal,
+		Category: flags.TxPoolCategory,
+	}
 	TxPoolLifetimeFlag = &cli.DurationFlag{
 		Name:     "txpool.lifetime",
 		Usage:    "Maximum amount of time non-executable transaction are queued",
@@ -700,6 +734,11 @@ var (
 		Value:    ethconfig.Defaults.RPCTxFeeCap,
 		Category: flags.APICategory,
 	}
+	RPCPrivateTxEndpointsFlag = &cli.StringSliceFlag{
+		Name:     "rpc.privatetxendpoints",
+		Usage:    "Comma separated RPC endpoints of the trusted validators or EVN nodes the private transactions are forwarded to",
+		Category: flags.APICategory,
+	}
 	// Authenticated RPC HTTP settings
 	AuthListenFlag = &cli.StringFlag{
 		Name:  "authrpc.addr",
@@ -1197,6 +1236,12 @@ Please note that --` + MetricsHTTPFlag.Name + ` must be set to start the server.
 		Category: flags.FastFinalityCategory,
 	}
 
+	MaliciousVoteSubmitterFlag = &cli.StringFlag{
+		Name:     "monitor.maliciousvote.submitter",
+		Usage:    "Account used to submit the malicious vote evidence to the slash indicator contract (requires --monitor.maliciousvote, the account must be unlocked)",
+		Category: flags.FastFinalityCategory,
+	}
+
 	BLSPasswordFileFlag = &cli.StringFlag{
 		Name:     "blspassword",
 		Usage:    "Password file path for the BLS wallet, which contains the password to unlock BLS wallet for managing votes in fast_finality feature",
@@ -1209,6 +1254,37 @@ Please note that --` + MetricsHTTPFlag.Name + ` must be set to start the server.
 		Category: flags.AccountCategory,
 	}
 
+	BLSRemoteSignerFlag = &cli.StringFlag{
+		Name:     "blsremotesigner",
+		Usage:    "URL of the remote signing service holding the BLS vote key, votes are signed by it instead of the BLS wallet",
+		Category: flags.FastFinalityCategory,
+	}
+	BLSRemoteSignerPubKeyFlag = &cli.StringFlag{
+		Name:     "blsremotesigner.pubkey",
+		Usage:    "BLS public key of the remote signing service to vote with (default = the first key of the service)",
+		Category: flags.FastFinalityCategory,
+	}
+	BLSRemoteSignerCertFlag = &cli.StringFlag{
+		Name:     "blsremotesigner.tls.cert",
+		Usage:    "Client certificate file for the mutual TLS connection to the remote signing service",
+		Category: flags.FastFinalityCategory,
+	}
+	BLSRemoteSignerKeyFlag = &cli.StringFlag{
+		Name:     "blsremotesigner.tls.key",
+		Usage:    "Client private key file for the mutual TLS connection to the remote signing service",
+		Category: flags.FastFinalityCategory,
+	}
+	BLSRemoteSignerCAFlag = &cli.StringFlag{
+		Name:     "blsremotesigner.tls.ca",
+		Usage:    "CA certificate file to verify the remote signing service (default = the system roots)",
+		Category: flags.FastFinalityCategory,
+	}
+	BLSRemoteSignerInsecureFlag = &cli.BoolFlag{
+		Name:     "blsremotesigner.insecure",
+		Usage:    "Allow connecting to the remote signing service over plain HTTP or without a client certificate (unsafe)",
+		Category: flags.FastFinalityCategory,
+	}
+
 	VoteJournalDirFlag = &flags.DirectoryFlag{
 		Name:     "vote-journal-path",
 		Usage:    "Path for the voteJournal dir in fast finality feature (default = inside the datadir)",
@@ -1536,6 +1612,9 @@ func setMonitors(ctx *cli.Context, cfg *node.Config) {
 	if ctx.Bool(EnableMaliciousVoteMonitorFlag.Name) {
 		cfg.EnableMaliciousVoteMonitor = true
 	}
+	if ctx.IsSet(MaliciousVoteSubmitterFlag.Name) {
+		cfg.MaliciousVoteSubmitter = ctx.String(MaliciousVoteSubmitterFlag.Name)
+	}
 }
 
 // MakeDatabaseHandles raises out the number of allowed file handles per process
@@ -1739,6 +1818,24 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(BLSPasswordFileFlag.Name) {
 		cfg.BLSPasswordFile = ctx.String(BLSPasswordFileFlag.Name)
 	}
+	if ctx.IsSet(BLSRemoteSignerFlag.Name) {
+		cfg.BLSRemoteSigner = ctx.String(BLSRemoteSignerFlag.Name)
+	}
+	if ctx.IsSet(BLSRemoteSignerPubKeyFlag.Name) {
+		cfg.BLSRemoteSignerPubKey = ctx.String(BLSRemoteSignerPubKeyFlag.Name)
+	}
+	if ctx.IsSet(BLSRemoteSignerCertFlag.Name) {
+		cfg.BLSRemoteSignerCert = ctx.String(BLSRemoteSignerCertFlag.Name)
+	}
+	if ctx.IsSet(BLSRemoteSignerKeyFlag.Name) {
+		cfg.BLSRemoteSignerKey = ctx.String(BLSRemoteSignerKeyFlag.Name)
+	}
+	if ctx.IsSet(BLSRemoteSignerCAFlag.Name) {
+		cfg.BLSRemoteSignerCA = ctx.String(BLSRemoteSignerCAFlag.Name)
+	}
+	if ctx.IsSet(BLSRemoteSignerInsecureFlag.Name) {
+		cfg.BLSRemoteSignerInsecure = ctx.Bool(BLSRemoteSignerInsecureFlag.Name)
+	}
 	if ctx.IsSet(DBEngineFlag.Name) {
 		dbEngine := ctx.String(DBEngineFlag.Name)
 		if dbEngine != "leveldb" && dbEngine != "pebble" {
@@ -1859,6 +1956,9 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolOverflowPoolSlotsFlag.Name) {
 		cfg.OverflowPoolSlots = ctx.Uint64(TxPoolOverflowPoolSlotsFlag.Name)
 	}
+	if ctx.IsSet(TxPoolOverflowPoolJournalFlag.Name) {
+		cfg.OverflowPoolJournal = ctx.String(TxPoolOverflowPoolJournalFlag.Name)
+	}
 	if ctx.IsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.Duration(TxPoolLifetimeFlag.Name)
 	}
@@ -2036,6 +2136,15 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(AncientFlag.Name) {
 		cfg.DatabaseFreezer = ctx.String(AncientFlag.Name)
 	}
+	if ctx.IsSet(RemoteDBPublishFlag.Name) {
+		cfg.DatabasePublish = ctx.Bool(RemoteDBPublishFlag.Name)
+	}
+	if ctx.IsSet(RemoteDBReplicaFlag.Name) {
+		cfg.DatabaseReplica = ctx.String(RemoteDBReplicaFlag.Name)
+	}
+	if ctx.IsSet(RemoteDBCacheFlag.Name) {
+		cfg.DatabaseReplicaCache = ctx.Int(RemoteDBCacheFlag.Name)
+	}
 	if ctx.IsSet(PruneAncientDataFlag.Name) {
 		log.Warn(fmt.Sprintf("Option --%s is deprecated. Please using --%s in the future", PruneAncientDataFlag.Name, BlockHistoryFlag.Name))
 		cfg.PruneAncientData = ctx.Bool(PruneAncientDataFlag.Name)
@@ -2067,6 +2176,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(StateHistoryFlag.Name) {
 		cfg.StateHistory = ctx.Uint64(StateHistoryFlag.Name)
 	}
+	if ctx.IsSet(StateIndexingFlag.Name) {
+		cfg.StateIndexing = ctx.Bool(StateIndexingFlag.Name)
+	}
 	scheme, err := ParseCLIAndConfigStateScheme(ctx.String(StateSchemeFlag.Name), cfg.StateScheme)
 	if err != nil {
 		Fatalf("%v", err)
@@ -2118,6 +2230,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(TriesInMemoryFlag.Name) {
 		cfg.TriesInMemory = ctx.Uint64(TriesInMemoryFlag.Name)
 	}
+	if ctx.IsSet(ParliaSnapshotRetentionFlag.Name) {
+		cfg.ParliaSnapshotRetention = ctx.Uint64(ParliaSnapshotRetentionFlag.Name)
+	}
 	if ctx.IsSet(TriesVerifyModeFlag.Name) {
 		if err = cfg.TriesVerifyMode.UnmarshalText([]byte(ctx.String(TriesVerifyModeFlag.Name))); err != nil {
 			Fatalf("invalid --tries-verify-mode flag: %v", err)
@@ -2179,6 +2294,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.Float64(RPCGlobalTxFeeCapFlag.Name)
 	}
+	if ctx.IsSet(RPCPrivateTxEndpointsFlag.Name) {
+		cfg.PrivateTxEndpoints = ctx.StringSlice(RPCPrivateTxEndpointsFlag.Name)
+	}
 	if ctx.IsSet(NoDiscoverFlag.Name) {
 		cfg.EthDiscoveryURLs, cfg.SnapDiscoveryURLs, cfg.BscDiscoveryURLs = []string{}, []string{}, []string{}
 	} else if ctx.IsSet(DNSDiscoveryFlag.Name) {
@@ -2708,6 +2826,7 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 		Preimages:           ctx.Bool(CachePreimagesFlag.Name),
 		StateScheme:         scheme,
 		StateHistory:        ctx.Uint64(StateHistoryFlag.Name),
+		StateIndexing:       ctx.Bool(StateIndexingFlag.Name),
 	}
 	if cache.TrieDirtyDisabled && !cache.Preimages {
 		cache.Preimages = true
@@ -17,12 +17,32 @@
 package parlia
 
 import (
+	"fmt"
+	"math/big"
+	"sort"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/parlia/finality"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/willf/bitset"
 )
 
+// maxValidatorPerformanceRange is the maximum number of blocks that can be
+// aggregated by a single GetValidatorPerformance call.
+const maxValidatorPerformanceRange = 10000
+
+// maxFinalityProofRange is the maximum number of blocks searched for the vote
+// attestation finalizing the requested block.
+const maxFinalityProofRange = 256
+
+// maxProposerScheduleCount is the maximum number of block slots forecast by a
+// single GetProposerSchedule call.
+const maxProposerScheduleCount = 1000
+
 // API is a user facing RPC API to allow query snapshot and validators
 type API struct {
 	chain  consensus.ChainHeaderReader
@@ -114,6 +134,99 @@ func (api *API) GetFinalizedNumber(number *rpc.BlockNumber) (uint64, error) {
 	return snap.Attestation.SourceNumber, nil
 }
 
+// ValidatorPerformance is the block production and liveness summary of a single
+// validator over a range of blocks.
+type ValidatorPerformance struct {
+	Validator        common.Address `json:"validator"`
+	InTurnBlocks     uint64         `json:"in_turn_blocks"`     // Blocks sealed with in-turn difficulty
+	OutOfTurnBlocks  uint64         `json:"out_of_turn_blocks"` // Blocks sealed with out-of-turn difficulty
+	MissedTurns      uint64         `json:"missed_turns"`       // In-turn slots that were sealed by another validator
+	AverageBackOff   float64        `json:"average_backoff"`    // Average backoff delay of the sealed blocks in milliseconds
+	VotesIncluded    uint64         `json:"votes_included"`     // Attestations whose vote address set includes the validator
+	VotesExpected    uint64         `json:"votes_expected"`     // Attestations the validator was eligible to be part of
+	AttestationShare float64        `json:"attestation_share"`  // Ratio of VotesIncluded to VotesExpected
+
+	totalBackOff uint64
+}
+
+// ValidatorPerformanceReport is the per-validator performance over a block range.
+type ValidatorPerformanceReport struct {
+	FromBlock    uint64                  `json:"from_block"`
+	ToBlock      uint64                  `json:"to_block"`
+	Attestations uint64                  `json:"attestations"` // Number of blocks carrying a vote attestation
+	Validators   []*ValidatorPerformance `json:"validators"`
+}
+
+// GetValidatorPerformance reports the in-turn, out-of-turn and missed blocks, the
+// average backoff delay and the vote attestation share of every validator that
+// was active between fromBlock and toBlock (both inclusive).
+func (api *API) GetValidatorPerformance(fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber) (*ValidatorPerformanceReport, error) {
+	from, to := api.getHeader(&fromBlock), api.getHeader(&toBlock)
+	if from == nil || to == nil {
+		return nil, errUnknownBlock
+	}
+	return api.parlia.validatorPerformance(api.chain, from.Number.Uint64(), to.Number.Uint64())
+}
+
+// ListEvidence returns the double sign evidence recorded by the double sign monitor
+// between fromBlock and toBlock (both inclusive).
+func (api *API) ListEvidence(fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber) ([]*types.DoubleSignEvidence, error) {
+	from, to := api.getHeader(&fromBlock), api.getHeader(&toBlock)
+	if from == nil || to == nil {
+		return nil, errUnknownBlock
+	}
+	return rawdb.ReadDoubleSignEvidences(api.parlia.db, from.Number.Uint64(), to.Number.Uint64()), nil
+}
+
+// GetFinalityProof returns the proof that the block is finalized, which can be
+// checked offline with the finality package.
+func (api *API) GetFinalityProof(number rpc.BlockNumber) (*finality.Proof, error) {
+	header := api.getHeader(&number)
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	return api.parlia.finalityProof(api.chain, header)
+}
+
+// FallbackProposer is a validator permitted to seal a block slot out of turn.
+type FallbackProposer struct {
+	Validator common.Address `json:"validator"`
+	BackOff   uint64         `json:"backoff"` // Delay after the expected time in milliseconds
+}
+
+// ProposerSlot is the forecast of a single block slot.
+type ProposerSlot struct {
+	Number       uint64             `json:"number"`
+	InTurn       common.Address     `json:"in_turn"`
+	TurnStart    uint64             `json:"turn_start"`    // First block of the turn of the in-turn validator
+	TurnEnd      uint64             `json:"turn_end"`      // Last block of the turn of the in-turn validator
+	ExpectedTime uint64             `json:"expected_time"` // Expected timestamp in milliseconds
+	Fallbacks    []FallbackProposer `json:"fallbacks"`     // Out-of-turn validators in backoff order
+}
+
+// ProposerSchedule is the forecast of the block slots following a block.
+type ProposerSchedule struct {
+	Number               uint64         `json:"number"` // Block the forecast starts after
+	Hash                 common.Hash    `json:"hash"`
+	BlockInterval        uint64         `json:"block_interval"`
+	TurnLength           uint8          `json:"turn_length"`
+	NextEpoch            uint64         `json:"next_epoch"`             // First block of the next epoch
+	NextValidatorsChange uint64         `json:"next_validators_change"` // Block the next validator set takes effect
+	Slots                []ProposerSlot `json:"slots"`
+}
+
+// GetProposerSchedule forecasts the in-turn validator, the turn boundaries, the
+// expected timestamp and the backoff order of the fallback validators of the
+// next count block slots after the current head. The forecast assumes every slot
+// is sealed by its in-turn validator on time, and stops at the next validator
+// set switch as the new set is unknown yet.
+func (api *API) GetProposerSchedule(count uint64) (*ProposerSchedule, error) {
+	if count == 0 || count > maxProposerScheduleCount {
+		return nil, fmt.Errorf("invalid slot count %d, max %d", count, maxProposerScheduleCount)
+	}
+	return api.parlia.proposerSchedule(api.chain, api.chain.CurrentHeader(), count)
+}
+
 func (api *API) getHeader(number *rpc.BlockNumber) (header *types.Header) {
 	currentHeader := api.chain.CurrentHeader()
 
@@ -136,3 +249,248 @@ func (api *API) getHeader(number *rpc.BlockNumber) (header *types.Header) {
 	}
 	return
 }
+
+// validatorPerformance walks the headers in [from, to] and aggregates the block
+// production and vote participation of every validator, based on the parent
+// snapshot of each header and the vote attestation carried in its extra data.
+func (p *Parlia) validatorPerformance(chain consensus.ChainHeaderReader, from, to uint64) (*ValidatorPerformanceReport, error) {
+	if from == 0 {
+		from = 1 // the genesis block has no parent snapshot
+	}
+	if from > to {
+		return nil, fmt.Errorf("invalid block range [%d, %d]", from, to)
+	}
+	if to-from+1 > maxValidatorPerformanceRange {
+		return nil, fmt.Errorf("block range too large, max %d blocks", maxValidatorPerformanceRange)
+	}
+
+	var (
+		report = &ValidatorPerformanceReport{FromBlock: from, ToBlock: to}
+		stats  = make(map[common.Address]*ValidatorPerformance)
+	)
+	statsOf := func(val common.Address) *ValidatorPerformance {
+		if s, ok := stats[val]; ok {
+			return s
+		}
+		s := &ValidatorPerformance{Validator: val}
+		stats[val] = s
+		return s
+	}
+
+	parent := chain.GetHeaderByNumber(from - 1)
+	if parent == nil {
+		return nil, errUnknownBlock
+	}
+	var parentSnap *Snapshot // snapshot of the grandparent, used to resolve vote addresses
+	for number := from; number <= to; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil || header.ParentHash != parent.Hash() {
+			return nil, errUnknownBlock
+		}
+		snap, err := p.snapshot(chain, number-1, header.ParentHash, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		signer := statsOf(header.Coinbase)
+		if header.Difficulty.Cmp(diffInTurn) == 0 {
+			signer.InTurnBlocks++
+		} else {
+			signer.OutOfTurnBlocks++
+			if inturn := snap.inturnValidator(); inturn != header.Coinbase {
+				statsOf(inturn).MissedTurns++
+			}
+		}
+		signer.totalBackOff += p.backOffTime(snap, parent, header, header.Coinbase)
+
+		attestation, err := getVoteAttestationFromHeader(header, p.chainConfig, snap.EpochLength)
+		if err != nil {
+			return nil, err
+		}
+		if attestation != nil && attestation.Data != nil && number > 1 {
+			// The voted validators are indexed against the snapshot of the target's parent,
+			// see verifyVoteAttestation.
+			if parentSnap == nil || parentSnap.Hash != parent.ParentHash {
+				if parentSnap, err = p.snapshot(chain, number-2, parent.ParentHash, nil); err != nil {
+					return nil, err
+				}
+			}
+			voted := bitset.From([]uint64{uint64(attestation.VoteAddressSet)})
+			for idx, val := range parentSnap.validators() {
+				s := statsOf(val)
+				s.VotesExpected++
+				if voted.Test(uint(idx)) {
+					s.VotesIncluded++
+				}
+			}
+			report.Attestations++
+		}
+		parentSnap, parent = snap, header
+	}
+
+	validators := make([]common.Address, 0, len(stats))
+	for val := range stats {
+		validators = append(validators, val)
+	}
+	sort.Sort(validatorsAscending(validators))
+	for _, val := range validators {
+		s := stats[val]
+		if blocks := s.InTurnBlocks + s.OutOfTurnBlocks; blocks > 0 {
+			s.AverageBackOff = float64(s.totalBackOff) / float64(blocks)
+		}
+		if s.VotesExpected > 0 {
+			s.AttestationShare = float64(s.VotesIncluded) / float64(s.VotesExpected)
+		}
+		report.Validators = append(report.Validators, s)
+	}
+	return report, nil
+}
+
+// finalityProof searches the canonical chain after the header for the first vote
+// attestation finalizing it or one of its descendants, and assembles the proof.
+func (p *Parlia) finalityProof(chain consensus.ChainHeaderReader, header *types.Header) (*finality.Proof, error) {
+	var (
+		number  = header.Number.Uint64()
+		current = chain.CurrentHeader().Number.Uint64()
+		proof   *finality.Proof
+	)
+	for carrierNumber := number + 2; carrierNumber <= current && carrierNumber <= number+maxFinalityProofRange; carrierNumber++ {
+		carrier := chain.GetHeaderByNumber(carrierNumber)
+		if carrier == nil {
+			return nil, errUnknownBlock
+		}
+		epochLength, err := p.epochLength(chain, carrier, nil)
+		if err != nil {
+			return nil, err
+		}
+		attestation, err := getVoteAttestationFromHeader(carrier, p.chainConfig, epochLength)
+		if err != nil {
+			return nil, err
+		}
+		if attestation == nil || attestation.Data == nil ||
+			attestation.Data.TargetNumber+1 != carrierNumber || attestation.Data.SourceNumber+1 != attestation.Data.TargetNumber ||
+			attestation.Data.SourceNumber < number {
+			continue
+		}
+		target := chain.GetHeader(carrier.ParentHash, carrierNumber-1)
+		if target == nil || target.Hash() != attestation.Data.TargetHash {
+			continue
+		}
+		finalized := chain.GetHeader(target.ParentHash, target.Number.Uint64()-1)
+		if finalized == nil || finalized.Hash() != attestation.Data.SourceHash {
+			continue
+		}
+		enc, err := rlp.EncodeToBytes(attestation)
+		if err != nil {
+			return nil, err
+		}
+		proof = &finality.Proof{
+			Ancestors:         []*types.Header{},
+			FinalizedHeader:   finalized,
+			JustifyingHeaders: []*types.Header{target, carrier},
+			Attestation:       enc,
+			VoteAddressSet:    attestation.VoteAddressSet,
+		}
+		break
+	}
+	if proof == nil {
+		return nil, fmt.Errorf("block %d is not finalized within %d blocks", number, maxFinalityProofRange)
+	}
+
+	// Link the requested block to the finalized one.
+	for parent := proof.FinalizedHeader; parent.Number.Uint64() > number; {
+		if parent = chain.GetHeader(parent.ParentHash, parent.Number.Uint64()-1); parent == nil {
+			return nil, errUnknownBlock
+		}
+		proof.Ancestors = append([]*types.Header{parent}, proof.Ancestors...)
+	}
+	if proof.Header().Hash() != header.Hash() {
+		return nil, fmt.Errorf("block %d is not an ancestor of the finalized block %d", number, proof.FinalizedHeader.Number)
+	}
+
+	// The attestation is verified against the validators at the finalized block,
+	// see verifyVoteAttestation.
+	snap, err := p.snapshot(chain, proof.FinalizedHeader.Number.Uint64(), proof.FinalizedHeader.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, val := range snap.validators() {
+		proof.Validators = append(proof.Validators, finality.Validator{
+			Address:     val,
+			VoteAddress: common.CopyBytes(snap.Validators[val].VoteAddress[:]),
+		})
+	}
+	return proof, nil
+}
+
+// proposerSchedule forecasts the count block slots after head, by applying the
+// in-turn validator of every slot to a copy of the snapshot of head. The epoch
+// length and block interval are assumed to stay the same over the forecast.
+func (p *Parlia) proposerSchedule(chain consensus.ChainHeaderReader, head *types.Header, count uint64) (*ProposerSchedule, error) {
+	snap, err := p.snapshot(chain, head.Number.Uint64(), head.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+	blockInterval := snap.BlockInterval
+	if blockInterval == 0 {
+		blockInterval = defaultBlockInterval
+	}
+	schedule := &ProposerSchedule{
+		Number:               snap.Number,
+		Hash:                 snap.Hash,
+		BlockInterval:        blockInterval,
+		TurnLength:           snap.TurnLength,
+		NextEpoch:            (snap.Number/snap.EpochLength + 1) * snap.EpochLength,
+		NextValidatorsChange: snap.nexValidatorsChangeBlock(),
+		Slots:                make([]ProposerSlot, 0, count),
+	}
+	var (
+		sim    = snap.copy()
+		parent = types.CopyHeader(head)
+	)
+	for number := snap.Number + 1; number <= snap.Number+count && number < schedule.NextValidatorsChange; number++ {
+		expected := head.MilliTimestamp() + (number-snap.Number)*blockInterval
+		header := &types.Header{
+			Number:     new(big.Int).SetUint64(number),
+			ParentHash: parent.Hash(),
+			Time:       expected / 1000,
+			Difficulty: new(big.Int).Set(diffInTurn),
+		}
+		inturn := sim.inturnValidator()
+		slot := ProposerSlot{
+			Number:       number,
+			InTurn:       inturn,
+			TurnStart:    number - number%uint64(sim.TurnLength),
+			TurnEnd:      number - number%uint64(sim.TurnLength) + uint64(sim.TurnLength) - 1,
+			ExpectedTime: expected,
+			Fallbacks:    []FallbackProposer{},
+		}
+		counts := sim.countRecents()
+		for _, val := range sim.validators() {
+			// The validators signed recently are not permitted to seal the slot.
+			if val == inturn || sim.signRecentlyByCounts(val, counts) {
+				continue
+			}
+			slot.Fallbacks = append(slot.Fallbacks, FallbackProposer{Validator: val, BackOff: p.backOffTime(sim, parent, header, val)})
+		}
+		sort.SliceStable(slot.Fallbacks, func(i, j int) bool { return slot.Fallbacks[i].BackOff < slot.Fallbacks[j].BackOff })
+		schedule.Slots = append(schedule.Slots, slot)
+
+		// Advance the snapshot as if the in-turn validator sealed the slot.
+		if limit := sim.minerHistoryCheckLen() + 1; number >= limit {
+			delete(sim.Recents, number-limit)
+		}
+		sim.Recents[number] = inturn
+		if p.chainConfig.IsMaxwell(header.Number, header.Time) && number >= 2 {
+			// BEP-524: assume the grandparent gets finalized in time.
+			for blockNumber := range sim.Recents {
+				if blockNumber <= number-2 {
+					delete(sim.Recents, blockNumber)
+				}
+			}
+		}
+		sim.Number, sim.Hash = number, header.Hash()
+		parent = header
+	}
+	return schedule, nil
+}
@@ -0,0 +1,228 @@
+package parlia
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/parlia/finality"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// mockHeaderChain is a canonical chain of headers kept in memory.
+type mockHeaderChain struct {
+	consensus.ChainHeaderReader
+	config  *params.ChainConfig
+	headers []*types.Header
+}
+
+func (c *mockHeaderChain) Config() *params.ChainConfig { return c.config }
+
+func (c *mockHeaderChain) CurrentHeader() *types.Header { return c.headers[len(c.headers)-1] }
+
+func (c *mockHeaderChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number >= uint64(len(c.headers)) {
+		return nil
+	}
+	return c.headers[number]
+}
+
+func (c *mockHeaderChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := c.GetHeaderByNumber(number); header != nil && header.Hash() == hash {
+		return header
+	}
+	return nil
+}
+
+func (c *mockHeaderChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	for _, header := range c.headers {
+		if header.Hash() == hash {
+			return header
+		}
+	}
+	return nil
+}
+
+func TestValidatorPerformance(t *testing.T) {
+	config := &params.ChainConfig{ChainID: big.NewInt(1), LubanBlock: big.NewInt(0), Parlia: &params.ParliaConfig{}}
+	validators := []common.Address{{0x1}, {0x2}, {0x3}}
+	voteAddrs := make([]types.BLSPublicKey, len(validators))
+
+	p := &Parlia{
+		chainConfig: config,
+		config:      config.Parlia,
+		recentSnaps: lru.NewCache[common.Hash, *Snapshot](inMemorySnapshots),
+		signatures:  lru.NewCache[common.Hash, common.Address](inMemorySignatures),
+	}
+	chain := &mockHeaderChain{config: config}
+
+	// The in-turn validator of block n is validators[n%3], validator 0x3 is offline
+	// so block 2 and 5 are sealed out of turn by 0x1.
+	sealers := []common.Address{{0x2}, {0x1}, {0x1}, {0x2}, {0x1}, {0x1}}
+	for number := uint64(0); number <= 6; number++ {
+		header := &types.Header{
+			Number:     new(big.Int).SetUint64(number),
+			Difficulty: new(big.Int).Set(diffInTurn),
+			Extra:      make([]byte, extraVanity+extraSeal),
+		}
+		if number > 0 {
+			parent := chain.headers[number-1]
+			header.ParentHash = parent.Hash()
+			header.Coinbase = sealers[number-1]
+			if header.Coinbase != validators[number%3] {
+				header.Difficulty = new(big.Int).Set(diffNoTurn)
+			}
+			// Validators 0x1 and 0x2 attest every parent block except the genesis.
+			if number > 1 {
+				attestation := &types.VoteAttestation{
+					VoteAddressSet: 0b011,
+					Data:           &types.VoteData{TargetNumber: number - 1, TargetHash: parent.Hash()},
+				}
+				enc, err := rlp.EncodeToBytes(attestation)
+				require.NoError(t, err)
+				header.Extra = append(append(make([]byte, extraVanity), enc...), make([]byte, extraSeal)...)
+			}
+		}
+		chain.headers = append(chain.headers, header)
+		p.recentSnaps.Add(header.Hash(), newSnapshot(p.config, p.signatures, number, header.Hash(), validators, voteAddrs, nil))
+	}
+
+	report, err := p.validatorPerformance(chain, 1, 6)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), report.Attestations)
+	require.Len(t, report.Validators, 3)
+
+	v1, v2, v3 := report.Validators[0], report.Validators[1], report.Validators[2]
+	assert.Equal(t, common.Address{0x1}, v1.Validator)
+	assert.Equal(t, uint64(2), v1.InTurnBlocks)
+	assert.Equal(t, uint64(2), v1.OutOfTurnBlocks)
+	assert.Equal(t, uint64(0), v1.MissedTurns)
+	assert.Greater(t, v1.AverageBackOff, float64(0))
+	assert.Equal(t, float64(1), v1.AttestationShare)
+
+	assert.Equal(t, uint64(2), v2.InTurnBlocks)
+	assert.Equal(t, uint64(0), v2.OutOfTurnBlocks)
+	assert.Equal(t, float64(0), v2.AverageBackOff)
+
+	assert.Equal(t, uint64(0), v3.InTurnBlocks+v3.OutOfTurnBlocks)
+	assert.Equal(t, uint64(2), v3.MissedTurns)
+	assert.Equal(t, uint64(5), v3.VotesExpected)
+	assert.Equal(t, float64(0), v3.AttestationShare)
+
+	_, err = p.validatorPerformance(chain, 5, 2)
+	assert.Error(t, err)
+}
+
+func TestFinalityProof(t *testing.T) {
+	config := &params.ChainConfig{ChainID: big.NewInt(1), LubanBlock: big.NewInt(0), Parlia: &params.ParliaConfig{}}
+	validators := []common.Address{{0x1}, {0x2}, {0x3}}
+	keys := make([]bls.SecretKey, len(validators))
+	voteAddrs := make([]types.BLSPublicKey, len(validators))
+	for i := range keys {
+		key, err := bls.RandKey()
+		require.NoError(t, err)
+		keys[i] = key
+		copy(voteAddrs[i][:], key.PublicKey().Marshal())
+	}
+
+	p := &Parlia{
+		chainConfig: config,
+		config:      config.Parlia,
+		recentSnaps: lru.NewCache[common.Hash, *Snapshot](inMemorySnapshots),
+		signatures:  lru.NewCache[common.Hash, common.Address](inMemorySignatures),
+	}
+	chain := &mockHeaderChain{config: config}
+
+	// Block 3 carries the attestation finalizing block 1, signed by 0x1 and 0x2.
+	for number := uint64(0); number <= 5; number++ {
+		header := &types.Header{
+			Number:     new(big.Int).SetUint64(number),
+			Difficulty: new(big.Int).Set(diffInTurn),
+			Extra:      make([]byte, extraVanity+extraSeal),
+		}
+		if number > 0 {
+			header.ParentHash = chain.headers[number-1].Hash()
+		}
+		if number == 3 {
+			attestation := &types.VoteAttestation{
+				VoteAddressSet: 0b011,
+				Data: &types.VoteData{
+					SourceNumber: 1, SourceHash: chain.headers[1].Hash(),
+					TargetNumber: 2, TargetHash: chain.headers[2].Hash(),
+				},
+			}
+			sigs := []bls.Signature{keys[0].Sign(attestation.Data.Hash().Bytes()), keys[1].Sign(attestation.Data.Hash().Bytes())}
+			copy(attestation.AggSignature[:], bls.AggregateSignatures(sigs).Marshal())
+			enc, err := rlp.EncodeToBytes(attestation)
+			require.NoError(t, err)
+			header.Extra = append(append(make([]byte, extraVanity), enc...), make([]byte, extraSeal)...)
+		}
+		chain.headers = append(chain.headers, header)
+		p.recentSnaps.Add(header.Hash(), newSnapshot(p.config, p.signatures, number, header.Hash(), validators, voteAddrs, nil))
+	}
+
+	proof, err := p.finalityProof(chain, chain.headers[1])
+	require.NoError(t, err)
+	assert.Empty(t, proof.Ancestors)
+	assert.Equal(t, chain.headers[1].Hash(), proof.FinalizedHeader.Hash())
+	require.NoError(t, finality.VerifyProof(proof))
+
+	// The ancestors link an earlier block to the finalized one.
+	proof, err = p.finalityProof(chain, chain.headers[0])
+	require.NoError(t, err)
+	require.Len(t, proof.Ancestors, 1)
+	assert.Equal(t, chain.headers[0].Hash(), proof.Header().Hash())
+	require.NoError(t, finality.VerifyProof(proof))
+
+	_, err = p.finalityProof(chain, chain.headers[2])
+	assert.Error(t, err, "block 2 is not finalized")
+}
+
+func TestProposerSchedule(t *testing.T) {
+	config := &params.ChainConfig{ChainID: big.NewInt(1), LubanBlock: big.NewInt(0), PlanckBlock: big.NewInt(0), Parlia: &params.ParliaConfig{}}
+	validators := []common.Address{{0x1}, {0x2}, {0x3}}
+
+	p := &Parlia{
+		chainConfig: config,
+		config:      config.Parlia,
+		recentSnaps: lru.NewCache[common.Hash, *Snapshot](inMemorySnapshots),
+		signatures:  lru.NewCache[common.Hash, common.Address](inMemorySignatures),
+	}
+	genesis := &types.Header{Number: big.NewInt(0), Difficulty: new(big.Int).Set(diffInTurn), Time: 100}
+	head := &types.Header{Number: big.NewInt(1), ParentHash: genesis.Hash(), Difficulty: new(big.Int).Set(diffInTurn), Time: 103, Coinbase: common.Address{0x2}}
+	chain := &mockHeaderChain{config: config, headers: []*types.Header{genesis, head}}
+
+	snap := newSnapshot(p.config, p.signatures, 1, head.Hash(), validators, nil, nil)
+	snap.Recents[1] = head.Coinbase
+	p.recentSnaps.Add(head.Hash(), snap)
+
+	api := &API{chain: chain, parlia: p}
+	_, err := api.GetProposerSchedule(0)
+	assert.Error(t, err)
+
+	schedule, err := api.GetProposerSchedule(4)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(defaultEpochLength), schedule.NextEpoch)
+	require.Len(t, schedule.Slots, 4)
+	for i, slot := range schedule.Slots {
+		assert.Equal(t, uint64(i+2), slot.Number)
+		assert.Equal(t, validators[slot.Number%3], slot.InTurn)
+		assert.Equal(t, slot.Number, slot.TurnStart)
+		assert.Equal(t, slot.Number, slot.TurnEnd)
+		assert.Equal(t, head.MilliTimestamp()+uint64(i+1)*defaultBlockInterval, slot.ExpectedTime)
+
+		// The in-turn validator of the previous slot has signed recently.
+		require.Len(t, slot.Fallbacks, 1)
+		assert.NotEqual(t, slot.InTurn, slot.Fallbacks[0].Validator)
+		assert.NotEqual(t, validators[(slot.Number-1)%3], slot.Fallbacks[0].Validator)
+		assert.Greater(t, slot.Fallbacks[0].BackOff, uint64(0))
+	}
+}
@@ -0,0 +1,162 @@
+// Package finality verifies the fast finality proofs of the Parlia consensus
+// engine, so that light clients and bridges can check a block is finalized
+// without trusting the node serving the proof.
+package finality
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/willf/bitset"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	cmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	extraVanity = 32 // Fixed number of extra-data prefix bytes reserved for signer vanity
+	extraSeal   = 65 // Fixed number of extra-data suffix bytes reserved for signer seal
+)
+
+// Validator is a member of the validator set voting for fast finality.
+type Validator struct {
+	Address     common.Address `json:"address"`
+	VoteAddress hexutil.Bytes  `json:"voteAddress"` // BLS public key of the validator
+}
+
+// Proof proves that a block is finalized. A block is finalized once a vote
+// attestation, carried by the grandchild of the block, votes for its direct
+// child with the block itself as the source.
+//
+// The proof only shows that the attestation is signed by the validator set in
+// it, the verifier has to check the validator set against the one it trusts.
+type Proof struct {
+	// Ancestors are the headers from the requested block up to the parent of the
+	// finalized header, empty if the requested block is finalized directly.
+	Ancestors []*types.Header `json:"ancestors"`
+
+	// FinalizedHeader is the finalized header, the source of the attestation.
+	FinalizedHeader *types.Header `json:"finalizedHeader"`
+
+	// JustifyingHeaders are the child of the finalized header, which is the target
+	// of the attestation, and the grandchild carrying the attestation.
+	JustifyingHeaders []*types.Header `json:"justifyingHeaders"`
+
+	// Attestation is the RLP encoded vote attestation in the extra data of the
+	// last justifying header.
+	Attestation hexutil.Bytes `json:"attestation"`
+
+	// VoteAddressSet is the bitset of the validators that signed the attestation,
+	// indexed by the validators below.
+	VoteAddressSet types.ValidatorsBitSet `json:"voteAddressSet"`
+
+	// Validators is the validator set at the finalized header, in ascending order
+	// of the address.
+	Validators []Validator `json:"validators"`
+}
+
+// Header returns the header of the block that the proof was requested for.
+func (p *Proof) Header() *types.Header {
+	if len(p.Ancestors) > 0 {
+		return p.Ancestors[0]
+	}
+	return p.FinalizedHeader
+}
+
+// VerifyProof checks that the proof is self-consistent and that the attestation
+// finalizing the block is signed by at least 2/3 of its validators.
+func VerifyProof(proof *Proof) error {
+	if proof.FinalizedHeader == nil || len(proof.JustifyingHeaders) != 2 {
+		return errors.New("incomplete finality proof")
+	}
+	// The headers must form a chain from the requested block to the attestation.
+	headers := append(append(append([]*types.Header{}, proof.Ancestors...), proof.FinalizedHeader), proof.JustifyingHeaders...)
+	for i := 1; i < len(headers); i++ {
+		if headers[i] == nil || headers[i-1] == nil {
+			return errors.New("incomplete finality proof")
+		}
+		if headers[i].ParentHash != headers[i-1].Hash() || headers[i].Number.Uint64() != headers[i-1].Number.Uint64()+1 {
+			return fmt.Errorf("header %d is not the child of header %d", headers[i].Number, headers[i-1].Number)
+		}
+	}
+	finalized, target, carrier := proof.FinalizedHeader, proof.JustifyingHeaders[0], proof.JustifyingHeaders[1]
+
+	// The attestation must be the one in the extra data of the carrier header.
+	if len(carrier.Extra) < extraVanity+extraSeal ||
+		!bytes.HasSuffix(carrier.Extra[extraVanity:len(carrier.Extra)-extraSeal], proof.Attestation) {
+		return errors.New("attestation not found in the header")
+	}
+	var attestation types.VoteAttestation
+	if err := rlp.DecodeBytes(proof.Attestation, &attestation); err != nil {
+		return fmt.Errorf("invalid attestation: %v", err)
+	}
+	if attestation.Data == nil {
+		return errors.New("invalid attestation, vote data is nil")
+	}
+	if attestation.VoteAddressSet != proof.VoteAddressSet {
+		return errors.New("vote address set mismatch")
+	}
+	if attestation.Data.SourceNumber != finalized.Number.Uint64() || attestation.Data.SourceHash != finalized.Hash() {
+		return fmt.Errorf("invalid attestation, source mismatch, expected block: %d, hash: %s; real block: %d, hash: %s",
+			finalized.Number, finalized.Hash(), attestation.Data.SourceNumber, attestation.Data.SourceHash)
+	}
+	if attestation.Data.TargetNumber != target.Number.Uint64() || attestation.Data.TargetHash != target.Hash() {
+		return fmt.Errorf("invalid attestation, target mismatch, expected block: %d, hash: %s; real block: %d, hash: %s",
+			target.Number, target.Hash(), attestation.Data.TargetNumber, attestation.Data.TargetHash)
+	}
+
+	voteAddrs := make([]types.BLSPublicKey, len(proof.Validators))
+	for i, val := range proof.Validators {
+		if i > 0 && bytes.Compare(proof.Validators[i-1].Address[:], val.Address[:]) >= 0 {
+			return errors.New("validators not in ascending order")
+		}
+		if len(val.VoteAddress) != types.BLSPublicKeyLength {
+			return fmt.Errorf("invalid vote address of validator %s", val.Address)
+		}
+		copy(voteAddrs[i][:], val.VoteAddress)
+	}
+	return VerifyAttestation(&attestation, voteAddrs)
+}
+
+// VerifyAttestation checks that the aggregated signature of the attestation is
+// signed by at least 2/3 of the validators. The vote addresses must be ordered
+// as the validators in ascending order of address, which is how the bits of the
+// vote address set are indexed.
+func VerifyAttestation(attestation *types.VoteAttestation, voteAddrs []types.BLSPublicKey) error {
+	validatorsBitSet := bitset.From([]uint64{uint64(attestation.VoteAddressSet)})
+	if validatorsBitSet.Count() > uint(len(voteAddrs)) {
+		return errors.New("invalid attestation, vote number larger than validators number")
+	}
+	votedAddrs := make([]bls.PublicKey, 0, validatorsBitSet.Count())
+	for index, addr := range voteAddrs {
+		if !validatorsBitSet.Test(uint(index)) {
+			continue
+		}
+
+		voteAddr, err := bls.PublicKeyFromBytes(addr[:])
+		if err != nil {
+			return fmt.Errorf("BLS public key converts failed: %v", err)
+		}
+		votedAddrs = append(votedAddrs, voteAddr)
+	}
+
+	// The valid voted validators should be no less than 2/3 validators.
+	if len(votedAddrs) < cmath.CeilDiv(len(voteAddrs)*2, 3) {
+		return errors.New("invalid attestation, not enough validators voted")
+	}
+
+	// Verify the aggregated signature.
+	aggSig, err := bls.SignatureFromBytes(attestation.AggSignature[:])
+	if err != nil {
+		return fmt.Errorf("BLS signature converts failed: %v", err)
+	}
+	if !aggSig.FastAggregateVerify(votedAddrs, attestation.Data.Hash()) {
+		return errors.New("invalid attestation, signature verify failed")
+	}
+	return nil
+}
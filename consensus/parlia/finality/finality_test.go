@@ -0,0 +1,103 @@
+package finality
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// newTestProof builds a proof finalizing block 10, signed by the first signers
+// of the three validators.
+func newTestProof(t *testing.T, signers int) *Proof {
+	var (
+		keys       = make([]bls.SecretKey, 3)
+		validators = make([]Validator, 3)
+	)
+	for i := range keys {
+		key, err := bls.RandKey()
+		require.NoError(t, err)
+		keys[i] = key
+		validators[i] = Validator{Address: common.Address{byte(i + 1)}, VoteAddress: key.PublicKey().Marshal()}
+	}
+	sort.Slice(validators, func(i, j int) bool { return bytes.Compare(validators[i].Address[:], validators[j].Address[:]) < 0 })
+
+	newHeader := func(number uint64, parent *types.Header, extra []byte) *types.Header {
+		header := &types.Header{
+			Number:     new(big.Int).SetUint64(number),
+			Difficulty: big.NewInt(2),
+			Extra:      append(append(make([]byte, extraVanity), extra...), make([]byte, extraSeal)...),
+		}
+		if parent != nil {
+			header.ParentHash = parent.Hash()
+		}
+		return header
+	}
+	ancestor := newHeader(9, nil, nil)
+	finalized := newHeader(10, ancestor, nil)
+	target := newHeader(11, finalized, nil)
+
+	attestation := &types.VoteAttestation{
+		Data: &types.VoteData{
+			SourceNumber: 10, SourceHash: finalized.Hash(),
+			TargetNumber: 11, TargetHash: target.Hash(),
+		},
+	}
+	var sigs []bls.Signature
+	for i := 0; i < signers; i++ {
+		attestation.VoteAddressSet |= 1 << i
+		sigs = append(sigs, keys[i].Sign(attestation.Data.Hash().Bytes()))
+	}
+	copy(attestation.AggSignature[:], bls.AggregateSignatures(sigs).Marshal())
+	enc, err := rlp.EncodeToBytes(attestation)
+	require.NoError(t, err)
+
+	return &Proof{
+		Ancestors:         []*types.Header{ancestor},
+		FinalizedHeader:   finalized,
+		JustifyingHeaders: []*types.Header{target, newHeader(12, target, enc)},
+		Attestation:       enc,
+		VoteAddressSet:    attestation.VoteAddressSet,
+		Validators:        validators,
+	}
+}
+
+func TestVerifyProof(t *testing.T) {
+	proof := newTestProof(t, 2)
+	require.NoError(t, VerifyProof(proof))
+	assert.Equal(t, uint64(9), proof.Header().Number.Uint64())
+
+	// The proof survives the JSON encoding served by the RPC.
+	enc, err := json.Marshal(proof)
+	require.NoError(t, err)
+	decoded := new(Proof)
+	require.NoError(t, json.Unmarshal(enc, decoded))
+	require.NoError(t, VerifyProof(decoded))
+
+	// Not enough validators voted.
+	assert.Error(t, VerifyProof(newTestProof(t, 1)))
+
+	// The attestation must be carried by the header.
+	tampered := newTestProof(t, 3)
+	tampered.JustifyingHeaders[1].Extra = make([]byte, extraVanity+extraSeal)
+	assert.Error(t, VerifyProof(tampered))
+
+	// The headers must be linked.
+	tampered = newTestProof(t, 3)
+	tampered.Ancestors[0].Time = 1
+	assert.Error(t, VerifyProof(tampered))
+
+	// The validator set must be the one signing.
+	tampered = newTestProof(t, 3)
+	tampered.Validators[0].VoteAddress = tampered.Validators[1].VoteAddress
+	assert.Error(t, VerifyProof(tampered))
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/consensus/parlia/finality"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/forkid"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -256,6 +257,7 @@ type Parlia struct {
 
 	ethAPI                     *ethapi.BlockChainAPI
 	VotePool                   consensus.VotePool
+	snapshotRetention          uint64 // Number of checkpoint snapshots kept on disk, zero keeps them all
 	validatorSetABIBeforeLuban abi.ABI
 	validatorSetABI            abi.ABI
 	slashABI                   abi.ABI
@@ -512,40 +514,13 @@ func (p *Parlia) verifyVoteAttestation(chain consensus.ChainHeaderReader, header
 		return err
 	}
 
-	// Filter out valid validator from attestation.
+	// Verify the aggregated signature against the vote addresses of the validators.
 	validators := snap.validators()
-	validatorsBitSet := bitset.From([]uint64{uint64(attestation.VoteAddressSet)})
-	if validatorsBitSet.Count() > uint(len(validators)) {
-		return errors.New("invalid attestation, vote number larger than validators number")
-	}
-	votedAddrs := make([]bls.PublicKey, 0, validatorsBitSet.Count())
+	voteAddrs := make([]types.BLSPublicKey, len(validators))
 	for index, val := range validators {
-		if !validatorsBitSet.Test(uint(index)) {
-			continue
-		}
-
-		voteAddr, err := bls.PublicKeyFromBytes(snap.Validators[val].VoteAddress[:])
-		if err != nil {
-			return fmt.Errorf("BLS public key converts failed: %v", err)
-		}
-		votedAddrs = append(votedAddrs, voteAddr)
-	}
-
-	// The valid voted validators should be no less than 2/3 validators.
-	if len(votedAddrs) < cmath.CeilDiv(len(snap.Validators)*2, 3) {
-		return errors.New("invalid attestation, not enough validators voted")
-	}
-
-	// Verify the aggregated signature.
-	aggSig, err := bls.SignatureFromBytes(attestation.AggSignature[:])
-	if err != nil {
-		return fmt.Errorf("BLS signature converts failed: %v", err)
-	}
-	if !aggSig.FastAggregateVerify(votedAddrs, attestation.Data.Hash()) {
-		return errors.New("invalid attestation, signature verify failed")
+		voteAddrs[index] = snap.Validators[val].VoteAddress
 	}
-
-	return nil
+	return finality.VerifyAttestation(attestation, voteAddrs)
 }
 
 // verifyHeader checks whether a header conforms to the consensus rules.The
@@ -882,10 +857,34 @@ func (p *Parlia) snapshot(chain consensus.ChainHeaderReader, number uint64, hash
 			return nil, err
 		}
 		log.Trace("Stored snapshot to disk", "number", snap.Number, "hash", snap.Hash)
+		p.pruneSnapshot(chain, snap.Number)
 	}
 	return snap, err
 }
 
+// SetSnapshotRetention sets the number of checkpoint snapshots kept on disk,
+// the older ones being deleted as new checkpoints are stored. Zero keeps them all.
+func (p *Parlia) SetSnapshotRetention(keep uint64) {
+	p.snapshotRetention = keep
+}
+
+// pruneSnapshot deletes the canonical checkpoint snapshot falling out of the
+// retention window once the snapshot of the given checkpoint is stored.
+func (p *Parlia) pruneSnapshot(chain consensus.ChainHeaderReader, number uint64) {
+	if p.snapshotRetention == 0 || number < p.snapshotRetention*checkpointInterval {
+		return
+	}
+	header := chain.GetHeaderByNumber(number - p.snapshotRetention*checkpointInterval)
+	if header == nil {
+		return
+	}
+	if err := p.db.Delete(snapshotKey(header.Hash())); err != nil {
+		log.Warn("Failed to delete stale snapshot", "number", header.Number, "hash", header.Hash(), "err", err)
+		return
+	}
+	log.Trace("Deleted stale snapshot from disk", "number", header.Number, "hash", header.Hash())
+}
+
 // VerifyUncles implements consensus.Engine, always returning an error for any
 // uncles as this consensus mechanism doesn't permit uncles.
 func (p *Parlia) VerifyUncles(chain consensus.ChainReader, block *types.Block) error {
@@ -1575,6 +1574,20 @@ func (p *Parlia) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header *
 	return blk, receipts, nil
 }
 
+// VoteAddresses returns the vote addresses of the validators allowed to vote
+// for the header, by validator address.
+func (p *Parlia) VoteAddresses(chain consensus.ChainHeaderReader, header *types.Header) (map[common.Address]types.BLSPublicKey, error) {
+	snap, err := p.snapshot(chain, header.Number.Uint64()-1, header.ParentHash, nil)
+	if err != nil {
+		return nil, err
+	}
+	voteAddrs := make(map[common.Address]types.BLSPublicKey, len(snap.Validators))
+	for val, info := range snap.Validators {
+		voteAddrs[val] = info.VoteAddress
+	}
+	return voteAddrs, nil
+}
+
 func (p *Parlia) IsActiveValidatorAt(chain consensus.ChainHeaderReader, header *types.Header, checkVoteKeyFn func(bLSPublicKey *types.BLSPublicKey) bool) bool {
 	number := header.Number.Uint64()
 	snap, err := p.snapshot(chain, number-1, header.ParentHash, nil)
@@ -2338,6 +2351,21 @@ func (p *Parlia) NextProposalBlock(chain consensus.ChainHeaderReader, header *ty
 	return snap.nextProposalBlock(proposer)
 }
 
+// BackOffTime returns the time in milliseconds the validator has to wait on top
+// of the block interval before proposing the header, zero if it is in turn.
+func (p *Parlia) BackOffTime(chain consensus.ChainHeaderReader, header *types.Header, val common.Address) (uint64, error) {
+	number := header.Number.Uint64()
+	parent := chain.GetHeader(header.ParentHash, number-1)
+	if parent == nil {
+		return 0, consensus.ErrUnknownAncestor
+	}
+	snap, err := p.snapshot(chain, number-1, header.ParentHash, nil)
+	if err != nil {
+		return 0, err
+	}
+	return p.backOffTime(snap, parent, header, val), nil
+}
+
 func (p *Parlia) checkNanoBlackList(state vm.StateDB, header *types.Header) error {
 	if p.chainConfig.IsNano(header.Number) {
 		for _, blackListAddr := range types.NanoBlackList {
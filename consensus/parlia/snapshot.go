@@ -28,11 +28,13 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 // Snapshot is the state of the validatorSet at a given point.
@@ -110,14 +112,112 @@ func (s validatorsAscending) Len() int           { return len(s) }
 func (s validatorsAscending) Less(i, j int) bool { return bytes.Compare(s[i][:], s[j][:]) < 0 }
 func (s validatorsAscending) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 
+// snapshotKey returns the database key of the snapshot of the block.
+func snapshotKey(hash common.Hash) []byte {
+	return append(rawdb.ParliaSnapshotPrefix, hash[:]...)
+}
+
+// snapshotRLP is the compact encoding of the snapshot stored in the database,
+// with the maps flattened into lists sorted by key.
+type snapshotRLP struct {
+	Number           uint64
+	Hash             common.Hash
+	EpochLength      uint64
+	BlockInterval    uint64
+	TurnLength       uint8
+	Validators       []validatorRLP
+	Recents          []recentRLP
+	RecentForkHashes []recentForkHashRLP
+	Attestation      *types.VoteData `rlp:"nil"`
+}
+
+type validatorRLP struct {
+	Address     common.Address
+	Index       uint64
+	VoteAddress types.BLSPublicKey
+}
+
+type recentRLP struct {
+	Number    uint64
+	Validator common.Address
+}
+
+type recentForkHashRLP struct {
+	Number   uint64
+	ForkHash string
+}
+
+// encodeSnapshot encodes the snapshot in the compact RLP format.
+func encodeSnapshot(s *Snapshot) ([]byte, error) {
+	enc := &snapshotRLP{
+		Number:           s.Number,
+		Hash:             s.Hash,
+		EpochLength:      s.EpochLength,
+		BlockInterval:    s.BlockInterval,
+		TurnLength:       s.TurnLength,
+		Validators:       make([]validatorRLP, 0, len(s.Validators)),
+		Recents:          make([]recentRLP, 0, len(s.Recents)),
+		RecentForkHashes: make([]recentForkHashRLP, 0, len(s.RecentForkHashes)),
+		Attestation:      s.Attestation,
+	}
+	for _, val := range s.validators() {
+		info := s.Validators[val]
+		enc.Validators = append(enc.Validators, validatorRLP{Address: val, Index: uint64(info.Index), VoteAddress: info.VoteAddress})
+	}
+	for number, val := range s.Recents {
+		enc.Recents = append(enc.Recents, recentRLP{Number: number, Validator: val})
+	}
+	sort.Slice(enc.Recents, func(i, j int) bool { return enc.Recents[i].Number < enc.Recents[j].Number })
+	for number, forkHash := range s.RecentForkHashes {
+		enc.RecentForkHashes = append(enc.RecentForkHashes, recentForkHashRLP{Number: number, ForkHash: forkHash})
+	}
+	sort.Slice(enc.RecentForkHashes, func(i, j int) bool { return enc.RecentForkHashes[i].Number < enc.RecentForkHashes[j].Number })
+	return rlp.EncodeToBytes(enc)
+}
+
+// decodeSnapshot decodes a snapshot stored in the compact RLP format, or in the
+// JSON format used by older versions.
+func decodeSnapshot(blob []byte) (*Snapshot, error) {
+	snap := new(Snapshot)
+	if len(blob) > 0 && blob[0] == '{' {
+		if err := json.Unmarshal(blob, snap); err != nil {
+			return nil, err
+		}
+		return snap, nil
+	}
+	var dec snapshotRLP
+	if err := rlp.DecodeBytes(blob, &dec); err != nil {
+		return nil, err
+	}
+	snap.Number = dec.Number
+	snap.Hash = dec.Hash
+	snap.EpochLength = dec.EpochLength
+	snap.BlockInterval = dec.BlockInterval
+	snap.TurnLength = dec.TurnLength
+	snap.Attestation = dec.Attestation
+	snap.Validators = make(map[common.Address]*ValidatorInfo, len(dec.Validators))
+	for _, val := range dec.Validators {
+		snap.Validators[val.Address] = &ValidatorInfo{Index: int(val.Index), VoteAddress: val.VoteAddress}
+	}
+	snap.Recents = make(map[uint64]common.Address, len(dec.Recents))
+	for _, recent := range dec.Recents {
+		snap.Recents[recent.Number] = recent.Validator
+	}
+	snap.RecentForkHashes = make(map[uint64]string, len(dec.RecentForkHashes))
+	for _, recent := range dec.RecentForkHashes {
+		snap.RecentForkHashes[recent.Number] = recent.ForkHash
+	}
+	return snap, nil
+}
+
 // loadSnapshot loads an existing snapshot from the database.
 func loadSnapshot(config *params.ParliaConfig, sigCache *lru.Cache[common.Hash, common.Address], db ethdb.Database, hash common.Hash, ethAPI *ethapi.BlockChainAPI) (*Snapshot, error) {
-	blob, err := db.Get(append([]byte("parlia-"), hash[:]...))
+	blob, err := db.Get(snapshotKey(hash))
 	if err != nil {
 		return nil, err
 	}
-	snap := new(Snapshot)
-	if err := json.Unmarshal(blob, snap); err != nil {
+	snap, err := decodeSnapshot(blob)
+	if err != nil {
 		return nil, err
 	}
 	if snap.EpochLength == 0 { // no EpochLength field in old snapshots
@@ -139,11 +239,88 @@ func loadSnapshot(config *params.ParliaConfig, sigCache *lru.Cache[common.Hash,
 
 // store inserts the snapshot into the database.
 func (s *Snapshot) store(db ethdb.Database) error {
-	blob, err := json.Marshal(s)
+	blob, err := encodeSnapshot(s)
 	if err != nil {
 		return err
 	}
-	return db.Put(append([]byte("parlia-"), s.Hash[:]...), blob)
+	return db.Put(snapshotKey(s.Hash), blob)
+}
+
+// PruneSnapshots deletes the checkpoint snapshots stored in the database but
+// the keep most recent ones, which are rewritten in the compact encoding if
+// stored in the legacy JSON one. It returns the number of snapshots deleted.
+func PruneSnapshots(db ethdb.KeyValueStore, keep int) (int, error) {
+	type storedSnapshot struct {
+		key    []byte
+		number uint64
+		legacy bool
+	}
+	var snaps []*storedSnapshot
+
+	it := db.NewIterator(rawdb.ParliaSnapshotPrefix, nil)
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(rawdb.ParliaSnapshotPrefix)+common.HashLength {
+			continue
+		}
+		blob := it.Value()
+		snap, err := decodeSnapshot(blob)
+		if err != nil {
+			log.Warn("Skipping undecodable parlia snapshot", "key", hex.EncodeToString(key), "err", err)
+			continue
+		}
+		snaps = append(snaps, &storedSnapshot{
+			key:    common.CopyBytes(key),
+			number: snap.Number,
+			legacy: blob[0] == '{',
+		})
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].number > snaps[j].number })
+
+	var (
+		batch   = db.NewBatch()
+		deleted int
+	)
+	for i, stored := range snaps {
+		if i < keep {
+			if !stored.legacy {
+				continue
+			}
+			blob, err := db.Get(stored.key)
+			if err != nil {
+				return deleted, err
+			}
+			snap, err := decodeSnapshot(blob)
+			if err != nil {
+				return deleted, err
+			}
+			if blob, err = encodeSnapshot(snap); err != nil {
+				return deleted, err
+			}
+			if err := batch.Put(stored.key, blob); err != nil {
+				return deleted, err
+			}
+		} else {
+			if err := batch.Delete(stored.key); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return deleted, err
+			}
+			batch.Reset()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return deleted, err
+	}
+	return deleted, nil
 }
 
 // copy creates a deep copy of the snapshot
@@ -2,12 +2,16 @@ package parlia
 
 import (
 	"bytes"
+	"encoding/json"
 	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 func TestValidatorSetSort(t *testing.T) {
@@ -21,3 +25,60 @@ func TestValidatorSetSort(t *testing.T) {
 		assert.True(t, bytes.Compare(validators[i][:], validators[i+1][:]) < 0)
 	}
 }
+
+func newTestSnapshot(number uint64) *Snapshot {
+	snap := newSnapshot(nil, nil, number, common.Hash{byte(number), byte(number >> 8)},
+		[]common.Address{{0x2}, {0x1}}, []types.BLSPublicKey{{0x2}, {0x1}}, nil)
+	snap.Recents[number] = common.Address{0x1}
+	snap.RecentForkHashes[number] = "00000000"
+	snap.Attestation = &types.VoteData{SourceNumber: number - 2, TargetNumber: number - 1}
+	return snap
+}
+
+func TestSnapshotEncoding(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+
+	// The compact encoding round trips.
+	snap := newTestSnapshot(1024)
+	require.NoError(t, snap.store(db))
+	loaded, err := loadSnapshot(nil, nil, db, snap.Hash, nil)
+	require.NoError(t, err)
+	assert.Equal(t, snap, loaded)
+
+	// The snapshots stored in JSON by older versions still load.
+	legacy := newTestSnapshot(2048)
+	blob, err := json.Marshal(legacy)
+	require.NoError(t, err)
+	require.NoError(t, db.Put(snapshotKey(legacy.Hash), blob))
+	loaded, err = loadSnapshot(nil, nil, db, legacy.Hash, nil)
+	require.NoError(t, err)
+	assert.Equal(t, legacy, loaded)
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	for i := uint64(1); i <= 5; i++ {
+		snap := newTestSnapshot(i * checkpointInterval)
+		if i%2 == 0 {
+			blob, err := json.Marshal(snap)
+			require.NoError(t, err)
+			require.NoError(t, db.Put(snapshotKey(snap.Hash), blob))
+		} else {
+			require.NoError(t, snap.store(db))
+		}
+	}
+	deleted, err := PruneSnapshots(db, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	for i := uint64(1); i <= 5; i++ {
+		snap := newTestSnapshot(i * checkpointInterval)
+		blob, err := db.Get(snapshotKey(snap.Hash))
+		if i <= 2 {
+			assert.Error(t, err, "snapshot %d not pruned", snap.Number)
+			continue
+		}
+		require.NoError(t, err)
+		assert.NotEqual(t, byte('{'), blob[0], "snapshot %d not rewritten in the compact encoding", snap.Number)
+	}
+}
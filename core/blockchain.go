@@ -104,6 +104,7 @@ var (
 
 	errInsertionInterrupted = errors.New("insertion is interrupted")
 	errChainStopped         = errors.New("blockchain is stopped")
+	errReplicaMode          = errors.New("block processing is disabled in replica mode")
 	errInvalidOldChain      = errors.New("invalid old chain")
 	errInvalidNewChain      = errors.New("invalid new chain")
 )
@@ -167,6 +168,7 @@ type CacheConfig struct {
 	TriesInMemory       uint64        // How many tries keeps in memory
 	NoTries             bool          // Insecure settings. Do not have any tries in databases if enabled.
 	StateHistory        uint64        // Number of blocks from head whose state histories are reserved.
+	StateIndexing       bool          // Whether to index the state histories for serving historic states.
 	StateScheme         string        // Scheme used to store ethereum states and merkle tree nodes on top
 	PathSyncFlush       bool          // Whether sync flush the trienodebuffer of pathdb to disk.
 	JournalFilePath     string
@@ -174,6 +176,8 @@ type CacheConfig struct {
 
 	SnapshotNoBuild bool // Whether the background generation is allowed
 	SnapshotWait    bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
+
+	Replica bool // Whether the database is replicated from another node, disabling the block processing
 }
 
 // triedbConfig derives the configures for trie database.
@@ -193,6 +197,7 @@ func (c *CacheConfig) triedbConfig(isVerkle bool) *triedb.Config {
 		config.PathDB = &pathdb.Config{
 			SyncFlush:       c.PathSyncFlush,
 			StateHistory:    c.StateHistory,
+			StateIndexing:   c.StateIndexing,
 			CleanCacheSize:  c.TrieCleanLimit * 1024 * 1024,
 			WriteBufferSize: c.TrieDirtyLimit * 1024 * 1024,
 			JournalFilePath: c.JournalFilePath,
@@ -454,7 +459,11 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 				}
 				log.Debug("Head state missing, check recoverable", "disk root", diskRoot, "recoverable", recoverable)
 			}
-			if diskRoot != (common.Hash{}) {
+			if bc.cacheConfig.Replica {
+				// The state is replicated from the primary node, which persists
+				// it later than the head block marker.
+				log.Info("Head state missing, wait for replication", "number", head.Number, "hash", head.Hash())
+			} else if diskRoot != (common.Hash{}) {
 				log.Warn("Head state missing, repairing", "number", head.Number, "hash", head.Hash(), "diskRoot", diskRoot)
 
 				snapDisk, err := bc.setHeadBeyondRoot(head.Number.Uint64(), 0, diskRoot, true)
@@ -738,6 +747,79 @@ func (bc *BlockChain) loadLastState() error {
 	return nil
 }
 
+// ReloadHead reloads the chain head from the database, along with the state
+// layers, after the database was modified externally by the primary node the
+// chain is replicated from. The replicated head block may be ahead of the state
+// persisted by the primary, in which case the chain advances only up to the
+// newest block whose state is available.
+func (bc *BlockChain) ReloadHead() error {
+	if !bc.chainmu.TryLock() {
+		return errChainStopped
+	}
+	defer bc.chainmu.Unlock()
+
+	hash := rawdb.ReadHeadBlockHash(bc.db)
+	current := bc.CurrentBlock()
+	if hash == (common.Hash{}) || hash == current.Hash() {
+		return nil
+	}
+	header := bc.GetHeaderByHash(hash)
+	if header == nil {
+		return fmt.Errorf("head block missing: %x", hash)
+	}
+	// Drop the in-memory state layers, they are superseded by the replicated state
+	if bc.triedb.Scheme() == rawdb.PathScheme {
+		if err := bc.triedb.Reload(); err != nil {
+			return err
+		}
+	}
+	if bc.snaps != nil {
+		if err := bc.snaps.Reload(); err != nil {
+			log.Warn("Failed to reload state snapshot", "err", err)
+		}
+	}
+	for !bc.HasState(header.Root) {
+		if header.Number.Sign() == 0 {
+			return fmt.Errorf("no state available for head block %x", hash)
+		}
+		header = bc.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+		if header == nil {
+			return fmt.Errorf("ancestor of head block missing: %x", hash)
+		}
+	}
+	if header.Hash() != hash {
+		log.Debug("Replicated head block ahead of persisted state", "number", header.Number, "head", hash)
+	}
+	if header.Hash() == current.Hash() {
+		return nil
+	}
+	if header.ParentHash != current.Hash() {
+		bc.txLookupCache.Purge()
+	}
+	headHeader := header
+	if head := rawdb.ReadHeadHeaderHash(bc.db); head != (common.Hash{}) {
+		if h := bc.GetHeaderByHash(head); h != nil {
+			headHeader = h
+		}
+	}
+	bc.hc.SetCurrentHeader(headHeader)
+	bc.currentBlock.Store(header)
+	headBlockGauge.Update(int64(header.Number.Uint64()))
+
+	bc.currentSnapBlock.Store(header)
+	if head := rawdb.ReadHeadFastBlockHash(bc.db); head != (common.Hash{}) {
+		if h := bc.GetHeaderByHash(head); h != nil {
+			bc.currentSnapBlock.Store(h)
+		}
+	}
+	headFastBlockGauge.Update(int64(bc.CurrentSnapBlock().Number.Uint64()))
+
+	log.Debug("Reloaded replicated chain head", "number", header.Number, "hash", header.Hash())
+	bc.chainFeed.Send(ChainEvent{Header: header})
+	bc.chainHeadFeed.Send(ChainHeadEvent{Header: header})
+	return nil
+}
+
 // SetHead rewinds the local chain to a new head. Depending on whether the node
 // was snap synced or full synced and in which state, the method will try to
 // delete minimal data from disk whilst retaining chain consistency.
@@ -1423,6 +1505,9 @@ const (
 // InsertReceiptChain attempts to complete an already existing header chain with
 // transaction and receipt data.
 func (bc *BlockChain) InsertReceiptChain(blockChain types.Blocks, receiptChain []types.Receipts, ancientLimit uint64) (int, error) {
+	if bc.cacheConfig.Replica {
+		return 0, errReplicaMode
+	}
 	// We don't require the chainMu here since we want to maximize the
 	// concurrency of header insertion and receipt insertion.
 	bc.wg.Add(1)
@@ -1844,6 +1929,9 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 // WriteBlockAndSetHead writes the given block and all associated state to the database,
 // and applies the block as the new chain head.
 func (bc *BlockChain) WriteBlockAndSetHead(block *types.Block, receipts []*types.Receipt, logs []*types.Log, state *state.StateDB, sealedBlockSender *event.TypeMux) (status WriteStatus, err error) {
+	if bc.cacheConfig.Replica {
+		return NonStatTy, errReplicaMode
+	}
 	if !bc.chainmu.TryLock() {
 		return NonStatTy, errChainStopped
 	}
@@ -1947,6 +2035,9 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 	if len(chain) == 0 {
 		return 0, nil
 	}
+	if bc.cacheConfig.Replica {
+		return 0, errReplicaMode
+	}
 	bc.blockProcFeed.Send(true)
 	defer bc.blockProcFeed.Send(false)
 
@@ -2847,6 +2938,9 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Header) error
 // updating. It relies on the additional SetCanonical call to finalize the entire
 // procedure.
 func (bc *BlockChain) InsertBlockWithoutSetHead(block *types.Block, makeWitness bool) (*stateless.Witness, error) {
+	if bc.cacheConfig.Replica {
+		return nil, errReplicaMode
+	}
 	if !bc.chainmu.TryLock() {
 		return nil, errChainStopped
 	}
@@ -2860,6 +2954,9 @@ func (bc *BlockChain) InsertBlockWithoutSetHead(block *types.Block, makeWitness
 // block. It's possible that the state of the new head is missing, and it will
 // be recovered in this function as well.
 func (bc *BlockChain) SetCanonical(head *types.Block) (common.Hash, error) {
+	if bc.cacheConfig.Replica {
+		return common.Hash{}, errReplicaMode
+	}
 	if !bc.chainmu.TryLock() {
 		return common.Hash{}, errChainStopped
 	}
@@ -3028,6 +3125,9 @@ func (bc *BlockChain) InsertHeaderChain(chain []*types.Header) (int, error) {
 	if len(chain) == 0 {
 		return 0, nil
 	}
+	if bc.cacheConfig.Replica {
+		return 0, errReplicaMode
+	}
 	start := time.Now()
 	if i, err := bc.hc.ValidateHeaderChain(chain); err != nil {
 		return i, err
@@ -3048,7 +3148,7 @@ func (bc *BlockChain) HeadChain() *HeaderChain {
 func (bc *BlockChain) TriesInMemory() uint64 { return bc.triesInMemory }
 
 func EnableDoubleSignChecker(bc *BlockChain) (*BlockChain, error) {
-	bc.doubleSignMonitor = monitor.NewDoubleSignMonitor()
+	bc.doubleSignMonitor = monitor.NewDoubleSignMonitor(bc.db)
 	return bc, nil
 }
 
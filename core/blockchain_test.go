@@ -4488,3 +4488,93 @@ func TestEIP7702(t *testing.T) {
 		t.Fatalf("addr2 storage wrong: expected %d, got %d", fortyTwo, actual)
 	}
 }
+
+// Tests that a chain replicated from another node follows the replicated head
+// and rejects the block processing.
+func TestReloadHead(t *testing.T) {
+	testReloadHead(t, rawdb.HashScheme)
+	testReloadHead(t, rawdb.PathScheme)
+}
+
+func testReloadHead(t *testing.T, scheme string) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(100000000000000000)
+		gspec   = &Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{address: {Balance: funds}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+		engine = ethash.NewFaker()
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 12, func(i int, gen *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(address), common.Address{0x00}, big.NewInt(1000), params.TxGas, gen.header.BaseFee, nil), signer, key)
+		if err != nil {
+			panic(err)
+		}
+		gen.AddTx(tx)
+	})
+	ahead := blocks[10:] // Blocks replicated without their state
+	blocks = blocks[:10]
+
+	// The primary chain writes the database the replica chain is opened on.
+	// The state histories are not kept, they are owned by a single instance.
+	diskdb := rawdb.NewMemoryDatabase()
+	defer diskdb.Close()
+
+	config := DefaultCacheConfigWithScheme(scheme)
+	config.TrieDirtyDisabled = true
+	primary, err := NewBlockChain(diskdb, config, gspec, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create primary chain: %v", err)
+	}
+	defer primary.Stop()
+
+	if _, err := primary.InsertChain(blocks[:5]); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	replicaConfig := DefaultCacheConfigWithScheme(scheme)
+	replicaConfig.Replica = true
+	replicaConfig.SnapshotNoBuild = true
+	replica, err := NewBlockChain(diskdb, replicaConfig, gspec, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create replica chain: %v", err)
+	}
+	defer replica.Stop()
+
+	if _, err := replica.InsertChain(blocks[5:]); !errors.Is(err, errReplicaMode) {
+		t.Fatalf("block insertion error mismatch: have %v, want %v", err, errReplicaMode)
+	}
+	if _, err := primary.InsertChain(blocks[5:]); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	head := blocks[len(blocks)-1]
+	if err := primary.TrieDB().Commit(head.Root(), false); err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	if err := replica.ReloadHead(); err != nil {
+		t.Fatalf("failed to reload head: %v", err)
+	}
+	if current := replica.CurrentBlock(); current.Hash() != head.Hash() {
+		t.Fatalf("head mismatch: have %d, want %d", current.Number, head.Number())
+	}
+	if !replica.HasState(head.Root()) {
+		t.Fatal("replicated head state missing")
+	}
+	// Replicate head blocks ahead of the persisted state, the replica must stay
+	// at the newest block with state.
+	for _, block := range ahead {
+		rawdb.WriteBlock(diskdb, block)
+		rawdb.WriteCanonicalHash(diskdb, block.Hash(), block.NumberU64())
+	}
+	rawdb.WriteHeadBlockHash(diskdb, ahead[len(ahead)-1].Hash())
+
+	if err := replica.ReloadHead(); err != nil {
+		t.Fatalf("failed to reload head: %v", err)
+	}
+	if current := replica.CurrentBlock(); current.Hash() != head.Hash() {
+		t.Fatalf("head mismatch: have %d, want %d", current.Number, head.Number())
+	}
+}
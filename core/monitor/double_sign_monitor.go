@@ -2,10 +2,13 @@ package monitor
 
 import (
 	"bytes"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/prque"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 )
@@ -14,14 +17,18 @@ const (
 	MaxCacheHeader = 100
 )
 
-func NewDoubleSignMonitor() *DoubleSignMonitor {
+// NewDoubleSignMonitor creates a double sign monitor. The detected evidence is
+// persisted into db if it's not nil.
+func NewDoubleSignMonitor(db ethdb.KeyValueWriter) *DoubleSignMonitor {
 	return &DoubleSignMonitor{
+		db:            db,
 		headerNumbers: prque.New[int64, *types.Header](nil),
 		headers:       make(map[uint64]*types.Header, MaxCacheHeader),
 	}
 }
 
 type DoubleSignMonitor struct {
+	db            ethdb.KeyValueWriter
 	headerNumbers *prque.Prque[int64, *types.Header]
 	headers       map[uint64]*types.Header
 }
@@ -90,13 +97,24 @@ func (m *DoubleSignMonitor) Verify(h *types.Header) {
 		h1Bytes, err := rlp.EncodeToBytes(h)
 		if err != nil {
 			log.Error("encode header error", "err", err, "hash", h.Hash())
+			return
 		}
 		h2Bytes, err := rlp.EncodeToBytes(h2)
 		if err != nil {
-			log.Error("encode header error", "err", err, "hash", h.Hash())
+			log.Error("encode header error", "err", err, "hash", h2.Hash())
+			return
 		}
 		log.Warn("double sign header content",
 			"header1", hexutil.Encode(h1Bytes),
 			"header2", hexutil.Encode(h2Bytes))
+		if m.db != nil {
+			rawdb.WriteDoubleSignEvidence(m.db, &types.DoubleSignEvidence{
+				Validator:  h.Coinbase,
+				Number:     h.Number.Uint64(),
+				Header1:    h1Bytes,
+				Header2:    h2Bytes,
+				DetectedAt: uint64(time.Now().Unix()),
+			})
+		}
 	}
 }
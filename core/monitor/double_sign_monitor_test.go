@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoubleSignMonitorEvidence(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	monitor := NewDoubleSignMonitor(db)
+
+	validator := common.Address{0x1}
+	newHeader := func(extra byte) *types.Header {
+		return &types.Header{
+			ParentHash: common.Hash{0x2},
+			Number:     big.NewInt(100),
+			Coinbase:   validator,
+			Difficulty: big.NewInt(2),
+			Extra:      []byte{extra},
+		}
+	}
+	h1, h2 := newHeader(1), newHeader(2)
+	monitor.Verify(h1)
+	assert.Empty(t, rawdb.ReadDoubleSignEvidences(db, 0, 1000))
+
+	// The same header again is not a double sign
+	monitor.Verify(h1)
+	assert.Empty(t, rawdb.ReadDoubleSignEvidences(db, 0, 1000))
+
+	// Detecting the same conflicting pair twice must not duplicate the evidence
+	monitor.Verify(h2)
+	monitor.Verify(h2)
+	evidences := rawdb.ReadDoubleSignEvidences(db, 100, 100)
+	assert.Len(t, evidences, 1)
+	assert.Equal(t, validator, evidences[0].Validator)
+	assert.Equal(t, uint64(100), evidences[0].Number)
+
+	var decoded types.Header
+	assert.NoError(t, rlp.DecodeBytes(evidences[0].Header2, &decoded))
+	assert.Equal(t, h1.Hash(), decoded.Hash())
+	assert.Empty(t, rawdb.ReadDoubleSignEvidences(db, 101, 1000))
+}
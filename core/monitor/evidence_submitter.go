@@ -0,0 +1,222 @@
+package monitor
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/systemcontracts"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// evidenceGasLimit is the gas limit of the evidence submission transactions.
+	evidenceGasLimit = 800000
+
+	// evidenceInclusionTimeout is the time to wait for a submission to be included
+	// before it's considered lost and the evidence is submitted again.
+	evidenceInclusionTimeout = 2 * time.Minute
+)
+
+var (
+	evidenceSubmittedCounter = metrics.NewRegisteredCounter("monitor/maliciousVote/submitted", nil)
+	evidenceFailedCounter    = metrics.NewRegisteredCounter("monitor/maliciousVote/submitFailed", nil)
+	evidenceIncludedCounter  = metrics.NewRegisteredCounter("monitor/maliciousVote/included", nil)
+)
+
+// slashIndicatorVoteData is the Go binding of SlashIndicator.VoteData.
+type slashIndicatorVoteData struct {
+	SrcNum  *big.Int
+	SrcHash [32]byte
+	TarNum  *big.Int
+	TarHash [32]byte
+	Sig     []byte
+}
+
+// slashIndicatorFinalityEvidence is the Go binding of SlashIndicator.FinalityEvidence.
+type slashIndicatorFinalityEvidence struct {
+	VoteA    slashIndicatorVoteData
+	VoteB    slashIndicatorVoteData
+	VoteAddr []byte
+}
+
+// TxPool is the subset of the transaction pool used to send the evidence.
+type TxPool interface {
+	Has(hash common.Hash) bool
+	Nonce(addr common.Address) uint64
+	Add(txs []*types.Transaction, sync bool) []error
+}
+
+// SignerTxFn is a signer callback function to request a transaction to be signed
+// by the configured account.
+type SignerTxFn func(accounts.Account, *types.Transaction, *big.Int) (*types.Transaction, error)
+
+// EvidenceSubmitter turns the malicious votes detected by MaliciousVoteMonitor into
+// submitFinalityViolationEvidence transactions and sends them through the local
+// transaction pool. Every evidence is persisted before submission and tracked
+// until its transaction is included in the chain, the submission is repeated if
+// the transaction gets lost, including across restarts.
+type EvidenceSubmitter struct {
+	db       ethdb.Database
+	txPool   TxPool
+	chainID  *big.Int
+	gasPrice *big.Int
+	account  accounts.Account
+	signTxFn SignerTxFn
+	abi      abi.ABI
+
+	lock sync.Mutex
+}
+
+// NewEvidenceSubmitter creates an evidence submitter sending transactions from account.
+func NewEvidenceSubmitter(db ethdb.Database, txPool TxPool, chainID, gasPrice *big.Int, account common.Address, signTxFn SignerTxFn) (*EvidenceSubmitter, error) {
+	parsed, err := abi.JSON(strings.NewReader(systemcontracts.SlashIndicatorFinalityABI))
+	if err != nil {
+		return nil, err
+	}
+	return &EvidenceSubmitter{
+		db:       db,
+		txPool:   txPool,
+		chainID:  chainID,
+		gasPrice: gasPrice,
+		account:  accounts.Account{Address: account},
+		signTxFn: signTxFn,
+		abi:      parsed,
+	}, nil
+}
+
+// ResubmitPending retries the evidence that failed to be submitted or whose
+// submission was not included in time, including the evidence left over from
+// before a restart. Evidence that is out of the slash scope of the contract is
+// discarded.
+func (s *EvidenceSubmitter) ResubmitPending(pendingBlockNumber uint64) {
+	for _, evidence := range rawdb.ReadAllMaliciousVoteEvidences(s.db) {
+		if evidence.VoteA.Data == nil || evidence.VoteB.Data == nil ||
+			evidence.VoteA.Data.TargetNumber+maliciousVoteSlashScope <= pendingBlockNumber ||
+			evidence.VoteB.Data.TargetNumber+maliciousVoteSlashScope <= pendingBlockNumber {
+			if !evidence.Included {
+				log.Warn("Discard expired malicious vote evidence", "evidence", evidence.Hash())
+			}
+			rawdb.DeleteMaliciousVoteEvidence(s.db, evidence.Hash())
+			continue
+		}
+		if !evidence.Included {
+			s.Submit(evidence.VoteA, evidence.VoteB)
+		}
+	}
+}
+
+// Submit sends the evidence of the conflicting votes to the slash indicator
+// contract, unless it has been included already or its last submission is
+// still pending.
+func (s *EvidenceSubmitter) Submit(voteA, voteB *types.VoteEnvelope) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	evidence := &types.MaliciousVoteEvidence{VoteA: voteA, VoteB: voteB}
+	hash := evidence.Hash()
+	if stored := rawdb.ReadMaliciousVoteEvidence(s.db, hash); stored != nil {
+		evidence = stored
+		if s.included(evidence) {
+			log.Debug("Malicious vote evidence already included", "evidence", hash, "tx", evidence.TxHash)
+			return
+		}
+		if s.pending(evidence) {
+			log.Debug("Malicious vote evidence pending inclusion", "evidence", hash, "tx", evidence.TxHash)
+			return
+		}
+		if evidence.TxHash != (common.Hash{}) {
+			log.Warn("Malicious vote evidence submission lost, resubmitting", "evidence", hash, "tx", evidence.TxHash)
+		}
+	} else {
+		rawdb.WriteMaliciousVoteEvidence(s.db, evidence)
+	}
+
+	tx, err := s.submit(evidence.VoteA, evidence.VoteB)
+	if err != nil {
+		evidenceFailedCounter.Inc(1)
+		log.Error("Failed to submit malicious vote evidence", "evidence", hash, "err", err)
+		return
+	}
+	evidence.TxHash = tx.Hash()
+	evidence.SubmittedAt = uint64(time.Now().Unix())
+	rawdb.WriteMaliciousVoteEvidence(s.db, evidence)
+	evidenceSubmittedCounter.Inc(1)
+	log.Info("Submitted malicious vote evidence", "evidence", hash, "voteAddress", voteA.VoteAddress, "tx", tx.Hash())
+}
+
+// included reports whether the last submission of the evidence is included in
+// the chain, marking the evidence as such the first time it's noticed.
+func (s *EvidenceSubmitter) included(evidence *types.MaliciousVoteEvidence) bool {
+	if evidence.Included {
+		return true
+	}
+	if evidence.TxHash == (common.Hash{}) || rawdb.ReadTxLookupEntry(s.db, evidence.TxHash) == nil {
+		return false
+	}
+	evidence.Included = true
+	rawdb.WriteMaliciousVoteEvidence(s.db, evidence)
+	evidenceIncludedCounter.Inc(1)
+	log.Info("Malicious vote evidence included", "evidence", evidence.Hash(), "tx", evidence.TxHash)
+	return true
+}
+
+// pending reports whether the last submission of the evidence may still be
+// included, i.e. it's in the transaction pool or was sent recently enough for
+// the transaction indexing to lag behind.
+func (s *EvidenceSubmitter) pending(evidence *types.MaliciousVoteEvidence) bool {
+	if evidence.TxHash == (common.Hash{}) {
+		return false
+	}
+	if s.txPool.Has(evidence.TxHash) {
+		return true
+	}
+	return time.Since(time.Unix(int64(evidence.SubmittedAt), 0)) < evidenceInclusionTimeout
+}
+
+func (s *EvidenceSubmitter) submit(voteA, voteB *types.VoteEnvelope) (*types.Transaction, error) {
+	data, err := s.packEvidence(voteA, voteB)
+	if err != nil {
+		return nil, err
+	}
+	contract := common.HexToAddress(systemcontracts.SlashContract)
+	tx := types.NewTransaction(s.txPool.Nonce(s.account.Address), contract, common.Big0, evidenceGasLimit, s.gasPrice, data)
+	signed, err := s.signTxFn(s.account, tx, s.chainID)
+	if err != nil {
+		return nil, err
+	}
+	if errs := s.txPool.Add([]*types.Transaction{signed}, true); len(errs) > 0 && errs[0] != nil {
+		return nil, errs[0]
+	}
+	return signed, nil
+}
+
+// packEvidence encodes the call to submitFinalityViolationEvidence.
+func (s *EvidenceSubmitter) packEvidence(voteA, voteB *types.VoteEnvelope) ([]byte, error) {
+	if voteA.Data == nil || voteB.Data == nil || voteA.VoteAddress != voteB.VoteAddress {
+		return nil, errors.New("invalid malicious vote evidence")
+	}
+	toVoteData := func(vote *types.VoteEnvelope) slashIndicatorVoteData {
+		return slashIndicatorVoteData{
+			SrcNum:  new(big.Int).SetUint64(vote.Data.SourceNumber),
+			SrcHash: vote.Data.SourceHash,
+			TarNum:  new(big.Int).SetUint64(vote.Data.TargetNumber),
+			TarHash: vote.Data.TargetHash,
+			Sig:     vote.Signature[:],
+		}
+	}
+	return s.abi.Pack("submitFinalityViolationEvidence", slashIndicatorFinalityEvidence{
+		VoteA:    toVoteData(voteA),
+		VoteB:    toVoteData(voteB),
+		VoteAddr: voteA.VoteAddress[:],
+	})
+}
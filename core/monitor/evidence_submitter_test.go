@@ -0,0 +1,165 @@
+package monitor
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTxPool struct {
+	txs     []*types.Transaction
+	dropped map[common.Hash]bool
+}
+
+func (p *mockTxPool) Has(hash common.Hash) bool {
+	for _, tx := range p.txs {
+		if tx.Hash() == hash {
+			return !p.dropped[hash]
+		}
+	}
+	return false
+}
+
+func (p *mockTxPool) Nonce(addr common.Address) uint64 { return uint64(len(p.txs)) }
+
+func (p *mockTxPool) Add(txs []*types.Transaction, sync bool) []error {
+	p.txs = append(p.txs, txs...)
+	return make([]error, len(txs))
+}
+
+func TestEvidenceSubmitter(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	chainID := big.NewInt(56)
+	signer := types.LatestSignerForChainID(chainID)
+	signTxFn := func(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+		return types.SignTx(tx, signer, key)
+	}
+	db := rawdb.NewMemoryDatabase()
+	pool := new(mockTxPool)
+	submitter, err := NewEvidenceSubmitter(db, pool, chainID, big.NewInt(1), crypto.PubkeyToAddress(key.PublicKey), signTxFn)
+	require.NoError(t, err)
+
+	m := NewMaliciousVoteMonitor()
+	m.SetEvidenceSubmitter(submitter)
+
+	voteAddress := types.BLSPublicKey{0x1}
+	newVote := func(target common.Hash) *types.VoteEnvelope {
+		return &types.VoteEnvelope{
+			VoteAddress: voteAddress,
+			Data:        &types.VoteData{SourceNumber: 98, TargetNumber: 99, TargetHash: target},
+		}
+	}
+	vote1, vote2 := newVote(common.Hash{0x1}), newVote(common.Hash{0x2})
+	assert.False(t, m.ConflictDetect(vote1, 100))
+	assert.True(t, m.ConflictDetect(vote2, 100))
+	require.Len(t, pool.txs, 1)
+
+	tx := pool.txs[0]
+	assert.Equal(t, common.HexToAddress("0x0000000000000000000000000000000000001001"), *tx.To())
+	assert.Equal(t, submitter.abi.Methods["submitFinalityViolationEvidence"].ID, tx.Data()[:4])
+
+	// The same evidence is submitted only once, even after a restart.
+	submitter, err = NewEvidenceSubmitter(db, pool, chainID, big.NewInt(1), crypto.PubkeyToAddress(key.PublicKey), signTxFn)
+	require.NoError(t, err)
+	submitter.Submit(vote2, vote1)
+	submitter.ResubmitPending(100)
+	assert.Len(t, pool.txs, 1)
+
+	evidences := rawdb.ReadAllMaliciousVoteEvidences(db)
+	require.Len(t, evidences, 1)
+	assert.Equal(t, tx.Hash(), evidences[0].TxHash)
+	assert.False(t, evidences[0].Included)
+
+	// Once included, the evidence is not submitted again even if it's aged
+	rawdb.WriteTxLookupEntries(db, 100, []common.Hash{tx.Hash()})
+	submitter.ResubmitPending(101)
+	evidences = rawdb.ReadAllMaliciousVoteEvidences(db)
+	require.Len(t, evidences, 1)
+	assert.True(t, evidences[0].Included)
+
+	pool.dropped = map[common.Hash]bool{tx.Hash(): true}
+	evidences[0].SubmittedAt = 0
+	rawdb.WriteMaliciousVoteEvidence(db, evidences[0])
+	submitter.ResubmitPending(102)
+	assert.Len(t, pool.txs, 1)
+
+	// Included evidence is dropped once out of the slash scope
+	submitter.ResubmitPending(99 + maliciousVoteSlashScope)
+	assert.Empty(t, rawdb.ReadAllMaliciousVoteEvidences(db))
+}
+
+func TestEvidenceSubmitterLost(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	pool := &mockTxPool{dropped: make(map[common.Hash]bool)}
+	signTxFn := func(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+		return tx, nil
+	}
+	submitter, err := NewEvidenceSubmitter(db, pool, big.NewInt(56), big.NewInt(1), common.Address{0x1}, signTxFn)
+	require.NoError(t, err)
+
+	vote1 := &types.VoteEnvelope{Data: &types.VoteData{TargetNumber: 99, TargetHash: common.Hash{0x1}}}
+	vote2 := &types.VoteEnvelope{Data: &types.VoteData{TargetNumber: 99, TargetHash: common.Hash{0x2}}}
+	submitter.Submit(vote1, vote2)
+	require.Len(t, pool.txs, 1)
+
+	// A dropped submission is not retried before the inclusion timeout
+	pool.dropped[pool.txs[0].Hash()] = true
+	submitter.ResubmitPending(100)
+	assert.Len(t, pool.txs, 1)
+
+	evidence := rawdb.ReadAllMaliciousVoteEvidences(db)[0]
+	evidence.SubmittedAt = uint64(time.Now().Add(-evidenceInclusionTimeout).Unix())
+	rawdb.WriteMaliciousVoteEvidence(db, evidence)
+	submitter.ResubmitPending(100)
+	require.Len(t, pool.txs, 2)
+
+	evidence = rawdb.ReadAllMaliciousVoteEvidences(db)[0]
+	assert.Equal(t, pool.txs[1].Hash(), evidence.TxHash)
+
+	// A submission still in the pool is waited for regardless of its age
+	evidence.SubmittedAt = 0
+	rawdb.WriteMaliciousVoteEvidence(db, evidence)
+	submitter.ResubmitPending(100)
+	assert.Len(t, pool.txs, 2)
+}
+
+func TestEvidenceSubmitterResubmit(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	pool := new(mockTxPool)
+	locked := true
+	signTxFn := func(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+		if locked {
+			return nil, errors.New("account locked")
+		}
+		return tx, nil
+	}
+	submitter, err := NewEvidenceSubmitter(db, pool, big.NewInt(56), big.NewInt(1), common.Address{0x1}, signTxFn)
+	require.NoError(t, err)
+
+	vote1 := &types.VoteEnvelope{Data: &types.VoteData{TargetNumber: 99, TargetHash: common.Hash{0x1}}}
+	vote2 := &types.VoteEnvelope{Data: &types.VoteData{TargetNumber: 99, TargetHash: common.Hash{0x2}}}
+	submitter.Submit(vote1, vote2)
+	assert.Empty(t, pool.txs)
+	require.Len(t, rawdb.ReadAllMaliciousVoteEvidences(db), 1)
+
+	locked = false
+	submitter.ResubmitPending(100)
+	assert.Len(t, pool.txs, 1)
+
+	// Expired pending evidence is discarded
+	vote3 := &types.VoteEnvelope{Data: &types.VoteData{TargetNumber: 10, TargetHash: common.Hash{0x3}}}
+	locked = true
+	submitter.Submit(vote1, vote3)
+	require.Len(t, rawdb.ReadAllMaliciousVoteEvidences(db), 2)
+	submitter.ResubmitPending(10 + maliciousVoteSlashScope)
+	assert.Len(t, rawdb.ReadAllMaliciousVoteEvidences(db), 1)
+}
@@ -23,9 +23,10 @@ var (
 
 // two purposes
 // 1. monitor whether there are bugs in the voting mechanism, so add metrics to observe it.
-// 2. do malicious vote slashing. TODO
+// 2. do malicious vote slashing, if an evidence submitter is set.
 type MaliciousVoteMonitor struct {
-	curVotes map[types.BLSPublicKey]*lru.Cache[uint64, *types.VoteEnvelope]
+	curVotes  map[types.BLSPublicKey]*lru.Cache[uint64, *types.VoteEnvelope]
+	submitter *EvidenceSubmitter
 }
 
 func NewMaliciousVoteMonitor() *MaliciousVoteMonitor {
@@ -34,6 +35,20 @@ func NewMaliciousVoteMonitor() *MaliciousVoteMonitor {
 	}
 }
 
+// SetEvidenceSubmitter enables submitting the detected malicious votes to the
+// slash indicator contract.
+func (m *MaliciousVoteMonitor) SetEvidenceSubmitter(submitter *EvidenceSubmitter) {
+	m.submitter = submitter
+}
+
+// ResubmitPending retries the evidence that failed to be submitted. It's a noop
+// if no evidence submitter is set.
+func (m *MaliciousVoteMonitor) ResubmitPending(pendingBlockNumber uint64) {
+	if m.submitter != nil {
+		m.submitter.ResubmitPending(pendingBlockNumber)
+	}
+}
+
 func (m *MaliciousVoteMonitor) ConflictDetect(newVote *types.VoteEnvelope, pendingBlockNumber uint64) bool {
 	// get votes for specified VoteAddress
 	if _, ok := m.curVotes[newVote.VoteAddress]; !ok {
@@ -81,6 +96,9 @@ func (m *MaliciousVoteMonitor) ConflictDetect(newVote *types.VoteEnvelope, pendi
 				} else {
 					log.Warn("MaliciousVote, construct evidence failed")
 				}
+				if m.submitter != nil {
+					m.submitter.Submit(voteEnvelope, newVote)
+				}
 				return true
 			}
 		}
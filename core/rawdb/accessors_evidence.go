@@ -0,0 +1,113 @@
+package rawdb
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// WriteDoubleSignEvidence stores a pair of conflicting headers. The headers are
+// keyed by their hashes in ascending order, so that detecting the same pair twice
+// does not create duplicate entries.
+func WriteDoubleSignEvidence(db ethdb.KeyValueWriter, evidence *types.DoubleSignEvidence) {
+	hash1, hash2 := crypto.Keccak256Hash(evidence.Header1), crypto.Keccak256Hash(evidence.Header2)
+	if bytes.Compare(hash1[:], hash2[:]) > 0 {
+		hash1, hash2 = hash2, hash1
+	}
+	data, err := rlp.EncodeToBytes(evidence)
+	if err != nil {
+		log.Crit("Failed to encode double sign evidence", "err", err)
+	}
+	if err := db.Put(doubleSignEvidenceKey(evidence.Number, hash1, hash2), data); err != nil {
+		log.Crit("Failed to store double sign evidence", "err", err)
+	}
+}
+
+// ReadDoubleSignEvidences retrieves all the double sign evidence detected at the
+// given height range. Both limits are inclusive.
+func ReadDoubleSignEvidences(db ethdb.Iteratee, first, last uint64) []*types.DoubleSignEvidence {
+	var (
+		keyLength = len(DoubleSignEvidencePrefix) + 8 + 2*32
+		evidences []*types.DoubleSignEvidence
+		it        = db.NewIterator(DoubleSignEvidencePrefix, encodeBlockNumber(first))
+	)
+	defer it.Release()
+	for it.Next() {
+		key := it.Key()
+		if len(key) != keyLength {
+			continue
+		}
+		num := binary.BigEndian.Uint64(key[len(DoubleSignEvidencePrefix) : len(DoubleSignEvidencePrefix)+8])
+		if num > last {
+			break
+		}
+		evidence := new(types.DoubleSignEvidence)
+		if err := rlp.DecodeBytes(it.Value(), evidence); err != nil {
+			log.Error("Invalid double sign evidence RLP", "number", num, "err", err)
+			continue
+		}
+		evidences = append(evidences, evidence)
+	}
+	return evidences
+}
+
+// ReadMaliciousVoteEvidence retrieves the malicious vote evidence with the given hash.
+func ReadMaliciousVoteEvidence(db ethdb.KeyValueReader, hash common.Hash) *types.MaliciousVoteEvidence {
+	data, _ := db.Get(maliciousVoteEvidenceKey(hash))
+	if len(data) == 0 {
+		return nil
+	}
+	evidence := new(types.MaliciousVoteEvidence)
+	if err := rlp.DecodeBytes(data, evidence); err != nil {
+		log.Error("Invalid malicious vote evidence RLP", "hash", hash, "err", err)
+		return nil
+	}
+	return evidence
+}
+
+// ReadAllMaliciousVoteEvidences retrieves all the malicious vote evidence stored
+// in the database, both submitted and pending ones.
+func ReadAllMaliciousVoteEvidences(db ethdb.Iteratee) []*types.MaliciousVoteEvidence {
+	var (
+		keyLength = len(MaliciousVoteEvidencePrefix) + common.HashLength
+		evidences []*types.MaliciousVoteEvidence
+		it        = db.NewIterator(MaliciousVoteEvidencePrefix, nil)
+	)
+	defer it.Release()
+	for it.Next() {
+		if len(it.Key()) != keyLength {
+			continue
+		}
+		evidence := new(types.MaliciousVoteEvidence)
+		if err := rlp.DecodeBytes(it.Value(), evidence); err != nil {
+			log.Error("Invalid malicious vote evidence RLP", "key", it.Key(), "err", err)
+			continue
+		}
+		evidences = append(evidences, evidence)
+	}
+	return evidences
+}
+
+// WriteMaliciousVoteEvidence stores the malicious vote evidence.
+func WriteMaliciousVoteEvidence(db ethdb.KeyValueWriter, evidence *types.MaliciousVoteEvidence) {
+	data, err := rlp.EncodeToBytes(evidence)
+	if err != nil {
+		log.Crit("Failed to encode malicious vote evidence", "err", err)
+	}
+	if err := db.Put(maliciousVoteEvidenceKey(evidence.Hash()), data); err != nil {
+		log.Crit("Failed to store malicious vote evidence", "err", err)
+	}
+}
+
+// DeleteMaliciousVoteEvidence removes the malicious vote evidence with the given hash.
+func DeleteMaliciousVoteEvidence(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Delete(maliciousVoteEvidenceKey(hash)); err != nil {
+		log.Crit("Failed to delete malicious vote evidence", "err", err)
+	}
+}
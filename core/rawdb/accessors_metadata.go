@@ -210,3 +210,17 @@ func WriteSafePointBlockNumber(db ethdb.KeyValueWriter, number uint64) {
 		log.Crit("Failed to store safe point of block number", "err", err)
 	}
 }
+
+// ReadReplicaStale retrieves if the database replica missed updates of the
+// primary node, and needs to be reseeded.
+func ReadReplicaStale(db ethdb.KeyValueReader) bool {
+	stale, _ := db.Has(replicaStaleKey)
+	return stale
+}
+
+// WriteReplicaStale stores the flag of a database replica missing updates.
+func WriteReplicaStale(db ethdb.KeyValueWriter) {
+	if err := db.Put(replicaStaleKey, []byte("42")); err != nil {
+		log.Crit("Failed to store replica stale flag", "err", err)
+	}
+}
@@ -137,6 +137,105 @@ func WritePersistentStateID(db ethdb.KeyValueWriter, number uint64) {
 	}
 }
 
+// ReadStateHistoryIndexHead retrieves the id of the last indexed state history,
+// nil is returned if the state histories are not indexed.
+func ReadStateHistoryIndexHead(db ethdb.KeyValueReader) *uint64 {
+	data, _ := db.Get(stateHistoryIndexHeadKey)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// WriteStateHistoryIndexHead stores the id of the last indexed state history.
+func WriteStateHistoryIndexHead(db ethdb.KeyValueWriter, id uint64) {
+	if err := db.Put(stateHistoryIndexHeadKey, encodeBlockNumber(id)); err != nil {
+		log.Crit("Failed to store the state history index head", "err", err)
+	}
+}
+
+// DeleteStateHistoryIndexHead deletes the id of the last indexed state history.
+func DeleteStateHistoryIndexHead(db ethdb.KeyValueWriter) {
+	if err := db.Delete(stateHistoryIndexHeadKey); err != nil {
+		log.Crit("Failed to delete the state history index head", "err", err)
+	}
+}
+
+// DeleteStateHistoryIndex deletes the whole state history index along with the
+// id of the last indexed state history.
+func DeleteStateHistoryIndex(db ethdb.KeyValueStore) {
+	for _, prefix := range [][]byte{StateHistoryAccountIndexPrefix, StateHistoryStorageIndexPrefix} {
+		end := common.CopyBytes(prefix)
+		end[len(end)-1]++
+		if err := db.DeleteRange(prefix, end); err != nil {
+			log.Crit("Failed to delete state history index", "err", err)
+		}
+	}
+	DeleteStateHistoryIndexHead(db)
+}
+
+// readHistoryIndex returns the first history id within [from, to] indexed under
+// the given prefix.
+func readHistoryIndex(db ethdb.Iteratee, prefix []byte, from, to uint64) (uint64, bool) {
+	it := db.NewIterator(prefix, encodeBlockNumber(from))
+	defer it.Release()
+
+	if !it.Next() || len(it.Key()) != len(prefix)+8 {
+		return 0, false
+	}
+	id := binary.BigEndian.Uint64(it.Key()[len(prefix):])
+	if id > to {
+		return 0, false
+	}
+	return id, true
+}
+
+// ReadAccountHistoryIndex returns the id of the first state history within the
+// range [from, to] in which the given account was modified.
+func ReadAccountHistoryIndex(db ethdb.Iteratee, address common.Address, from, to uint64) (uint64, bool) {
+	key := stateHistoryAccountIndexKey(address, 0)
+	return readHistoryIndex(db, key[:len(key)-8], from, to)
+}
+
+// WriteAccountHistoryIndex marks the account as modified in the given state history.
+func WriteAccountHistoryIndex(db ethdb.KeyValueWriter, address common.Address, id uint64) {
+	if err := db.Put(stateHistoryAccountIndexKey(address, id), nil); err != nil {
+		log.Crit("Failed to store account history index", "err", err)
+	}
+}
+
+// DeleteAccountHistoryIndex removes the account modification mark of the given
+// state history.
+func DeleteAccountHistoryIndex(db ethdb.KeyValueWriter, address common.Address, id uint64) {
+	if err := db.Delete(stateHistoryAccountIndexKey(address, id)); err != nil {
+		log.Crit("Failed to delete account history index", "err", err)
+	}
+}
+
+// ReadStorageHistoryIndex returns the id of the first state history within the
+// range [from, to] in which the given storage slot was modified.
+func ReadStorageHistoryIndex(db ethdb.Iteratee, address common.Address, slot common.Hash, from, to uint64) (uint64, bool) {
+	key := stateHistoryStorageIndexKey(address, slot, 0)
+	return readHistoryIndex(db, key[:len(key)-8], from, to)
+}
+
+// WriteStorageHistoryIndex marks the storage slot as modified in the given state
+// history.
+func WriteStorageHistoryIndex(db ethdb.KeyValueWriter, address common.Address, slot common.Hash, id uint64) {
+	if err := db.Put(stateHistoryStorageIndexKey(address, slot, id), nil); err != nil {
+		log.Crit("Failed to store storage history index", "err", err)
+	}
+}
+
+// DeleteStorageHistoryIndex removes the storage slot modification mark of the
+// given state history.
+func DeleteStorageHistoryIndex(db ethdb.KeyValueWriter, address common.Address, slot common.Hash, id uint64) {
+	if err := db.Delete(stateHistoryStorageIndexKey(address, slot, id)); err != nil {
+		log.Crit("Failed to delete storage history index", "err", err)
+	}
+}
+
 // ReadTrieJournal retrieves the serialized in-memory trie nodes of layers saved at
 // the last shutdown.
 func ReadTrieJournal(db ethdb.KeyValueReader) []byte {
@@ -0,0 +1,58 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ReadVoteWatermark retrieves the slashing protection watermark of the BLS key.
+func ReadVoteWatermark(db ethdb.KeyValueReader, pubKey types.BLSPublicKey) *types.VoteWatermark {
+	data, _ := db.Get(voteWatermarkKey(pubKey))
+	if len(data) == 0 {
+		return nil
+	}
+	watermark := new(types.VoteWatermark)
+	if err := rlp.DecodeBytes(data, watermark); err != nil {
+		log.Error("Invalid vote watermark RLP", "pubkey", pubKey, "err", err)
+		return nil
+	}
+	return watermark
+}
+
+// ReadAllVoteWatermarks retrieves the slashing protection watermarks of all the
+// BLS keys stored in the database.
+func ReadAllVoteWatermarks(db ethdb.Iteratee) map[types.BLSPublicKey]*types.VoteWatermark {
+	var (
+		keyLength  = len(VoteWatermarkPrefix) + types.BLSPublicKeyLength
+		watermarks = make(map[types.BLSPublicKey]*types.VoteWatermark)
+		it         = db.NewIterator(VoteWatermarkPrefix, nil)
+	)
+	defer it.Release()
+	for it.Next() {
+		if len(it.Key()) != keyLength {
+			continue
+		}
+		watermark := new(types.VoteWatermark)
+		if err := rlp.DecodeBytes(it.Value(), watermark); err != nil {
+			log.Error("Invalid vote watermark RLP", "key", it.Key(), "err", err)
+			continue
+		}
+		var pubKey types.BLSPublicKey
+		copy(pubKey[:], it.Key()[len(VoteWatermarkPrefix):])
+		watermarks[pubKey] = watermark
+	}
+	return watermarks
+}
+
+// WriteVoteWatermark stores the slashing protection watermark of the BLS key.
+func WriteVoteWatermark(db ethdb.KeyValueWriter, pubKey types.BLSPublicKey, watermark *types.VoteWatermark) {
+	data, err := rlp.EncodeToBytes(watermark)
+	if err != nil {
+		log.Crit("Failed to encode vote watermark", "err", err)
+	}
+	if err := db.Put(voteWatermarkKey(pubKey), data); err != nil {
+		log.Crit("Failed to store vote watermark", "err", err)
+	}
+}
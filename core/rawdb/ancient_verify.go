@@ -0,0 +1,282 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// AncientTableReport is the verification result of a single chain freezer table.
+type AncientTableReport struct {
+	Name  string `json:"name"`
+	Tail  uint64 `json:"tail"`            // Number of the first accessible item
+	Items uint64 `json:"items"`           // Number of items including the ones removed from the tail
+	Valid uint64 `json:"valid"`           // Number of leading items with consistent index and data
+	Error string `json:"error,omitempty"` // Reason of the first inconsistency, if any
+}
+
+// AncientIssue is an inconsistency of a chain segment detected by cross-checking
+// the chain freezer tables.
+type AncientIssue struct {
+	Number uint64 `json:"number"`
+	Table  string `json:"table"`
+	Error  string `json:"error"`
+}
+
+// AncientVerifyReport is the verification result of the chain freezer.
+type AncientVerifyReport struct {
+	Tables    []*AncientTableReport `json:"tables"`
+	Tail      uint64                `json:"tail"`            // Number of the first block accessible in all tables
+	Items     uint64                `json:"items"`           // Number of blocks in the freezer, the highest table head
+	Valid     uint64                `json:"valid"`           // Number of leading blocks consistent across all tables
+	Issue     *AncientIssue         `json:"issue,omitempty"` // The first cross-table inconsistency, if any
+	Repaired  bool                  `json:"repaired"`        // Whether the tables were truncated to the valid head
+	Truncated uint64                `json:"truncated"`       // Number of blocks discarded by the repair
+}
+
+// Consistent returns whether the chain freezer is free of any inconsistency.
+func (r *AncientVerifyReport) Consistent() bool {
+	if r.Issue != nil || r.Valid != r.Items {
+		return false
+	}
+	for _, table := range r.Tables {
+		if table.Error != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyChainFreezer checks the integrity of the chain freezer under the given
+// ancient directory. Every table is checked for the consistency between its
+// index and data files, then the tables are cross-checked block by block: the
+// hashes must match the headers and link them together, and the number of
+// receipts must match the number of transactions in the bodies.
+//
+// The freezer is always verified in read-only mode first. If repair is set and
+// an inconsistency is found, the freezer is then opened in write mode and all
+// the tables are truncated to the last block consistent across the entire
+// freezer. The database must not be in use by a running node.
+func VerifyChainFreezer(ancient string, repair bool) (*AncientVerifyReport, error) {
+	path := resolveChainFreezerDir(ancient)
+
+	report := verifyChainFreezerReadonly(path)
+	if !repair || report.Consistent() {
+		return report, nil
+	}
+	head, err := repairChainFreezer(path)
+	if err != nil {
+		return report, err
+	}
+	if head < report.Items {
+		report.Repaired = true
+		report.Truncated = report.Items - head
+		log.Warn("Truncated inconsistent chain freezer", "head", head, "truncated", report.Truncated)
+	}
+	return report, nil
+}
+
+// verifyChainFreezerReadonly verifies the chain freezer at the given path without
+// modifying it. The tables are opened one by one, so that a broken table doesn't
+// prevent the others from being inspected.
+func verifyChainFreezerReadonly(path string) *AncientVerifyReport {
+	var (
+		tables = make(map[string]*freezerTable)
+		report = new(AncientVerifyReport)
+	)
+	for name, noSnappy := range chainFreezerNoSnappy {
+		table, err := newTable(path, name, metrics.NewInactiveMeter(), metrics.NewInactiveMeter(), metrics.NewGauge(), freezerTableSize, noSnappy, true)
+		if err != nil {
+			if slices.Contains(additionTables, name) && errors.Is(err, os.ErrNotExist) {
+				continue // Addition table not yet created
+			}
+			report.Tables = append(report.Tables, &AncientTableReport{Name: name, Error: err.Error()})
+			continue
+		}
+		defer table.Close()
+		tables[name] = table
+	}
+	verifyChainFreezer(tables, report)
+	return report
+}
+
+// repairChainFreezer opens the chain freezer at the given path in write mode,
+// which already truncates the dangling data of each table and aligns their
+// heads, then truncates the tables to the last block consistent across them.
+// The resulting head is returned.
+func repairChainFreezer(path string) (uint64, error) {
+	freezer, err := NewFreezer(path, "", false, freezerTableSize, chainFreezerNoSnappy)
+	if err != nil {
+		return 0, err
+	}
+	defer freezer.Close()
+
+	report := new(AncientVerifyReport)
+	verifyChainFreezer(freezer.tables, report)
+	if report.Valid < report.Items {
+		if _, err := freezer.TruncateHead(report.Valid); err != nil {
+			return 0, err
+		}
+		return report.Valid, nil
+	}
+	return report.Items, nil
+}
+
+// verifyChainFreezer verifies the given chain freezer tables, filling the result
+// into the report.
+func verifyChainFreezer(tables map[string]*freezerTable, report *AncientVerifyReport) {
+	// A mandatory table which can't even be opened invalidates the whole freezer
+	limit := uint64(math.MaxUint64)
+	for _, table := range report.Tables {
+		if !slices.Contains(additionTables, table.Name) {
+			limit = 0
+		}
+	}
+	for name, table := range tables {
+		res := &AncientTableReport{
+			Name:  name,
+			Tail:  table.itemHidden.Load(),
+			Items: table.items.Load(),
+		}
+		number, err := table.verify()
+		res.Valid = number
+		if err != nil {
+			res.Error = fmt.Sprintf("item %d: %v", number, err)
+		}
+		report.Tables = append(report.Tables, res)
+
+		// The addition tables have their own tail, and are empty until the
+		// first block carrying the data is frozen.
+		if slices.Contains(additionTables, name) {
+			if EmptyTable(table) {
+				continue
+			}
+		} else {
+			report.Tail = max(report.Tail, res.Tail)
+		}
+		report.Items = max(report.Items, res.Items)
+		limit = min(limit, res.Valid)
+	}
+	sort.Slice(report.Tables, func(i, j int) bool { return report.Tables[i].Name < report.Tables[j].Name })
+
+	// Cross-check the blocks accessible in all tables
+	limit = min(limit, report.Items)
+	if limit <= report.Tail {
+		report.Valid = limit
+		return
+	}
+	report.Valid, report.Issue = verifyChainSegment(tables, report.Tail, limit)
+}
+
+// verifyChainSegment cross-checks the blocks within [from, to) of the chain
+// freezer tables, returning the number of the first inconsistent block along
+// with the issue, or the end of the range if all are consistent.
+func verifyChainSegment(tables map[string]*freezerTable, from, to uint64) (uint64, *AncientIssue) {
+	var (
+		start   = time.Now()
+		logged  = time.Now()
+		parent  common.Hash
+		blobs   = tables[ChainFreezerBlobSidecarTable]
+		failure = func(number uint64, table string, err error) (uint64, *AncientIssue) {
+			return number, &AncientIssue{Number: number, Table: table, Error: err.Error()}
+		}
+	)
+	for number := from; number < to; number++ {
+		blob, err := tables[ChainFreezerHashTable].Retrieve(number)
+		if err != nil {
+			return failure(number, ChainFreezerHashTable, err)
+		}
+		if len(blob) != common.HashLength {
+			return failure(number, ChainFreezerHashTable, fmt.Errorf("invalid hash length %d", len(blob)))
+		}
+		hash := common.BytesToHash(blob)
+
+		if blob, err = tables[ChainFreezerHeaderTable].Retrieve(number); err != nil {
+			return failure(number, ChainFreezerHeaderTable, err)
+		}
+		header := new(types.Header)
+		if err := rlp.DecodeBytes(blob, header); err != nil {
+			return failure(number, ChainFreezerHeaderTable, err)
+		}
+		if header.Number == nil || !header.Number.IsUint64() || header.Number.Uint64() != number {
+			return failure(number, ChainFreezerHeaderTable, fmt.Errorf("header number mismatch: %v", header.Number))
+		}
+		if header.Hash() != hash {
+			return failure(number, ChainFreezerHeaderTable, fmt.Errorf("header hash mismatch, want: %x, got: %x", hash, header.Hash()))
+		}
+		if number > from && header.ParentHash != parent {
+			return failure(number, ChainFreezerHeaderTable, fmt.Errorf("parent hash mismatch, want: %x, got: %x", parent, header.ParentHash))
+		}
+		parent = hash
+
+		if blob, err = tables[ChainFreezerBodiesTable].Retrieve(number); err != nil {
+			return failure(number, ChainFreezerBodiesTable, err)
+		}
+		body := new(types.Body)
+		if err := rlp.DecodeBytes(blob, body); err != nil {
+			return failure(number, ChainFreezerBodiesTable, err)
+		}
+		if blob, err = tables[ChainFreezerReceiptTable].Retrieve(number); err != nil {
+			return failure(number, ChainFreezerReceiptTable, err)
+		}
+		var receipts []*types.ReceiptForStorage
+		if err := rlp.DecodeBytes(blob, &receipts); err != nil {
+			return failure(number, ChainFreezerReceiptTable, err)
+		}
+		if len(receipts) != len(body.Transactions) {
+			return failure(number, ChainFreezerReceiptTable, fmt.Errorf("receipt count mismatch, transactions: %d, receipts: %d", len(body.Transactions), len(receipts)))
+		}
+		if blob, err = tables[ChainFreezerDifficultyTable].Retrieve(number); err != nil {
+			return failure(number, ChainFreezerDifficultyTable, err)
+		}
+		if err := rlp.DecodeBytes(blob, new(big.Int)); err != nil {
+			return failure(number, ChainFreezerDifficultyTable, err)
+		}
+		if blobs != nil && blobs.has(number) {
+			if blob, err = blobs.Retrieve(number); err != nil {
+				return failure(number, ChainFreezerBlobSidecarTable, err)
+			}
+			var sidecars types.BlobSidecars
+			if err := rlp.DecodeBytes(blob, &sidecars); err != nil {
+				return failure(number, ChainFreezerBlobSidecarTable, err)
+			}
+			for _, sidecar := range sidecars {
+				if err := sidecar.SanityCheck(header.Number, hash); err != nil {
+					return failure(number, ChainFreezerBlobSidecarTable, err)
+				}
+			}
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Verifying chain freezer", "number", number, "to", to, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	return to, nil
+}
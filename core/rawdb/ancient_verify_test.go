@@ -0,0 +1,141 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// writeTestChainFreezer writes a chain of linked blocks into the chain freezer
+// under the given ancient directory. The receipts of the block numbered broken
+// are left out if it's within the chain.
+func writeTestChainFreezer(t *testing.T, ancient string, n int, broken int) {
+	f, err := NewFreezer(filepath.Join(ancient, ChainFreezerName), "", false, freezerTableSize, chainFreezerNoSnappy)
+	if err != nil {
+		t.Fatalf("Failed to create freezer: %v", err)
+	}
+	defer f.Close()
+
+	var (
+		txs      = makeTestBlocks(1, 2)[0].Transactions()
+		receipts = makeTestReceipts(n, 2)
+		blocks   = make([]*types.Block, n)
+		parent   common.Hash
+	)
+	for i := 0; i < n; i++ {
+		header := &types.Header{
+			ParentHash: parent,
+			Number:     big.NewInt(int64(i)),
+			Difficulty: big.NewInt(1),
+			Extra:      []byte("test block"),
+		}
+		blocks[i] = types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: txs})
+		parent = blocks[i].Hash()
+	}
+	if broken < n {
+		receipts[broken] = nil
+	}
+	if _, err := WriteAncientBlocks(f, blocks, receipts, big.NewInt(1)); err != nil {
+		t.Fatalf("Failed to write ancient blocks: %v", err)
+	}
+}
+
+func TestVerifyChainFreezer(t *testing.T) {
+	ancient := t.TempDir()
+	writeTestChainFreezer(t, ancient, 100, 100)
+
+	report, err := VerifyChainFreezer(ancient, false)
+	if err != nil {
+		t.Fatalf("Failed to verify freezer: %v", err)
+	}
+	if !report.Consistent() || report.Items != 100 || report.Valid != 100 {
+		t.Fatalf("Unexpected report: items %d, valid %d, issue %v", report.Items, report.Valid, report.Issue)
+	}
+}
+
+func TestVerifyChainFreezerRepairMismatch(t *testing.T) {
+	ancient := t.TempDir()
+	writeTestChainFreezer(t, ancient, 100, 60)
+
+	report, err := VerifyChainFreezer(ancient, false)
+	if err != nil {
+		t.Fatalf("Failed to verify freezer: %v", err)
+	}
+	if report.Consistent() || report.Valid != 60 || report.Issue == nil || report.Issue.Table != ChainFreezerReceiptTable {
+		t.Fatalf("Unexpected report: valid %d, issue %v", report.Valid, report.Issue)
+	}
+	// Verification must leave the freezer untouched
+	if report, _ = VerifyChainFreezer(ancient, false); report.Items != 100 {
+		t.Fatalf("Freezer modified by verification, items: %d", report.Items)
+	}
+	report, err = VerifyChainFreezer(ancient, true)
+	if err != nil {
+		t.Fatalf("Failed to repair freezer: %v", err)
+	}
+	if !report.Repaired || report.Truncated != 40 {
+		t.Fatalf("Unexpected repair: repaired %v, truncated %d", report.Repaired, report.Truncated)
+	}
+	report, err = VerifyChainFreezer(ancient, false)
+	if err != nil {
+		t.Fatalf("Failed to verify freezer: %v", err)
+	}
+	if !report.Consistent() || report.Items != 60 {
+		t.Fatalf("Unexpected report after repair: items %d, valid %d, issue %v", report.Items, report.Valid, report.Issue)
+	}
+}
+
+func TestVerifyChainFreezerRepairDanglingIndex(t *testing.T) {
+	ancient := t.TempDir()
+	writeTestChainFreezer(t, ancient, 100, 100)
+
+	// Cut the last body short, leaving a dangling index entry
+	data := filepath.Join(ancient, ChainFreezerName, ChainFreezerBodiesTable+".0000.cdat")
+	stat, err := os.Stat(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(data, stat.Size()-1); err != nil {
+		t.Fatal(err)
+	}
+	report, err := VerifyChainFreezer(ancient, false)
+	if err != nil {
+		t.Fatalf("Failed to verify freezer: %v", err)
+	}
+	if report.Consistent() || report.Valid != 0 {
+		t.Fatalf("Unexpected report: valid %d", report.Valid)
+	}
+	report, err = VerifyChainFreezer(ancient, true)
+	if err != nil {
+		t.Fatalf("Failed to repair freezer: %v", err)
+	}
+	if !report.Repaired || report.Truncated != 1 {
+		t.Fatalf("Unexpected repair: repaired %v, truncated %d", report.Repaired, report.Truncated)
+	}
+	report, err = VerifyChainFreezer(ancient, false)
+	if err != nil {
+		t.Fatalf("Failed to verify freezer: %v", err)
+	}
+	if !report.Consistent() || report.Items != 99 {
+		t.Fatalf("Unexpected report after repair: items %d, valid %d, issue %v", report.Items, report.Valid, report.Issue)
+	}
+}
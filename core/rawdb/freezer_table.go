@@ -583,6 +583,60 @@ func (t *freezerTable) checkIndexItems(a, b indexEntry) error {
 	return nil
 }
 
+// verifyBatch is the number of index entries loaded at once while verifying
+// the consistency of the table.
+const verifyBatch = 65536
+
+// verify checks the consistency of the index and data files without modifying
+// them: all the index entries must be in order, and every item must be located
+// within the bounds of an existing data file. It returns the number of the first
+// item failing the check along with the reason, or the number of items in the
+// table if all of them are consistent.
+func (t *freezerTable) verify() (uint64, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.index == nil || t.head == nil || t.metadata.file == nil {
+		return 0, errClosed
+	}
+	var (
+		items = t.items.Load()
+		sizes = make(map[uint32]int64)
+	)
+	for start := t.itemOffset.Load(); start < items; {
+		count := min(items-start, verifyBatch)
+		indices, err := t.getIndices(start, count)
+		if err != nil {
+			return start, err
+		}
+		for i := 0; i < len(indices)-1; i++ {
+			number := start + uint64(i)
+			if err := t.checkIndexItems(*indices[i], *indices[i+1]); err != nil {
+				return number, err
+			}
+			_, end, filenum := indices[i].bounds(indices[i+1])
+			size, ok := sizes[filenum]
+			if !ok {
+				file, exist := t.files[filenum]
+				if !exist {
+					return number, fmt.Errorf("missing data file %d", filenum)
+				}
+				stat, err := file.Stat()
+				if err != nil {
+					return number, err
+				}
+				size = stat.Size()
+				sizes[filenum] = size
+			}
+			if int64(end) > size {
+				return number, fmt.Errorf("item beyond data file %d, end: %d, size: %d", filenum, end, size)
+			}
+		}
+		start += count
+	}
+	return items, nil
+}
+
 // preopen opens all files that the freezer will need. This method should be called from an init-context,
 // since it assumes that it doesn't have to bother with locking
 // The rationale for doing preopen is to not have to do it from within Retrieve, thus not needing to ever
@@ -22,6 +22,7 @@ import (
 	"encoding/binary"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/metrics"
 )
@@ -96,9 +97,15 @@ var (
 	// transitionStatusKey tracks the eth2 transition status.
 	transitionStatusKey = []byte("eth2-transition")
 
+	// stateHistoryIndexHeadKey tracks the id of the last indexed state history.
+	stateHistoryIndexHeadKey = []byte("LastStateHistoryIndex")
+
 	// snapSyncStatusFlagKey flags that status of snap sync.
 	snapSyncStatusFlagKey = []byte("SnapSyncStatus")
 
+	// replicaStaleKey flags that the database replica missed updates of the primary.
+	replicaStaleKey = []byte("ReplicaStale")
+
 	// Data item prefixes (use single byte to avoid mixing data types, avoid `i`, used for indexes).
 	headerPrefix       = []byte("h") // headerPrefix + num (uint64 big endian) + hash -> header
 	headerTDSuffix     = []byte("t") // headerPrefix + num (uint64 big endian) + hash + headerTDSuffix -> td
@@ -119,6 +126,9 @@ var (
 	TrieNodeStoragePrefix = []byte("O") // TrieNodeStoragePrefix + accountHash + hexPath -> trie node
 	stateIDPrefix         = []byte("L") // stateIDPrefix + state root -> state id
 
+	StateHistoryAccountIndexPrefix = []byte("mha") // StateHistoryAccountIndexPrefix + address + history id -> nil
+	StateHistoryStorageIndexPrefix = []byte("mhs") // StateHistoryStorageIndexPrefix + address + slot key + history id -> nil
+
 	// VerklePrefix is the database prefix for Verkle trie data, which includes:
 	// (a) Trie nodes
 	// (b) In-memory trie node journal
@@ -146,6 +156,10 @@ var (
 
 	BlockBlobSidecarsPrefix = []byte("blobs")
 
+	DoubleSignEvidencePrefix    = []byte("double-sign-")    // DoubleSignEvidencePrefix + num (uint64 big endian) + hash1 + hash2 -> double sign evidence
+	MaliciousVoteEvidencePrefix = []byte("malicious-vote-") // MaliciousVoteEvidencePrefix + evidence hash -> malicious vote evidence
+	VoteWatermarkPrefix         = []byte("vote-watermark-") // VoteWatermarkPrefix + BLS public key -> vote watermark
+
 	preimageCounter    = metrics.NewRegisteredCounter("db/preimage/total", nil)
 	preimageHitCounter = metrics.NewRegisteredCounter("db/preimage/hits", nil)
 )
@@ -205,6 +219,22 @@ func blockBlobSidecarsKey(number uint64, hash common.Hash) []byte {
 	return append(append(BlockBlobSidecarsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
 }
 
+// doubleSignEvidenceKey = DoubleSignEvidencePrefix + num (uint64 big endian) + hash1 + hash2
+func doubleSignEvidenceKey(number uint64, hash1, hash2 common.Hash) []byte {
+	key := append(DoubleSignEvidencePrefix, encodeBlockNumber(number)...)
+	return append(append(key, hash1.Bytes()...), hash2.Bytes()...)
+}
+
+// maliciousVoteEvidenceKey = MaliciousVoteEvidencePrefix + evidence hash
+func maliciousVoteEvidenceKey(hash common.Hash) []byte {
+	return append(MaliciousVoteEvidencePrefix, hash.Bytes()...)
+}
+
+// voteWatermarkKey = VoteWatermarkPrefix + BLS public key
+func voteWatermarkKey(pubKey types.BLSPublicKey) []byte {
+	return append(VoteWatermarkPrefix, pubKey[:]...)
+}
+
 // txLookupKey = txLookupPrefix + hash
 func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
@@ -273,6 +303,20 @@ func stateIDKey(root common.Hash) []byte {
 	return append(stateIDPrefix, root.Bytes()...)
 }
 
+// stateHistoryAccountIndexKey = StateHistoryAccountIndexPrefix + address + id (uint64 big endian)
+func stateHistoryAccountIndexKey(address common.Address, id uint64) []byte {
+	key := append(append(StateHistoryAccountIndexPrefix, address.Bytes()...), make([]byte, 8)...)
+	binary.BigEndian.PutUint64(key[len(key)-8:], id)
+	return key
+}
+
+// stateHistoryStorageIndexKey = StateHistoryStorageIndexPrefix + address + slot key + id (uint64 big endian)
+func stateHistoryStorageIndexKey(address common.Address, slot common.Hash, id uint64) []byte {
+	key := append(append(append(StateHistoryStorageIndexPrefix, address.Bytes()...), slot.Bytes()...), make([]byte, 8)...)
+	binary.BigEndian.PutUint64(key[len(key)-8:], id)
+	return key
+}
+
 // accountTrieNodeKey = TrieNodeAccountPrefix + nodePath.
 func accountTrieNodeKey(path []byte) []byte {
 	return append(TrieNodeAccountPrefix, path...)
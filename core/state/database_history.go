@@ -0,0 +1,160 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/utils"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/pathdb"
+)
+
+// historicReader implements StateReader, serving a historic state from the
+// state histories, and the accounts and storage slots unmodified since from
+// the state of the disk layer.
+type historicReader struct {
+	reader *pathdb.HistoricalStateReader
+	base   StateReader
+}
+
+// newHistoricReader constructs a reader of the historic state with the given root.
+func newHistoricReader(root common.Hash, db *triedb.Database, cache *utils.PointCache) (*historicReader, error) {
+	reader, err := db.HistoricReader(root)
+	if err != nil {
+		return nil, err
+	}
+	base, err := newTrieReader(reader.Base(), db, cache)
+	if err != nil {
+		return nil, err
+	}
+	return &historicReader{reader: reader, base: base}, nil
+}
+
+// Account implements StateReader, retrieving the account specified by the address.
+//
+// The returned account might be nil if it's not existent.
+func (r *historicReader) Account(addr common.Address) (*types.StateAccount, error) {
+	blob, found, err := r.reader.Account(addr)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return r.base.Account(addr)
+	}
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	return types.FullAccount(blob)
+}
+
+// Storage implements StateReader, retrieving the storage slot specified by the
+// address and slot key.
+//
+// The returned storage slot might be empty if it's not existent.
+func (r *historicReader) Storage(addr common.Address, key common.Hash) (common.Hash, error) {
+	blob, found, err := r.reader.Storage(addr, key)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if !found {
+		return r.base.Storage(addr, key)
+	}
+	if len(blob) == 0 {
+		return common.Hash{}, nil
+	}
+	// Perform the rlp-decode as the slot value is RLP-encoded in the state
+	// history.
+	_, content, _, err := rlp.Split(blob)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var value common.Hash
+	value.SetBytes(content)
+	return value, nil
+}
+
+// HistoricDB is an implementation of Database interface serving the historic
+// states covered by the indexed state histories of the path-based database,
+// which are no longer available in the state layers. The states are read-only
+// and carry no tries, they are meant for state queries only.
+type HistoricDB struct {
+	disk          ethdb.KeyValueStore
+	triedb        *triedb.Database
+	codeCache     *lru.SizeConstrainedCache[common.Hash, []byte]
+	codeSizeCache *lru.Cache[common.Hash, int]
+	pointCache    *utils.PointCache
+}
+
+// NewHistoricDatabase creates a historic state database with the provided data
+// sources.
+func NewHistoricDatabase(disk ethdb.KeyValueStore, triedb *triedb.Database) *HistoricDB {
+	return &HistoricDB{
+		disk:          disk,
+		triedb:        triedb,
+		codeCache:     lru.NewSizeConstrainedCache[common.Hash, []byte](codeCacheSize),
+		codeSizeCache: lru.NewCache[common.Hash, int](codeSizeCacheSize),
+		pointCache:    utils.NewPointCache(pointCacheSize),
+	}
+}
+
+// Reader implements Database interface, returning a reader of the historic state
+// with the specified state root.
+func (db *HistoricDB) Reader(stateRoot common.Hash) (Reader, error) {
+	reader, err := newHistoricReader(stateRoot, db.triedb, db.pointCache)
+	if err != nil {
+		return nil, err
+	}
+	return newReader(newCachingCodeReader(db.disk, db.codeCache, db.codeSizeCache), reader), nil
+}
+
+// OpenTrie implements Database interface, returning an empty trie as the tries
+// of historic states are not available.
+func (db *HistoricDB) OpenTrie(root common.Hash) (Trie, error) {
+	return trie.NewEmptyTrie(), nil
+}
+
+// OpenStorageTrie implements Database interface, returning an empty trie as the
+// tries of historic states are not available.
+func (db *HistoricDB) OpenStorageTrie(stateRoot common.Hash, address common.Address, root common.Hash, self Trie) (Trie, error) {
+	return trie.NewEmptyTrie(), nil
+}
+
+// PointCache returns the cache of evaluated curve points.
+func (db *HistoricDB) PointCache() *utils.PointCache {
+	return db.pointCache
+}
+
+// TrieDB retrieves the underlying trie database.
+func (db *HistoricDB) TrieDB() *triedb.Database {
+	return db.triedb
+}
+
+// NoTries returns true, the historic states carry no tries.
+func (db *HistoricDB) NoTries() bool {
+	return true
+}
+
+// Snapshot returns nil, the historic states are not covered by the snapshot.
+func (db *HistoricDB) Snapshot() *snapshot.Tree {
+	return nil
+}
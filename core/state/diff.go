@@ -0,0 +1,544 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// DiffConfig is a set of options to control what portions of the states will
+// be compared.
+type DiffConfig struct {
+	Addresses []common.Address // Accounts to compare, all of them if empty
+	Start     []byte           // Account hash to start the comparison from
+	Max       uint64           // Maximum number of changed accounts to report, unlimited if zero
+	MaxSlots  uint64           // Maximum number of changed slots to report in total, unlimited if zero
+}
+
+// DiffKind is the kind of change of an account between two states.
+type DiffKind string
+
+const (
+	DiffCreated  DiffKind = "created"
+	DiffDeleted  DiffKind = "deleted"
+	DiffModified DiffKind = "modified"
+)
+
+// Change is a pair of values of a field before and after.
+type Change[T any] struct {
+	From T `json:"from"`
+	To   T `json:"to"`
+}
+
+// SlotDiff is a changed storage slot.
+type SlotDiff struct {
+	Hash common.Hash  `json:"key"`            // Hash of the slot key
+	Slot *common.Hash `json:"slot,omitempty"` // Slot key, if the preimage is available
+	From common.Hash  `json:"from"`
+	To   common.Hash  `json:"to"`
+}
+
+// AccountDiff is a changed account along with its changed fields. A created or
+// deleted account is compared against an empty account.
+type AccountDiff struct {
+	Kind        DiffKind                `json:"kind"`
+	Address     *common.Address         `json:"address,omitempty"` // Address, if the preimage is available
+	AddressHash common.Hash             `json:"key"`
+	Balance     *Change[*hexutil.U256]  `json:"balance,omitempty"`
+	Nonce       *Change[hexutil.Uint64] `json:"nonce,omitempty"`
+	CodeHash    *Change[common.Hash]    `json:"codeHash,omitempty"`
+	Storage     []*SlotDiff             `json:"storage,omitempty"`
+	Truncated   bool                    `json:"truncated,omitempty"` // Whether the changed slots exceed the configured maximum
+}
+
+// StateDiffer compares two states by walking their flat states in parallel,
+// served either by the snapshot if both states are covered, or by the tries.
+type StateDiffer struct {
+	triedb *triedb.Database
+	snaps  *snapshot.Tree
+}
+
+// NewStateDiffer creates a state differ with the given data sources. The
+// snapshot tree is optional.
+func NewStateDiffer(triedb *triedb.Database, snaps *snapshot.Tree) *StateDiffer {
+	return &StateDiffer{triedb: triedb, snaps: snaps}
+}
+
+// Diff compares the states of the given roots, invoking the callback for every
+// changed account in the order of the account hashes. If the comparison is
+// aborted due to the configured maximums, the hash of the next account to be
+// compared is returned. The storage changes of the account exhausting the slot
+// maximum are truncated and marked as such. The comparison is aborted with the context error once
+// the context is cancelled.
+func (d *StateDiffer) Diff(ctx context.Context, from, to common.Hash, conf *DiffConfig, onAccount func(*AccountDiff) error) ([]byte, error) {
+	if conf == nil {
+		conf = new(DiffConfig)
+	}
+	var src diffSource = &trieDiffSource{db: d.triedb}
+	if d.snaps != nil && d.snaps.Snapshot(from) != nil && d.snaps.Snapshot(to) != nil {
+		src = &snapDiffSource{tree: d.snaps}
+	}
+	w := &diffWalker{
+		ctx:       ctx,
+		src:       src,
+		triedb:    d.triedb,
+		from:      from,
+		to:        to,
+		conf:      conf,
+		onAccount: onAccount,
+		start:     time.Now(),
+		logged:    time.Now(),
+	}
+	log.Info("State diffing started", "from", from, "to", to)
+	var (
+		next []byte
+		err  error
+	)
+	if len(conf.Addresses) > 0 {
+		next, err = w.diffAddresses()
+	} else {
+		next, err = w.diffAll()
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Info("State diffing complete", "accounts", w.accounts, "changed", w.changed, "elapsed", common.PrettyDuration(time.Since(w.start)))
+	return next, nil
+}
+
+// diffWalker is the state of an ongoing comparison.
+type diffWalker struct {
+	ctx       context.Context
+	src       diffSource
+	triedb    *triedb.Database
+	from, to  common.Hash
+	conf      *DiffConfig
+	onAccount func(*AccountDiff) error
+
+	accounts uint64 // Number of accounts compared
+	changed  uint64 // Number of changed accounts reported
+	slots    uint64 // Number of changed slots reported
+	start    time.Time
+	logged   time.Time
+}
+
+// diffAll walks all the accounts of both states.
+func (w *diffWalker) diffAll() ([]byte, error) {
+	itA, err := w.src.accounts(w.from, common.BytesToHash(w.conf.Start))
+	if err != nil {
+		return nil, err
+	}
+	defer itA.Release()
+
+	itB, err := w.src.accounts(w.to, common.BytesToHash(w.conf.Start))
+	if err != nil {
+		return nil, err
+	}
+	defer itB.Release()
+
+	okA, okB := itA.Next(), itB.Next()
+	for okA || okB {
+		if err := w.ctx.Err(); err != nil {
+			return nil, err
+		}
+		var (
+			hash   common.Hash
+			blobA  []byte
+			blobB  []byte
+			nextA  = okA
+			nextB  = okB
+			result int
+		)
+		switch {
+		case !okB:
+			result = -1
+		case !okA:
+			result = 1
+		default:
+			result = itA.Hash().Cmp(itB.Hash())
+		}
+		if result <= 0 {
+			hash, blobA = itA.Hash(), itA.Value()
+		} else {
+			nextA = false
+		}
+		if result >= 0 {
+			hash, blobB = itB.Hash(), itB.Value()
+		} else {
+			nextB = false
+		}
+		if w.full() && (result != 0 || !bytes.Equal(blobA, blobB)) {
+			return hash.Bytes(), nil
+		}
+		if result != 0 || !bytes.Equal(blobA, blobB) {
+			if err := w.diffAccount(hash, blobA, blobB); err != nil {
+				return nil, err
+			}
+		}
+		w.progress(hash)
+		if nextA {
+			okA = itA.Next()
+		}
+		if nextB {
+			okB = itB.Next()
+		}
+	}
+	if err := itA.Error(); err != nil {
+		return nil, err
+	}
+	return nil, itB.Error()
+}
+
+// diffAddresses compares the configured accounts of both states.
+func (w *diffWalker) diffAddresses() ([]byte, error) {
+	hashes := make([]common.Hash, 0, len(w.conf.Addresses))
+	for _, addr := range w.conf.Addresses {
+		hashes = append(hashes, crypto.Keccak256Hash(addr.Bytes()))
+	}
+	slices.SortFunc(hashes, common.Hash.Cmp)
+	hashes = slices.Compact(hashes)
+
+	start := common.BytesToHash(w.conf.Start)
+	for _, hash := range hashes {
+		if err := w.ctx.Err(); err != nil {
+			return nil, err
+		}
+		if hash.Cmp(start) < 0 {
+			continue
+		}
+		blobA, err := w.account(w.from, hash)
+		if err != nil {
+			return nil, err
+		}
+		blobB, err := w.account(w.to, hash)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(blobA, blobB) {
+			continue
+		}
+		if w.full() {
+			return hash.Bytes(), nil
+		}
+		if err := w.diffAccount(hash, blobA, blobB); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// full reports whether either of the configured maximums has been reached.
+func (w *diffWalker) full() bool {
+	if w.conf.Max > 0 && w.changed >= w.conf.Max {
+		return true
+	}
+	return w.conf.MaxSlots > 0 && w.slots >= w.conf.MaxSlots
+}
+
+// account retrieves the encoded account with the given hash in the specified
+// state, nil if it doesn't exist.
+func (w *diffWalker) account(root common.Hash, hash common.Hash) ([]byte, error) {
+	it, err := w.src.accounts(root, hash)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	if !it.Next() {
+		return nil, it.Error()
+	}
+	if it.Hash() != hash {
+		return nil, nil
+	}
+	return it.Value(), nil
+}
+
+// diffAccount compares the given encoded accounts, reporting the changes if any.
+func (w *diffWalker) diffAccount(hash common.Hash, blobA, blobB []byte) error {
+	diff := &AccountDiff{
+		Kind:        DiffModified,
+		AddressHash: hash,
+	}
+	a, b := types.NewEmptyStateAccount(), types.NewEmptyStateAccount()
+	switch {
+	case len(blobA) == 0:
+		diff.Kind = DiffCreated
+	case len(blobB) == 0:
+		diff.Kind = DiffDeleted
+	}
+	if len(blobA) != 0 {
+		acc, err := w.src.decode(blobA)
+		if err != nil {
+			return err
+		}
+		a = acc
+	}
+	if len(blobB) != 0 {
+		acc, err := w.src.decode(blobB)
+		if err != nil {
+			return err
+		}
+		b = acc
+	}
+	if preimage := w.triedb.Preimage(hash); preimage != nil {
+		addr := common.BytesToAddress(preimage)
+		diff.Address = &addr
+	}
+	if a.Balance.Cmp(b.Balance) != 0 {
+		diff.Balance = &Change[*hexutil.U256]{From: (*hexutil.U256)(a.Balance), To: (*hexutil.U256)(b.Balance)}
+	}
+	if a.Nonce != b.Nonce {
+		diff.Nonce = &Change[hexutil.Uint64]{From: hexutil.Uint64(a.Nonce), To: hexutil.Uint64(b.Nonce)}
+	}
+	if !bytes.Equal(a.CodeHash, b.CodeHash) {
+		diff.CodeHash = &Change[common.Hash]{From: common.BytesToHash(a.CodeHash), To: common.BytesToHash(b.CodeHash)}
+	}
+	if a.Root != b.Root {
+		slots, truncated, err := w.diffStorage(hash, a.Root, b.Root)
+		if err != nil {
+			return err
+		}
+		diff.Storage, diff.Truncated = slots, truncated
+	}
+	w.changed++
+	return w.onAccount(diff)
+}
+
+// diffStorage walks the storage of the account in both states, returning the
+// changed slots and whether they were truncated due to the slot maximum.
+func (w *diffWalker) diffStorage(account common.Hash, rootA, rootB common.Hash) ([]*SlotDiff, bool, error) {
+	itA, err := w.src.storage(w.from, account, rootA)
+	if err != nil {
+		return nil, false, err
+	}
+	defer itA.Release()
+
+	itB, err := w.src.storage(w.to, account, rootB)
+	if err != nil {
+		return nil, false, err
+	}
+	defer itB.Release()
+
+	var (
+		slots     []*SlotDiff
+		truncated bool
+		okA, okB  = itA.Next(), itB.Next()
+	)
+	for okA || okB {
+		if err := w.ctx.Err(); err != nil {
+			return nil, false, err
+		}
+		var (
+			diff   = new(SlotDiff)
+			result int
+			err    error
+		)
+		switch {
+		case !okB:
+			result = -1
+		case !okA:
+			result = 1
+		default:
+			result = itA.Hash().Cmp(itB.Hash())
+		}
+		if result <= 0 {
+			diff.Hash = itA.Hash()
+			if diff.From, err = decodeDiffSlot(itA.Value()); err != nil {
+				return nil, false, err
+			}
+		}
+		if result >= 0 {
+			diff.Hash = itB.Hash()
+			if diff.To, err = decodeDiffSlot(itB.Value()); err != nil {
+				return nil, false, err
+			}
+		}
+		if diff.From != diff.To {
+			if w.conf.MaxSlots > 0 && w.slots >= w.conf.MaxSlots {
+				truncated = true
+				break
+			}
+			if preimage := w.triedb.Preimage(diff.Hash); preimage != nil {
+				slot := common.BytesToHash(preimage)
+				diff.Slot = &slot
+			}
+			slots = append(slots, diff)
+			w.slots++
+		}
+		if result <= 0 {
+			okA = itA.Next()
+		}
+		if result >= 0 {
+			okB = itB.Next()
+		}
+	}
+	if err := itA.Error(); err != nil {
+		return nil, false, err
+	}
+	if err := itB.Error(); err != nil {
+		return nil, false, err
+	}
+	return slots, truncated, nil
+}
+
+// progress logs the progress of the comparison periodically.
+func (w *diffWalker) progress(at common.Hash) {
+	w.accounts++
+	if time.Since(w.logged) > 8*time.Second {
+		log.Info("State diffing in progress", "at", at, "accounts", w.accounts, "changed", w.changed,
+			"elapsed", common.PrettyDuration(time.Since(w.start)))
+		w.logged = time.Now()
+	}
+}
+
+// decodeDiffSlot decodes the RLP-encoded storage slot.
+func decodeDiffSlot(blob []byte) (common.Hash, error) {
+	_, content, _, err := rlp.Split(blob)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(content), nil
+}
+
+// diffIterator is an iterator over the accounts or storage slots of a state in
+// the order of their hashes.
+type diffIterator interface {
+	Next() bool
+	Hash() common.Hash
+	Value() []byte
+	Error() error
+	Release()
+}
+
+// diffSource opens iterators over a state.
+type diffSource interface {
+	// accounts returns an iterator over the encoded accounts of the state,
+	// starting from the given account hash.
+	accounts(root common.Hash, start common.Hash) (diffIterator, error)
+
+	// storage returns an iterator over the RLP-encoded storage slots of the
+	// account in the state.
+	storage(root common.Hash, account common.Hash, storageRoot common.Hash) (diffIterator, error)
+
+	// decode decodes the account returned by the account iterators.
+	decode(blob []byte) (*types.StateAccount, error)
+}
+
+// snapDiffSource is a diffSource backed by the snapshot.
+type snapDiffSource struct {
+	tree *snapshot.Tree
+}
+
+type snapAccountIterator struct{ snapshot.AccountIterator }
+
+func (it snapAccountIterator) Value() []byte { return it.Account() }
+
+type snapStorageIterator struct{ snapshot.StorageIterator }
+
+func (it snapStorageIterator) Value() []byte { return it.Slot() }
+
+func (s *snapDiffSource) accounts(root common.Hash, start common.Hash) (diffIterator, error) {
+	it, err := s.tree.AccountIterator(root, start)
+	if err != nil {
+		return nil, err
+	}
+	return snapAccountIterator{it}, nil
+}
+
+func (s *snapDiffSource) storage(root common.Hash, account common.Hash, storageRoot common.Hash) (diffIterator, error) {
+	if storageRoot == types.EmptyRootHash {
+		return emptyDiffIterator{}, nil
+	}
+	it, err := s.tree.StorageIterator(root, account, common.Hash{})
+	if err != nil {
+		return nil, err
+	}
+	return snapStorageIterator{it}, nil
+}
+
+func (s *snapDiffSource) decode(blob []byte) (*types.StateAccount, error) {
+	return types.FullAccount(blob)
+}
+
+// trieDiffSource is a diffSource backed by the tries.
+type trieDiffSource struct {
+	db *triedb.Database
+}
+
+type trieDiffIterator struct{ *trie.Iterator }
+
+func (it trieDiffIterator) Hash() common.Hash { return common.BytesToHash(it.Key) }
+func (it trieDiffIterator) Value() []byte     { return it.Iterator.Value }
+func (it trieDiffIterator) Error() error      { return it.Err }
+func (it trieDiffIterator) Release()          {}
+
+func (s *trieDiffSource) accounts(root common.Hash, start common.Hash) (diffIterator, error) {
+	tr, err := trie.NewStateTrie(trie.StateTrieID(root), s.db)
+	if err != nil {
+		return nil, err
+	}
+	it, err := tr.NodeIterator(start.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return trieDiffIterator{trie.NewIterator(it)}, nil
+}
+
+func (s *trieDiffSource) storage(root common.Hash, account common.Hash, storageRoot common.Hash) (diffIterator, error) {
+	if storageRoot == types.EmptyRootHash {
+		return emptyDiffIterator{}, nil
+	}
+	tr, err := trie.NewStateTrie(trie.StorageTrieID(root, account, storageRoot), s.db)
+	if err != nil {
+		return nil, err
+	}
+	it, err := tr.NodeIterator(nil)
+	if err != nil {
+		return nil, err
+	}
+	return trieDiffIterator{trie.NewIterator(it)}, nil
+}
+
+func (s *trieDiffSource) decode(blob []byte) (*types.StateAccount, error) {
+	acc := new(types.StateAccount)
+	if err := rlp.DecodeBytes(blob, acc); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+// emptyDiffIterator is an iterator over an empty storage.
+type emptyDiffIterator struct{}
+
+func (emptyDiffIterator) Next() bool        { return false }
+func (emptyDiffIterator) Hash() common.Hash { return common.Hash{} }
+func (emptyDiffIterator) Value() []byte     { return nil }
+func (emptyDiffIterator) Error() error      { return nil }
+func (emptyDiffIterator) Release()          {}
@@ -0,0 +1,162 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/holiman/uint256"
+)
+
+func TestStateDiff(t *testing.T) {
+	var (
+		disk     = rawdb.NewMemoryDatabase()
+		tdb      = triedb.NewDatabase(disk, &triedb.Config{Preimages: true})
+		snaps, _ = snapshot.New(snapshot.Config{CacheSize: 10}, disk, tdb, types.EmptyRootHash, 128, false)
+		db       = NewDatabase(tdb, snaps)
+
+		modified  = common.HexToAddress("0x01")
+		deleted   = common.HexToAddress("0x02")
+		created   = common.HexToAddress("0x03")
+		untouched = common.HexToAddress("0x04")
+	)
+	state, _ := New(types.EmptyRootHash, db)
+	state.SetBalance(modified, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	state.SetState(modified, common.HexToHash("0x01"), common.HexToHash("0x01"))
+	state.SetState(modified, common.HexToHash("0x02"), common.HexToHash("0x02"))
+	state.SetNonce(deleted, 1, tracing.NonceChangeUnspecified)
+	state.SetState(deleted, common.HexToHash("0x01"), common.HexToHash("0x01"))
+	state.SetBalance(untouched, uint256.NewInt(1), tracing.BalanceChangeUnspecified)
+	rootA, err := state.Commit(0, true, false)
+	if err != nil {
+		t.Fatalf("Failed to commit state: %v", err)
+	}
+	state, _ = New(rootA, db)
+	state.SetBalance(modified, uint256.NewInt(2), tracing.BalanceChangeUnspecified)
+	state.SetState(modified, common.HexToHash("0x02"), common.HexToHash("0x03"))
+	state.SetState(modified, common.HexToHash("0x03"), common.HexToHash("0x03"))
+	state.SelfDestruct(deleted)
+	state.SetCode(created, []byte{0x1})
+	rootB, err := state.Commit(1, true, false)
+	if err != nil {
+		t.Fatalf("Failed to commit state: %v", err)
+	}
+	for _, snaps := range []*snapshot.Tree{snaps, nil} {
+		differ := NewStateDiffer(tdb, snaps)
+
+		diffs := make(map[common.Address]*AccountDiff)
+		next, err := differ.Diff(context.Background(), rootA, rootB, nil, func(diff *AccountDiff) error {
+			if diff.Address == nil {
+				t.Fatalf("Missing address preimage of %x", diff.AddressHash)
+			}
+			diffs[*diff.Address] = diff
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed to diff states (snapshot %v): %v", snaps != nil, err)
+		}
+		if next != nil || len(diffs) != 3 {
+			t.Fatalf("Unexpected diff (snapshot %v): next %x, accounts %d", snaps != nil, next, len(diffs))
+		}
+		if diff := diffs[modified]; diff.Kind != DiffModified || diff.Balance == nil || (*uint256.Int)(diff.Balance.To).Uint64() != 2 || diff.Nonce != nil || diff.CodeHash != nil {
+			t.Fatalf("Unexpected modified account: %+v", diff)
+		}
+		if slots := diffs[modified].Storage; len(slots) != 2 {
+			t.Fatalf("Unexpected changed slots: %d", len(slots))
+		}
+		for _, slot := range diffs[modified].Storage {
+			if slot.Slot == nil || slot.Hash != crypto.Keccak256Hash(slot.Slot.Bytes()) {
+				t.Fatalf("Missing slot preimage of %x", slot.Hash)
+			}
+			if slot.To != common.HexToHash("0x03") {
+				t.Fatalf("Unexpected slot change: %+v", slot)
+			}
+		}
+		if diff := diffs[deleted]; diff.Kind != DiffDeleted || diff.Nonce == nil || len(diff.Storage) != 1 {
+			t.Fatalf("Unexpected deleted account: %+v", diff)
+		}
+		if diff := diffs[created]; diff.Kind != DiffCreated || diff.CodeHash == nil || diff.CodeHash.To != crypto.Keccak256Hash([]byte{0x1}) {
+			t.Fatalf("Unexpected created account: %+v", diff)
+		}
+		// Limit the comparison to certain accounts
+		var filtered []*AccountDiff
+		conf := &DiffConfig{Addresses: []common.Address{untouched, created}}
+		if _, err := differ.Diff(context.Background(), rootA, rootB, conf, func(diff *AccountDiff) error {
+			filtered = append(filtered, diff)
+			return nil
+		}); err != nil {
+			t.Fatalf("Failed to diff states: %v", err)
+		}
+		if len(filtered) != 1 || *filtered[0].Address != created {
+			t.Fatalf("Unexpected filtered diff: %v", filtered)
+		}
+		// Resume the comparison in pages of one account
+		var (
+			pages int
+			start []byte
+		)
+		for {
+			next, err := differ.Diff(context.Background(), rootA, rootB, &DiffConfig{Start: start, Max: 1}, func(*AccountDiff) error { return nil })
+			if err != nil {
+				t.Fatalf("Failed to diff states: %v", err)
+			}
+			pages++
+			if next == nil {
+				break
+			}
+			start = next
+		}
+		if pages != 3 {
+			t.Fatalf("Unexpected pages: %d", pages)
+		}
+		// Truncate the storage changes exceeding the slot maximum
+		var limited []*AccountDiff
+		next, err = differ.Diff(context.Background(), rootA, rootB, &DiffConfig{MaxSlots: 1}, func(diff *AccountDiff) error {
+			limited = append(limited, diff)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed to diff states: %v", err)
+		}
+		var slots int
+		for _, diff := range limited {
+			slots += len(diff.Storage)
+		}
+		if slots != 1 || next == nil {
+			t.Fatalf("Unexpected slot limited diff: slots %d, next %x", slots, next)
+		}
+		// Only the modified account has more changed slots than the maximum
+		if last := limited[len(limited)-1]; len(last.Storage) != 1 || last.Truncated != (*last.Address == modified) {
+			t.Fatalf("Unexpected truncation of the last account: %+v", last)
+		}
+		// Abort the comparison with a cancelled context
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := differ.Diff(ctx, rootA, rootB, nil, func(*AccountDiff) error { return nil }); !errors.Is(err, context.Canceled) {
+			t.Fatalf("Unexpected error of cancelled diff: %v", err)
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/VictoriaMetrics/fastcache"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -739,6 +740,57 @@ func (t *Tree) Rebuild(root common.Hash) {
 	}
 }
 
+// Reload drops all the layers and reopens the persistent snapshot, which was
+// modified externally, e.g. replicated from another node, as the only layer.
+// The snapshot generation is not resumed.
+func (t *Tree) Reload() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	root := rawdb.ReadSnapshotRoot(t.diskdb)
+	if root == (common.Hash{}) {
+		return errors.New("missing or corrupted snapshot")
+	}
+	base := &diskLayer{
+		diskdb: t.diskdb,
+		triedb: t.triedb,
+		cache:  fastcache.New(t.config.CacheSize * 1024 * 1024),
+		root:   root,
+	}
+	if blob := rawdb.ReadSnapshotGenerator(t.diskdb); len(blob) > 0 {
+		var generator journalGenerator
+		if err := rlp.DecodeBytes(blob, &generator); err != nil {
+			return fmt.Errorf("failed to decode snapshot generator: %v", err)
+		}
+		if !generator.Done {
+			base.genMarker = generator.Marker
+			if base.genMarker == nil {
+				base.genMarker = []byte{}
+			}
+		}
+	}
+	// Iterate over and mark all layers stale
+	for _, layer := range t.layers {
+		switch layer := layer.(type) {
+		case *diskLayer:
+			layer.stopGeneration()
+			layer.markStale()
+			layer.Release()
+
+		case *diffLayer:
+			layer.lock.Lock()
+			layer.stale.Store(true)
+			layer.lock.Unlock()
+
+		default:
+			panic(fmt.Sprintf("unknown layer type: %T", layer))
+		}
+	}
+	t.layers = map[common.Hash]snapshot{root: base}
+	log.Debug("Reloaded state snapshot", "root", root)
+	return nil
+}
+
 // AccountIterator creates a new account iterator for the specified root hash and
 // seeks to a starting account hash.
 func (t *Tree) AccountIterator(root common.Hash, seek common.Hash) (AccountIterator, error) {
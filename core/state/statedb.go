@@ -443,6 +443,32 @@ func (s *StateDB) GetStorageRoot(addr common.Address) common.Hash {
 	return common.Hash{}
 }
 
+// ValidateKnownAccounts checks the storage of the accounts against the expected
+// storage roots or slot values of a conditional transaction.
+//
+// The storage root of an account modified since the last root computation is
+// not known without hashing the pending changes, so an expected root of such an
+// account is reported as not met.
+func (s *StateDB) ValidateKnownAccounts(accounts types.KnownAccounts) error {
+	for addr, storage := range accounts {
+		if storage.StorageRoot != nil {
+			if obj := s.getStateObject(addr); obj != nil && (len(obj.dirtyStorage) > 0 || len(obj.uncommittedStorage) > 0) {
+				return types.ErrKnownStorageRoot
+			}
+			if s.GetRoot(addr) != *storage.StorageRoot {
+				return types.ErrKnownStorageRoot
+			}
+			continue
+		}
+		for slot, value := range storage.StorageSlots {
+			if s.GetState(addr, slot) != value {
+				return types.ErrKnownStorageSlot
+			}
+		}
+	}
+	return nil
+}
+
 // TxIndex returns the current transaction index set by SetTxContext.
 func (s *StateDB) TxIndex() int {
 	return s.txIndex
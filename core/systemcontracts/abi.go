@@ -0,0 +1,5 @@
+package systemcontracts
+
+// SlashIndicatorFinalityABI is the ABI of the finality violation evidence submission
+// method of the slash indicator contract.
+const SlashIndicatorFinalityABI = `[{"inputs":[{"components":[{"components":[{"internalType":"uint256","name":"srcNum","type":"uint256"},{"internalType":"bytes32","name":"srcHash","type":"bytes32"},{"internalType":"uint256","name":"tarNum","type":"uint256"},{"internalType":"bytes32","name":"tarHash","type":"bytes32"},{"internalType":"bytes","name":"sig","type":"bytes"}],"internalType":"structSlashIndicator.VoteData","name":"voteA","type":"tuple"},{"components":[{"internalType":"uint256","name":"srcNum","type":"uint256"},{"internalType":"bytes32","name":"srcHash","type":"bytes32"},{"internalType":"uint256","name":"tarNum","type":"uint256"},{"internalType":"bytes32","name":"tarHash","type":"bytes32"},{"internalType":"bytes","name":"sig","type":"bytes"}],"internalType":"structSlashIndicator.VoteData","name":"voteB","type":"tuple"},{"internalType":"bytes","name":"voteAddr","type":"bytes"}],"internalType":"structSlashIndicator.FinalityEvidence","name":"_evidence","type":"tuple"}],"name":"submitFinalityViolationEvidence","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
@@ -1044,19 +1044,7 @@ func upgradeBuildInSystemContract(config *params.ChainConfig, blockNumber *big.I
 		return
 	}
 
-	var network string
-	switch GenesisHash {
-	/* Add mainnet genesis hash */
-	case params.BSCGenesisHash:
-		network = mainNet
-	case params.ChapelGenesisHash:
-		network = chapelNet
-	case params.RialtoGenesisHash:
-		network = rialtoNet
-	default:
-		network = defaultNet
-	}
-
+	network := networkOf(GenesisHash)
 	logger := log.New("system-contract-upgrade", network)
 	if config.IsOnRamanujan(blockNumber) {
 		applySystemContractUpgrade(ramanujanUpgrade[network], blockNumber, statedb, logger)
@@ -1139,6 +1127,68 @@ func upgradeBuildInSystemContract(config *params.ChainConfig, blockNumber *big.I
 	*/
 }
 
+// networkOf returns the name of the network with the given genesis hash.
+func networkOf(genesisHash common.Hash) string {
+	switch genesisHash {
+	/* Add mainnet genesis hash */
+	case params.BSCGenesisHash:
+		return mainNet
+	case params.ChapelGenesisHash:
+		return chapelNet
+	case params.RialtoGenesisHash:
+		return rialtoNet
+	default:
+		return defaultNet
+	}
+}
+
+// GenesisUpgrades returns the code of the system contracts upgraded by the given
+// network for every fork enabled at genesis by config, in the order of the forks.
+// Together with the genesis contracts of that network, it allows a new network
+// to start with the system contracts the network runs.
+func GenesisUpgrades(genesisHash common.Hash, config *params.ChainConfig) (map[common.Address][]byte, error) {
+	var (
+		network  = networkOf(genesisHash)
+		upgrades = []struct {
+			enabled bool
+			upgrade map[string]*Upgrade
+		}{
+			{config.IsRamanujan(common.Big0), ramanujanUpgrade},
+			{config.IsNiels(common.Big0), nielsUpgrade},
+			{config.IsMirrorSync(common.Big0), mirrorUpgrade},
+			{config.IsBruno(common.Big0), brunoUpgrade},
+			{config.IsEuler(common.Big0), eulerUpgrade},
+			{config.IsGibbs(common.Big0), gibbsUpgrade},
+			{config.IsMoran(common.Big0), moranUpgrade},
+			{config.IsPlanck(common.Big0), planckUpgrade},
+			{config.IsLuban(common.Big0), lubanUpgrade},
+			{config.IsPlato(common.Big0), platoUpgrade},
+			{config.IsKepler(common.Big0, 0), keplerUpgrade},
+			{config.IsFeynman(common.Big0, 0), feynmanUpgrade},
+			{config.IsFeynmanFix(common.Big0, 0), feynmanFixUpgrade},
+			{config.IsHaberFix(common.Big0, 0), haberFixUpgrade},
+			{config.IsBohr(common.Big0, 0), bohrUpgrade},
+			{config.IsPascal(common.Big0, 0), pascalUpgrade},
+			{config.IsLorentz(common.Big0, 0), lorentzUpgrade},
+			{config.IsMaxwell(common.Big0, 0), maxwellUpgrade},
+		}
+		codes = make(map[common.Address][]byte)
+	)
+	for _, fork := range upgrades {
+		if !fork.enabled || fork.upgrade[network] == nil {
+			continue
+		}
+		for _, cfg := range fork.upgrade[network].Configs {
+			code, err := hex.DecodeString(strings.TrimSpace(cfg.Code))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s code of contract %s: %v", fork.upgrade[network].UpgradeName, cfg.ContractAddr, err)
+			}
+			codes[cfg.ContractAddr] = code
+		}
+	}
+	return codes, nil
+}
+
 func applySystemContractUpgrade(upgrade *Upgrade, blockNumber *big.Int, statedb vm.StateDB, logger log.Logger) {
 	if upgrade == nil {
 		logger.Info("Empty upgrade config", "height", blockNumber.String())
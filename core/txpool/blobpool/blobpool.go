@@ -322,6 +322,11 @@ type BlobPool struct {
 	reannoTxFeed event.Feed // Event feed for announcing transactions again
 	scope        event.SubscriptionScope
 
+	txEventFeed  event.Feed              // Event feed for the lifecycle of the transactions
+	txEventScope event.SubscriptionScope // Subscriptions to the lifecycle events, recorded only if any
+	txEvents     []*txpool.TxEvent       // Lifecycle events recorded, not sent yet
+	txEventsLock sync.Mutex              // Mutex protecting the recorded lifecycle events
+
 	// txValidationFn defaults to txpool.ValidateTransaction, but can be
 	// overridden for testing purposes.
 	txValidationFn txpool.ValidationFunction
@@ -546,6 +551,12 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.stored -= uint64(txs[i].size)
 			p.lookup.untrack(txs[i])
 
+			if gapped {
+				p.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonNonceGap, addr, txs[i])
+			} else {
+				p.recordInclusion(addr, txs[i], inclusions)
+			}
+
 			// Included transactions blobs need to be moved to the limbo
 			if filled && inclusions != nil {
 				p.offload(addr, txs[i].nonce, txs[i].id, inclusions)
@@ -586,6 +597,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[0].costCap)
 			p.stored -= uint64(txs[0].size)
 			p.lookup.untrack(txs[0])
+			p.recordInclusion(addr, txs[0], inclusions)
 
 			// Included transactions blobs need to be moved to the limbo
 			if inclusions != nil {
@@ -642,6 +654,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[i].costCap)
 			p.stored -= uint64(txs[i].size)
 			p.lookup.untrack(txs[i])
+			p.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonDuplicate, addr, txs[i])
 
 			if err := p.store.Delete(id); err != nil {
 				log.Error("Failed to delete blob transaction", "from", addr, "id", id, "err", err)
@@ -664,6 +677,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[j].costCap)
 			p.stored -= uint64(txs[j].size)
 			p.lookup.untrack(txs[j])
+			p.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonNonceGap, addr, txs[j])
 		}
 		txs = txs[:i]
 
@@ -702,6 +716,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], last.costCap)
 			p.stored -= uint64(last.size)
 			p.lookup.untrack(last)
+			p.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonInsufficientFunds, addr, last)
 		}
 		if len(txs) == 0 {
 			delete(p.index, addr)
@@ -742,6 +757,7 @@ func (p *BlobPool) recheck(addr common.Address, inclusions map[common.Hash]uint6
 			p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], last.costCap)
 			p.stored -= uint64(last.size)
 			p.lookup.untrack(last)
+			p.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonAccountLimit, addr, last)
 		}
 		p.index[addr] = txs
 
@@ -793,6 +809,8 @@ func (p *BlobPool) offload(addr common.Address, nonce uint64, id uint64, inclusi
 // Reset implements txpool.SubPool, allowing the blob pool's internal state to be
 // kept in sync with the main transaction pool's internal state.
 func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
+	defer p.sendTxEvents()
+
 	waitStart := time.Now()
 	p.lock.Lock()
 	resetwaitHist.Update(time.Since(waitStart).Nanoseconds())
@@ -819,6 +837,7 @@ func (p *BlobPool) Reset(oldHead, newHead *types.Header) {
 			for _, tx := range txs {
 				if err := p.reinject(addr, tx.Hash()); err == nil {
 					adds = append(adds, tx.WithoutBlobTxSidecar())
+					p.recordTxEventBy(txpool.TxEventAdded, "", addr, tx.Hash(), tx.Nonce(), common.Hash{})
 				}
 			}
 			// Recheck the account's pooled transactions to drop included and
@@ -1027,6 +1046,8 @@ func (p *BlobPool) reinject(addr common.Address, txhash common.Hash) error {
 // SetGasTip implements txpool.SubPool, allowing the blob pool's gas requirements
 // to be kept in sync with the main transaction pool's gas requirements.
 func (p *BlobPool) SetGasTip(tip *big.Int) {
+	defer p.sendTxEvents()
+
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -1047,6 +1068,7 @@ func (p *BlobPool) SetGasTip(tip *big.Int) {
 					p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], txs[i].costCap)
 					p.stored -= uint64(tx.size)
 					p.lookup.untrack(tx)
+					p.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonUnderpriced, addr, tx)
 					txs[i] = nil
 
 					// Drop everything afterwards, no gaps allowed
@@ -1057,6 +1079,7 @@ func (p *BlobPool) SetGasTip(tip *big.Int) {
 						p.spent[addr] = new(uint256.Int).Sub(p.spent[addr], tx.costCap)
 						p.stored -= uint64(tx.size)
 						p.lookup.untrack(tx)
+						p.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonNonceGap, addr, tx)
 						txs[i+1+j] = nil
 					}
 					// Clear out the dropped transactions from the index
@@ -1299,6 +1322,7 @@ func (p *BlobPool) Add(txs []*types.Transaction, sync bool) []error {
 		p.discoverFeed.Send(core.NewTxsEvent{Txs: adds})
 		p.insertFeed.Send(core.NewTxsEvent{Txs: adds})
 	}
+	p.sendTxEvents()
 	return errs
 }
 
@@ -1398,6 +1422,7 @@ func (p *BlobPool) add(tx *types.Transaction) (err error) {
 		p.lookup.untrack(prev)
 		p.lookup.track(meta)
 		p.stored += uint64(meta.size) - uint64(prev.size)
+		p.recordTxEventBy(txpool.TxEventReplaced, "", from, prev.hash, prev.nonce, meta.hash)
 	} else {
 		// Transaction extends previously scheduled ones
 		p.index[from] = append(p.index[from], meta)
@@ -1409,6 +1434,8 @@ func (p *BlobPool) add(tx *types.Transaction) (err error) {
 		p.lookup.track(meta)
 		p.stored += uint64(meta.size)
 	}
+	p.recordTxEvent(txpool.TxEventAdded, "", from, meta)
+
 	// Recompute the rolling eviction fields. In case of a replacement, this will
 	// recompute all subsequent fields. In case of an append, this will only do
 	// the fresh calculation.
@@ -1496,6 +1523,7 @@ func (p *BlobPool) drop() {
 	}
 	p.stored -= uint64(drop.size)
 	p.lookup.untrack(drop)
+	p.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonPoolLimit, from, drop)
 
 	// Remove the transaction from the pool's eviction heap:
 	//   - If the entire account was dropped, pop off the address
@@ -1678,6 +1706,60 @@ func (p *BlobPool) SubscribeReannoTxsEvent(ch chan<- core.ReannoTxsEvent) event.
 	return p.scope.Track(p.reannoTxFeed.Subscribe(ch))
 }
 
+// SubscribeTxEvents registers a subscription for the lifecycle events of the
+// transactions.
+func (p *BlobPool) SubscribeTxEvents(ch chan<- []*txpool.TxEvent) event.Subscription {
+	return p.txEventScope.Track(p.txEventFeed.Subscribe(ch))
+}
+
+// recordTxEvent records a lifecycle event of a pooled transaction, to be sent
+// once the pool lock is released. Nothing is recorded without subscribers.
+func (p *BlobPool) recordTxEvent(kind txpool.TxEventKind, reason txpool.TxEventReason, from common.Address, meta *blobTxMeta) {
+	p.recordTxEventBy(kind, reason, from, meta.hash, meta.nonce, common.Hash{})
+}
+
+// recordTxEventBy records a lifecycle event of a transaction, caused by the
+// given replacement if any.
+func (p *BlobPool) recordTxEventBy(kind txpool.TxEventKind, reason txpool.TxEventReason, from common.Address, hash common.Hash, nonce uint64, replacement common.Hash) {
+	if p.txEventScope.Count() == 0 {
+		return
+	}
+	ev := &txpool.TxEvent{
+		Kind:       kind,
+		Reason:     reason,
+		Hash:       hash,
+		From:       from,
+		Nonce:      nonce,
+		ReplacedBy: replacement,
+	}
+	p.txEventsLock.Lock()
+	p.txEvents = append(p.txEvents, ev)
+	p.txEventsLock.Unlock()
+}
+
+// recordInclusion records the removal of a transaction whose nonce was used on
+// chain, either by itself or by another transaction.
+func (p *BlobPool) recordInclusion(from common.Address, meta *blobTxMeta, inclusions map[common.Hash]uint64) {
+	if _, ok := inclusions[meta.hash]; ok {
+		p.recordTxEvent(txpool.TxEventIncluded, "", from, meta)
+	} else {
+		p.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonNonceTooLow, from, meta)
+	}
+}
+
+// sendTxEvents sends the recorded lifecycle events to the subscribers. It must
+// not be called while holding the pool lock, a subscriber may be slow.
+func (p *BlobPool) sendTxEvents() {
+	p.txEventsLock.Lock()
+	events := p.txEvents
+	p.txEvents = nil
+	p.txEventsLock.Unlock()
+
+	if len(events) > 0 {
+		p.txEventFeed.Send(events)
+	}
+}
+
 // Nonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (p *BlobPool) Nonce(addr common.Address) uint64 {
@@ -27,6 +27,7 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
@@ -1681,3 +1682,69 @@ func benchmarkPoolPending(b *testing.B, datacap uint64) {
 		}
 	}
 }
+
+// Tests that the lifecycle events of the blob transactions are reported with
+// their reasons.
+func TestTransactionLifecycleEvents(t *testing.T) {
+	storage, _ := os.MkdirTemp("", "blobpool-")
+	defer os.RemoveAll(storage)
+
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+
+		tx      = makeTx(0, 1, 1100, 110, key)
+		replace = makeTx(0, 2, 2200, 220, key)
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	statedb.AddBalance(addr, uint256.NewInt(1_000_000_000), tracing.BalanceChangeUnspecified)
+	statedb.Commit(0, true, false)
+
+	chain := &testBlockChain{
+		config:  params.MainnetChainConfig,
+		basefee: uint256.NewInt(1050),
+		blobfee: uint256.NewInt(105),
+		statedb: statedb,
+	}
+	pool := New(Config{Datadir: storage}, chain)
+	if err := pool.Init(1, chain.CurrentBlock(), makeAddressReserver()); err != nil {
+		t.Fatalf("failed to create blob pool: %v", err)
+	}
+	defer pool.Close()
+
+	events := make(chan []*txpool.TxEvent, 16)
+	sub := pool.SubscribeTxEvents(events)
+	defer sub.Unsubscribe()
+
+	expect := func(want ...txpool.TxEvent) {
+		t.Helper()
+
+		select {
+		case batch := <-events:
+			if len(batch) != len(want) {
+				t.Fatalf("event count mismatch: have %d, want %d", len(batch), len(want))
+			}
+			for i, ev := range batch {
+				if *ev != want[i] {
+					t.Errorf("event %d mismatch: have %+v, want %+v", i, *ev, want[i])
+				}
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("events missing")
+		}
+	}
+	if errs := pool.Add([]*types.Transaction{tx}, true); errs[0] != nil {
+		t.Fatalf("failed to add transaction: %v", errs[0])
+	}
+	expect(txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: tx.Hash(), From: addr, Nonce: 0})
+
+	if errs := pool.Add([]*types.Transaction{replace}, true); errs[0] != nil {
+		t.Fatalf("failed to replace transaction: %v", errs[0])
+	}
+	expect(
+		txpool.TxEvent{Kind: txpool.TxEventReplaced, Hash: tx.Hash(), From: addr, Nonce: 0, ReplacedBy: replace.Hash()},
+		txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: replace.Hash(), From: addr, Nonce: 0},
+	)
+	pool.SetGasTip(big.NewInt(3))
+	expect(txpool.TxEvent{Kind: txpool.TxEventDropped, Reason: txpool.TxReasonUnderpriced, Hash: replace.Hash(), From: addr, Nonce: 0})
+}
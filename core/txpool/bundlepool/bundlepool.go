@@ -0,0 +1,245 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package bundlepool implements the pool of the transaction bundles submitted
+// for inclusion in the blocks built locally.
+package bundlepool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// ErrBundlePoolFull is returned if the pool holds as many bundles as allowed.
+	ErrBundlePoolFull = errors.New("bundle pool full")
+
+	// ErrBundleExpired is returned if no block after the head may include the bundle.
+	ErrBundleExpired = errors.New("bundle expired")
+
+	// ErrBundleTooFar is returned if the bundle targets blocks too far after the head.
+	ErrBundleTooFar = errors.New("bundle targets blocks too far in the future")
+
+	// ErrBundleTooLarge is returned if the bundle holds too many transactions.
+	ErrBundleTooLarge = errors.New("bundle holds too many transactions")
+
+	// ErrBlobTxInBundle is returned if the bundle holds a blob transaction.
+	ErrBlobTxInBundle = errors.New("blob transactions not allowed in bundles")
+)
+
+var (
+	bundleGauge    = metrics.NewRegisteredGauge("bundlepool/bundles", nil)
+	expiredMeter   = metrics.NewRegisteredMeter("bundlepool/expired", nil)
+	committedMeter = metrics.NewRegisteredMeter("bundlepool/committed", nil)
+	addedMeter     = metrics.NewRegisteredMeter("bundlepool/added", nil)
+	rejectedMeter  = metrics.NewRegisteredMeter("bundlepool/rejected", nil)
+)
+
+// Config are the configuration parameters of the bundle pool.
+type Config struct {
+	Slots      int    // Maximum number of bundles in the pool
+	MaxTxs     int    // Maximum number of transactions of a bundle
+	MaxBlocks  uint64 // Maximum number of blocks after the head a bundle may target
+	ChainHeads int    // Size of the chain head event channel
+}
+
+// DefaultConfig contains the default configurations for the bundle pool.
+var DefaultConfig = Config{
+	Slots:      1024,
+	MaxTxs:     64,
+	MaxBlocks:  100,
+	ChainHeads: 10,
+}
+
+// BlockChain defines the minimal set of methods needed to back a bundle pool
+// with a chain.
+type BlockChain interface {
+	txpool.BlockChain
+
+	// Config retrieves the chain's fork configuration.
+	Config() *params.ChainConfig
+
+	// GetBlock retrieves a specific block, used to drop the included bundles.
+	GetBlock(hash common.Hash, number uint64) *types.Block
+}
+
+// BundlePool holds the bundles until no block may include them any more.
+type BundlePool struct {
+	config Config
+	chain  BlockChain
+	signer types.Signer
+
+	bundles map[common.Hash]*types.Bundle
+	head    uint64 // Number of the chain head, the bundles targeting it are expired
+	mu      sync.RWMutex
+
+	headSub event.Subscription
+	wg      sync.WaitGroup
+}
+
+// New creates a bundle pool following the chain head to expire the bundles.
+func New(config Config, chain BlockChain) *BundlePool {
+	pool := &BundlePool{
+		config:  config,
+		chain:   chain,
+		signer:  types.LatestSigner(chain.Config()),
+		bundles: make(map[common.Hash]*types.Bundle),
+		head:    chain.CurrentBlock().Number.Uint64(),
+	}
+	headCh := make(chan core.ChainHeadEvent, config.ChainHeads)
+	pool.headSub = chain.SubscribeChainHeadEvent(headCh)
+
+	pool.wg.Add(1)
+	go pool.loop(headCh)
+	return pool
+}
+
+// Close stops following the chain head.
+func (pool *BundlePool) Close() {
+	pool.headSub.Unsubscribe()
+	pool.wg.Wait()
+}
+
+func (pool *BundlePool) loop(headCh chan core.ChainHeadEvent) {
+	defer pool.wg.Done()
+
+	for {
+		select {
+		case ev := <-headCh:
+			pool.expire(ev.Header)
+		case <-pool.headSub.Err():
+			return
+		}
+	}
+}
+
+// expire drops the bundles no block after the head may include, along with the
+// bundles committed to the head block, or otherwise conflicting with it.
+func (pool *BundlePool) expire(head *types.Header) {
+	included := make(map[common.Hash]struct{})
+	if block := pool.chain.GetBlock(head.Hash(), head.Number.Uint64()); block != nil {
+		for _, tx := range block.Transactions() {
+			included[tx.Hash()] = struct{}{}
+		}
+	}
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.head = head.Number.Uint64()
+	var expired, committed int
+	for hash, bundle := range pool.bundles {
+		if bundle.MaxBlockNumber <= pool.head {
+			delete(pool.bundles, hash)
+			expired++
+			continue
+		}
+		for _, tx := range bundle.Txs {
+			if _, ok := included[tx.Hash()]; ok {
+				delete(pool.bundles, hash)
+				committed++
+				break
+			}
+		}
+	}
+	expiredMeter.Mark(int64(expired))
+	committedMeter.Mark(int64(committed))
+	bundleGauge.Update(int64(len(pool.bundles)))
+}
+
+// Add validates the bundle and inserts it into the pool.
+func (pool *BundlePool) Add(bundle *types.Bundle) error {
+	err := pool.add(bundle)
+	if err != nil {
+		rejectedMeter.Mark(1)
+		log.Debug("Rejected bundle", "hash", bundle.Hash(), "err", err)
+		return err
+	}
+	addedMeter.Mark(1)
+	return nil
+}
+
+func (pool *BundlePool) add(bundle *types.Bundle) error {
+	if len(bundle.Txs) > pool.config.MaxTxs {
+		return fmt.Errorf("%w: %d > %d", ErrBundleTooLarge, len(bundle.Txs), pool.config.MaxTxs)
+	}
+	for _, tx := range bundle.Txs {
+		if tx.Type() == types.BlobTxType {
+			return ErrBlobTxInBundle
+		}
+		if _, err := types.Sender(pool.signer, tx); err != nil {
+			return fmt.Errorf("%w: %v", txpool.ErrInvalidSender, err)
+		}
+	}
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if bundle.MaxBlockNumber <= pool.head {
+		return ErrBundleExpired
+	}
+	if bundle.MaxBlockNumber > pool.head+pool.config.MaxBlocks {
+		return fmt.Errorf("%w: %d > %d", ErrBundleTooFar, bundle.MaxBlockNumber, pool.head+pool.config.MaxBlocks)
+	}
+	hash := bundle.Hash()
+	if _, ok := pool.bundles[hash]; ok {
+		return txpool.ErrAlreadyKnown
+	}
+	if len(pool.bundles) >= pool.config.Slots {
+		return ErrBundlePoolFull
+	}
+	pool.bundles[hash] = bundle
+	bundleGauge.Update(int64(len(pool.bundles)))
+	return nil
+}
+
+// Get returns the bundle of the given hash, or nil if unknown.
+func (pool *BundlePool) Get(hash common.Hash) *types.Bundle {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return pool.bundles[hash]
+}
+
+// Pending returns the bundles the block of the given number may include.
+func (pool *BundlePool) Pending(number uint64) []*types.Bundle {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	bundles := make([]*types.Bundle, 0, len(pool.bundles))
+	for _, bundle := range pool.bundles {
+		if bundle.Targets(number) {
+			bundles = append(bundles, bundle)
+		}
+	}
+	return bundles
+}
+
+// Len returns the number of bundles in the pool.
+func (pool *BundlePool) Len() int {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return len(pool.bundles)
+}
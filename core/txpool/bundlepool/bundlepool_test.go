@@ -0,0 +1,153 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bundlepool
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+type testBlockChain struct {
+	head          *types.Header
+	blocks        map[common.Hash]*types.Block
+	chainHeadFeed event.Feed
+}
+
+func (bc *testBlockChain) Config() *params.ChainConfig { return params.TestChainConfig }
+func (bc *testBlockChain) CurrentBlock() *types.Header { return bc.head }
+
+func (bc *testBlockChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return bc.blocks[hash]
+}
+
+func (bc *testBlockChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return bc.chainHeadFeed.Subscribe(ch)
+}
+
+func testBundle(t *testing.T, min, max uint64, nonces ...uint64) *types.Bundle {
+	key, _ := crypto.GenerateKey()
+	signer := types.LatestSigner(params.TestChainConfig)
+
+	bundle := &types.Bundle{MinBlockNumber: min, MaxBlockNumber: max}
+	for _, nonce := range nonces {
+		tx, err := types.SignNewTx(key, signer, &types.LegacyTx{Nonce: nonce, Gas: 21000, GasPrice: big.NewInt(1), To: &common.Address{}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		bundle.Txs = append(bundle.Txs, tx)
+	}
+	return bundle
+}
+
+func TestBundlePoolAdd(t *testing.T) {
+	chain := &testBlockChain{head: &types.Header{Number: big.NewInt(10)}}
+	pool := New(Config{Slots: 2, MaxTxs: 2, MaxBlocks: 5, ChainHeads: 1}, chain)
+	defer pool.Close()
+
+	tests := []struct {
+		bundle *types.Bundle
+		err    error
+	}{
+		{testBundle(t, 0, 10, 0), ErrBundleExpired},
+		{testBundle(t, 0, 16, 0), ErrBundleTooFar},
+		{testBundle(t, 0, 15, 0, 1, 2), ErrBundleTooLarge},
+		{testBundle(t, 0, 11, 0), nil},
+		{testBundle(t, 12, 15, 0, 1), nil},
+		{testBundle(t, 0, 11, 0), ErrBundlePoolFull},
+	}
+	for i, test := range tests {
+		if err := pool.Add(test.bundle); !errors.Is(err, test.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, test.err)
+		}
+	}
+	if err := pool.Add(tests[3].bundle); !errors.Is(err, txpool.ErrAlreadyKnown) {
+		t.Errorf("duplicate error mismatch: have %v, want %v", err, txpool.ErrAlreadyKnown)
+	}
+	if pool.Get(tests[3].bundle.Hash()) == nil {
+		t.Errorf("added bundle missing")
+	}
+	if have := len(pool.Pending(11)); have != 1 {
+		t.Errorf("pending bundles of block 11 mismatch: have %d, want 1", have)
+	}
+	if have := len(pool.Pending(12)); have != 1 {
+		t.Errorf("pending bundles of block 12 mismatch: have %d, want 1", have)
+	}
+}
+
+func TestBundlePoolExpire(t *testing.T) {
+	chain := &testBlockChain{head: &types.Header{Number: big.NewInt(10)}}
+	pool := New(DefaultConfig, chain)
+	defer pool.Close()
+
+	short, long := testBundle(t, 0, 11, 0), testBundle(t, 0, 20, 0)
+	for _, bundle := range []*types.Bundle{short, long} {
+		if err := pool.Add(bundle); err != nil {
+			t.Fatalf("failed to add bundle: %v", err)
+		}
+	}
+	chain.chainHeadFeed.Send(core.ChainHeadEvent{Header: &types.Header{Number: big.NewInt(11)}})
+
+	for i := 0; i < 100 && pool.Len() != 1; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if pool.Get(short.Hash()) != nil {
+		t.Errorf("expired bundle still in pool")
+	}
+	if pool.Get(long.Hash()) == nil {
+		t.Errorf("pending bundle dropped")
+	}
+	if err := pool.Add(short); !errors.Is(err, ErrBundleExpired) {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrBundleExpired)
+	}
+}
+
+func TestBundlePoolCommitted(t *testing.T) {
+	chain := &testBlockChain{head: &types.Header{Number: big.NewInt(10)}}
+	pool := New(DefaultConfig, chain)
+	defer pool.Close()
+
+	committed, pending := testBundle(t, 0, 20, 0, 1), testBundle(t, 0, 20, 0)
+	for _, bundle := range []*types.Bundle{committed, pending} {
+		if err := pool.Add(bundle); err != nil {
+			t.Fatalf("failed to add bundle: %v", err)
+		}
+	}
+	block := types.NewBlock(&types.Header{Number: big.NewInt(11)}, &types.Body{Transactions: committed.Txs[:1]}, nil, trie.NewStackTrie(nil))
+	chain.blocks = map[common.Hash]*types.Block{block.Hash(): block}
+	chain.chainHeadFeed.Send(core.ChainHeadEvent{Header: block.Header()})
+
+	for i := 0; i < 100 && pool.Len() != 1; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if pool.Get(committed.Hash()) != nil {
+		t.Errorf("committed bundle still in pool")
+	}
+	if pool.Get(pending.Hash()) == nil {
+		t.Errorf("pending bundle dropped")
+	}
+}
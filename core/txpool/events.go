@@ -0,0 +1,62 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxEventKind is the change in the lifecycle of a pooled transaction.
+type TxEventKind string
+
+const (
+	TxEventAdded      TxEventKind = "added"      // Accepted into the pool
+	TxEventPromoted   TxEventKind = "promoted"   // Moved from the queue to the executable set
+	TxEventDemoted    TxEventKind = "demoted"    // Moved from the executable set back to the queue
+	TxEventReplaced   TxEventKind = "replaced"   // Replaced by a transaction with the same nonce
+	TxEventDropped    TxEventKind = "dropped"    // Removed from the pool without inclusion
+	TxEventOverflowed TxEventKind = "overflowed" // Evicted from the pool into the overflow pool
+	TxEventIncluded   TxEventKind = "included"   // Removed from the pool as its nonce was used on chain
+)
+
+// TxEventReason details why a transaction was demoted, dropped or overflowed.
+type TxEventReason string
+
+const (
+	TxReasonUnderpriced       TxEventReason = "underpriced"       // Evicted by better paying transactions, or below the minimum tip
+	TxReasonLifetime          TxEventReason = "lifetime"          // Queued for longer than the pool lifetime
+	TxReasonInsufficientFunds TxEventReason = "insufficientFunds" // Costing more than the balance of the sender, or over the block gas limit
+	TxReasonNonceTooLow       TxEventReason = "nonceTooLow"       // Nonce used on chain by another transaction
+	TxReasonNonceGap          TxEventReason = "nonceGap"          // A transaction with a lower nonce is missing
+	TxReasonAccountLimit      TxEventReason = "accountLimit"      // Over the number of transactions allowed per account
+	TxReasonPoolLimit         TxEventReason = "poolLimit"         // Over the capacity of the pool
+	TxReasonDuplicate         TxEventReason = "duplicate"         // Another transaction with the same nonce was kept
+	TxReasonConditional       TxEventReason = "conditional"       // Conditions of inclusion no longer met
+	TxReasonPrivateExpired    TxEventReason = "privateExpired"    // Private transaction past its expiry
+)
+
+// TxEvent is a change in the lifecycle of a pooled transaction.
+type TxEvent struct {
+	Kind   TxEventKind
+	Reason TxEventReason // Set for the demoted, dropped and overflowed transactions
+	Hash   common.Hash
+	From   common.Address
+	Nonce  uint64
+
+	ReplacedBy common.Hash // Hash of the replacement, for the replaced transactions
+	Private    bool        // Whether the transaction is private, not to be disclosed
+}
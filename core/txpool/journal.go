@@ -0,0 +1,167 @@
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// errNoActiveJournal is returned if an entry is attempted to be inserted into
+// the journal, but no such file is currently open.
+var errNoActiveJournal = errors.New("no active journal")
+
+// journalLoadBatch is the number of entries loaded from a journal at once.
+const journalLoadBatch = 1024
+
+// devNull is a WriteCloser that just discards anything written into it. Its
+// goal is to allow the journal to write into a fake journal when loading the
+// entries on startup without printing warnings due to no file being read for
+// write.
+type devNull struct{}
+
+func (*devNull) Write(p []byte) (n int, err error) { return len(p), nil }
+func (*devNull) Close() error                      { return nil }
+
+// Journal is a rotating log of RLP entries, transactions or changes to a set of
+// them, with the aim of allowing the pooled contents to survive node restarts.
+// New entries are appended as they happen, and the whole log is regenerated
+// from the live contents on rotation.
+//
+// The journal is not safe for concurrent use.
+type Journal[T any] struct {
+	path   string         // Filesystem path to store the entries at
+	writer io.WriteCloser // Output stream to write new entries into
+}
+
+// NewJournal creates a new journal at the given path. No entries may be inserted
+// before it is loaded and rotated.
+func NewJournal[T any](path string) *Journal[T] {
+	return &Journal[T]{
+		path: path,
+	}
+}
+
+// Load parses a journal dump from disk, passing its entries in batches to the
+// given function. It returns the number of entries parsed, along with any error
+// which stopped the parsing.
+func (journal *Journal[T]) Load(apply func([]T)) (int, error) {
+	// Open the journal for loading any past entries
+	input, err := os.Open(journal.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Skip the parsing if the journal file doesn't exist at all
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer input.Close()
+
+	// Temporarily discard any journal additions (don't double add on load)
+	journal.writer = new(devNull)
+	defer func() { journal.writer = nil }()
+
+	// Apply all entries from the journal in small-ish batches
+	var (
+		stream  = rlp.NewStream(input, 0)
+		total   int
+		failure error
+		batch   []T
+	)
+	for {
+		// Parse the next entry and terminate on error
+		var entry T
+		if err = stream.Decode(&entry); err != nil {
+			if err != io.EOF {
+				failure = err
+			}
+			if len(batch) > 0 {
+				apply(batch)
+			}
+			break
+		}
+		// New entry parsed, queue up for later, apply if threshold is reached
+		total++
+
+		if batch = append(batch, entry); len(batch) > journalLoadBatch {
+			apply(batch)
+			batch = batch[:0]
+		}
+	}
+	return total, failure
+}
+
+// Insert appends the specified entry to the disk journal.
+func (journal *Journal[T]) Insert(entry T) error {
+	if journal.writer == nil {
+		return errNoActiveJournal
+	}
+	if err := rlp.Encode(journal.writer, entry); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Rotate regenerates the journal from the given entries, the current contents
+// of the pool.
+func (journal *Journal[T]) Rotate(entries []T) error {
+	// Close the current journal (if any is open)
+	if journal.writer != nil {
+		if err := journal.writer.Close(); err != nil {
+			return err
+		}
+		journal.writer = nil
+	}
+	// Generate a new journal with the contents of the current pool
+	replacement, err := os.OpenFile(journal.path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err = rlp.Encode(replacement, entry); err != nil {
+			replacement.Close()
+			return err
+		}
+	}
+	replacement.Close()
+
+	// Replace the live journal with the newly generated one
+	if err = os.Rename(journal.path+".new", journal.path); err != nil {
+		return err
+	}
+	sink, err := os.OpenFile(journal.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	journal.writer = sink
+	return nil
+}
+
+// Close flushes the journal contents to disk and closes the file.
+func (journal *Journal[T]) Close() error {
+	var err error
+
+	if journal.writer != nil {
+		err = journal.writer.Close()
+		journal.writer = nil
+	}
+	return err
+}
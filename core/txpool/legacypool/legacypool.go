@@ -111,6 +111,14 @@ var (
 	underpricedTxMeter = metrics.NewRegisteredMeter("txpool/underpriced", nil)
 	overflowedTxMeter  = metrics.NewRegisteredMeter("txpool/overflowed", nil)
 
+	// conditionalViolationMeter counts the conditional transactions dropped because
+	// their conditions of inclusion can't be met any more.
+	conditionalViolationMeter = metrics.NewRegisteredMeter("txpool/conditional/violation", nil)
+
+	// privateExpiredMeter counts private transactions past their expiry, either
+	// dropped or made public
+	privateExpiredMeter = metrics.NewRegisteredMeter("txpool/private/expired", nil)
+
 	// throttleTxMeter counts how many transactions are rejected due to too-many-changes between
 	// txpool reorgs.
 	throttleTxMeter = metrics.NewRegisteredMeter("txpool/throttle", nil)
@@ -160,6 +168,8 @@ type Config struct {
 	GlobalQueue       uint64 // Maximum number of non-executable transaction slots for all accounts
 	OverflowPoolSlots uint64 // Maximum number of transaction slots in overflow pool
 
+	OverflowPoolJournal string // Journal of overflow pool transactions to survive node restarts, disabled if empty
+
 	Lifetime       time.Duration // Maximum amount of time non-executable transaction are queued
 	ReannounceTime time.Duration // Duration for announcing local pending transactions again
 }
@@ -214,6 +224,10 @@ func (config *Config) sanitize() Config {
 		log.Warn("Sanitizing invalid txpool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
 		conf.Lifetime = DefaultConfig.Lifetime
 	}
+	if conf.OverflowPoolJournal != "" && conf.Rejournal < time.Second {
+		log.Warn("Sanitizing invalid txpool journal time", "provided", conf.Rejournal, "updated", time.Second)
+		conf.Rejournal = time.Second
+	}
 	if conf.ReannounceTime < time.Minute {
 		log.Warn("Sanitizing invalid txpool reannounce time", "provided", conf.ReannounceTime, "updated", time.Minute)
 		conf.ReannounceTime = time.Minute
@@ -250,6 +264,11 @@ type LegacyPool struct {
 	txFeed       event.Feed
 	reannoTxFeed event.Feed // Event feed for announcing transactions again
 	scope        event.SubscriptionScope
+
+	txEventFeed  event.Feed              // Event feed for the lifecycle of the transactions
+	txEventScope event.SubscriptionScope // Subscriptions to the lifecycle events, recorded only if any
+	txEvents     []*txpool.TxEvent       // Lifecycle events recorded, not sent yet
+	txEventsLock sync.Mutex              // Mutex protecting the recorded lifecycle events
 	signer       types.Signer
 	mu           sync.RWMutex
 	maxGas       atomic.Uint64 // Currently accepted max gas, it will be modified by MinerAPI
@@ -346,6 +365,24 @@ func (pool *LegacyPool) Init(gasTip uint64, head *types.Header, reserve txpool.A
 	pool.currentState = statedb
 	pool.pendingNonces = newNoncer(statedb)
 
+	// If an overflow pool journal is configured, reload the transactions which
+	// are still valid against the current state
+	if pool.config.OverflowPoolJournal != "" {
+		pool.mu.Lock()
+		err := pool.localBufferPool.loadJournal(pool.config.OverflowPoolJournal, func(tx *types.Transaction) error {
+			if pool.all.Get(tx.Hash()) != nil {
+				return txpool.ErrAlreadyKnown
+			}
+			if err := pool.validateTxBasics(tx); err != nil {
+				return err
+			}
+			return pool.validateTx(tx)
+		})
+		pool.mu.Unlock()
+		if err != nil {
+			log.Warn("Failed to load overflow pool journal", "err", err)
+		}
+	}
 	pool.wg.Add(1)
 	go pool.scheduleReorgLoop()
 
@@ -372,6 +409,14 @@ func (pool *LegacyPool) loop() {
 	defer evict.Stop()
 	defer reannounce.Stop()
 
+	// Regenerate the overflow pool journal periodically, if enabled
+	var rejournal <-chan time.Time
+	if pool.config.OverflowPoolJournal != "" {
+		journal := time.NewTicker(pool.config.Rejournal)
+		defer journal.Stop()
+		rejournal = journal.C
+	}
+
 	// Notify tests that the init phase is done
 	close(pool.initDoneCh)
 	for {
@@ -401,11 +446,13 @@ func (pool *LegacyPool) loop() {
 					list := pool.queue[addr].Flatten()
 					for _, tx := range list {
 						pool.removeTx(tx.Hash(), true, true)
+						pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonLifetime, tx)
 					}
 					queuedEvictionMeter.Mark(int64(len(list)))
 				}
 			}
 			pool.mu.Unlock()
+			pool.sendTxEvents()
 
 		case <-reannounce.C:
 			pool.mu.RLock()
@@ -429,6 +476,12 @@ func (pool *LegacyPool) loop() {
 			if len(reannoTxs) > 0 {
 				pool.reannoTxFeed.Send(core.ReannoTxsEvent{Txs: reannoTxs})
 			}
+
+		// Handle overflow pool journal rotation
+		case <-rejournal:
+			if err := pool.localBufferPool.rotateJournal(); err != nil {
+				log.Warn("Failed to rotate overflow pool journal", "err", err)
+			}
 		}
 	}
 }
@@ -439,6 +492,9 @@ func (pool *LegacyPool) Close() error {
 	close(pool.reorgShutdownCh)
 	pool.wg.Wait()
 
+	if err := pool.localBufferPool.closeJournal(); err != nil {
+		log.Warn("Failed to close overflow pool journal", "err", err)
+	}
 	log.Info("Transaction pool stopped")
 	return nil
 }
@@ -466,9 +522,71 @@ func (pool *LegacyPool) SubscribeReannoTxsEvent(ch chan<- core.ReannoTxsEvent) e
 	return pool.scope.Track(pool.reannoTxFeed.Subscribe(ch))
 }
 
+// SubscribeTxEvents registers a subscription for the lifecycle events of the
+// transactions.
+func (pool *LegacyPool) SubscribeTxEvents(ch chan<- []*txpool.TxEvent) event.Subscription {
+	return pool.txEventScope.Track(pool.txEventFeed.Subscribe(ch))
+}
+
+// recordTxEvent records a lifecycle event of the transaction, to be sent once
+// the pool lock is released. Nothing is recorded without subscribers.
+func (pool *LegacyPool) recordTxEvent(kind txpool.TxEventKind, reason txpool.TxEventReason, tx *types.Transaction) {
+	pool.recordTxEventBy(kind, reason, tx, nil)
+}
+
+// recordTxEventBy records a lifecycle event of the transaction, caused by the
+// given replacement if any. A private replacement is not disclosed.
+func (pool *LegacyPool) recordTxEventBy(kind txpool.TxEventKind, reason txpool.TxEventReason, tx *types.Transaction, by *types.Transaction) {
+	if pool.txEventScope.Count() == 0 {
+		return
+	}
+	var replacement common.Hash
+	if by != nil && by.Private() == nil {
+		replacement = by.Hash()
+	}
+	from, _ := types.Sender(pool.signer, tx) // already validated
+	ev := &txpool.TxEvent{
+		Kind:       kind,
+		Reason:     reason,
+		Hash:       tx.Hash(),
+		From:       from,
+		Nonce:      tx.Nonce(),
+		ReplacedBy: replacement,
+		Private:    tx.Private() != nil,
+	}
+	pool.txEventsLock.Lock()
+	pool.txEvents = append(pool.txEvents, ev)
+	pool.txEventsLock.Unlock()
+}
+
+// recordInclusion records the removal of a transaction whose nonce was used on
+// chain, either by itself or by another transaction.
+func (pool *LegacyPool) recordInclusion(tx *types.Transaction, inclusions map[common.Hash]struct{}) {
+	if _, ok := inclusions[tx.Hash()]; ok {
+		pool.recordTxEvent(txpool.TxEventIncluded, "", tx)
+	} else {
+		pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonNonceTooLow, tx)
+	}
+}
+
+// sendTxEvents sends the recorded lifecycle events to the subscribers. It must
+// not be called while holding the pool lock, a subscriber may be slow.
+func (pool *LegacyPool) sendTxEvents() {
+	pool.txEventsLock.Lock()
+	events := pool.txEvents
+	pool.txEvents = nil
+	pool.txEventsLock.Unlock()
+
+	if len(events) > 0 {
+		pool.txEventFeed.Send(events)
+	}
+}
+
 // SetGasTip updates the minimum gas tip required by the transaction pool for a
 // new transaction, and drops all transactions below this threshold.
 func (pool *LegacyPool) SetGasTip(tip *big.Int) {
+	defer pool.sendTxEvents()
+
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
@@ -483,6 +601,7 @@ func (pool *LegacyPool) SetGasTip(tip *big.Int) {
 		drop := pool.all.TxsBelowTip(tip)
 		for _, tx := range drop {
 			pool.removeTx(tx.Hash(), false, true)
+			pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonUnderpriced, tx)
 		}
 		pool.priced.Removed(len(drop))
 	}
@@ -616,6 +735,7 @@ func (pool *LegacyPool) Pending(filter txpool.PendingFilter) map[common.Address]
 					GasTipCap: uint256.MustFromBig(txs[i].GasTipCap()),
 					Gas:       txs[i].Gas(),
 					BlobGas:   txs[i].BlobGas(),
+					Opts:      txs[i].Options(),
 				}
 			}
 			pending[addr] = lazies
@@ -861,10 +981,12 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 			pool.all.Remove(old.Hash())
 			pool.priced.Removed(1)
 			pendingReplaceMeter.Mark(1)
+			pool.recordTxEventBy(txpool.TxEventReplaced, "", old, tx)
 		}
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
 		pool.queueTxEvent(tx)
+		pool.recordTxEvent(txpool.TxEventAdded, "", tx)
 		log.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
 		// Successful promotion, bump the heartbeat
@@ -876,6 +998,7 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 	if err != nil {
 		return false, err
 	}
+	pool.recordTxEvent(txpool.TxEventAdded, "", tx)
 
 	log.Trace("Pooled new future transaction", "hash", hash, "from", from, "to", tx.To())
 	return replaced, nil
@@ -887,8 +1010,10 @@ func (pool *LegacyPool) addToOverflowPool(drop types.Transactions) {
 		if added {
 			from, _ := types.Sender(pool.signer, tx)
 			log.Debug("Added to OverflowPool", "transaction", tx.Hash().String(), "from", from.String())
+			pool.recordTxEvent(txpool.TxEventOverflowed, txpool.TxReasonUnderpriced, tx)
 		} else {
 			log.Debug("Failed to add transaction to OverflowPool", "transaction", tx.Hash().String())
+			pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonUnderpriced, tx)
 		}
 	}
 }
@@ -937,6 +1062,7 @@ func (pool *LegacyPool) enqueueTx(hash common.Hash, tx *types.Transaction, addAl
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		queuedReplaceMeter.Mark(1)
+		pool.recordTxEventBy(txpool.TxEventReplaced, "", old, tx)
 	} else {
 		// Nothing was replaced, bump the queued counter
 		queuedGauge.Inc(1)
@@ -974,6 +1100,7 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(hash)
 		pool.priced.Removed(1)
 		pendingDiscardMeter.Mark(1)
+		pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonDuplicate, tx)
 		return false
 	}
 	// Otherwise discard any previous transaction and mark this
@@ -981,10 +1108,12 @@ func (pool *LegacyPool) promoteTx(addr common.Address, hash common.Hash, tx *typ
 		pool.all.Remove(old.Hash())
 		pool.priced.Removed(1)
 		pendingReplaceMeter.Mark(1)
+		pool.recordTxEventBy(txpool.TxEventReplaced, "", old, tx)
 	} else {
 		// Nothing was replaced, bump the pending counter
 		pendingGauge.Inc(1)
 	}
+	pool.recordTxEvent(txpool.TxEventPromoted, "", tx)
 	// Set the potentially new pending nonce and notify any subsystems of the new tx
 	pool.pendingNonces.set(addr, tx.Nonce()+1)
 
@@ -1055,6 +1184,7 @@ func (pool *LegacyPool) Add(txs []*types.Transaction, sync bool) []error {
 	pool.mu.Lock()
 	newErrs, dirtyAddrs := pool.addTxsLocked(news)
 	pool.mu.Unlock()
+	pool.sendTxEvents()
 
 	var nilSlot = 0
 	for _, err := range newErrs {
@@ -1181,6 +1311,7 @@ func (pool *LegacyPool) removeTx(hash common.Hash, outofbound bool, unreserve bo
 			for _, tx := range invalids {
 				// Internal shuffle shouldn't touch the lookup set.
 				pool.enqueueTx(tx.Hash(), tx, false)
+				pool.recordTxEvent(txpool.TxEventDemoted, txpool.TxReasonNonceGap, tx)
 			}
 			// Update the account nonce if needed
 			pool.pendingNonces.setIfLower(addr, tx.Nonce())
@@ -1318,10 +1449,17 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 		// the flatten operation can be avoided.
 		promoteAddrs = dirtyAccounts.flatten()
 	}
+	var (
+		published  []*types.Transaction
+		inclusions map[common.Hash]struct{}
+	)
 	pool.mu.Lock()
 	if reset != nil {
 		// Reset from the old head to the new, rescheduling any reorged transactions
-		pool.reset(reset.oldHead, reset.newHead)
+		inclusions = pool.reset(reset.oldHead, reset.newHead)
+
+		// Drop the conditional transactions which can't be included any more
+		pool.dropConditionalViolations()
 
 		// Nonces were reset, discard any events that became stale
 		for addr := range events {
@@ -1343,7 +1481,12 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	// remove any transaction that has been included in the block or was invalidated
 	// because of another transaction (e.g. higher gas price).
 	if reset != nil {
-		pool.demoteUnexecutables()
+		pool.demoteUnexecutables(inclusions)
+
+		// Drop or publish the private transactions which expired, and weren't
+		// included meanwhile
+		published = pool.expirePrivateTxs()
+
 		if reset.newHead != nil {
 			if pool.chainconfig.IsLondon(new(big.Int).Add(reset.newHead.Number, big.NewInt(1))) {
 				pendingBaseFee := eip1559.CalcBaseFee(pool.chainconfig, reset.newHead)
@@ -1367,10 +1510,16 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	dropBetweenReorgHistogram.Update(int64(pool.changesSinceReorg))
 	pool.changesSinceReorg = 0 // Reset change counter
 	pool.mu.Unlock()
+	pool.sendTxEvents()
 
 	// Transfer transactions from OverflowPool to MainPool for new block import
 	pool.transferTransactions()
 
+	// Announce the expired private transactions which fell back to public
+	if len(published) > 0 {
+		pool.reannoTxFeed.Send(core.ReannoTxsEvent{Txs: published})
+	}
+
 	// Notify subsystems for newly added transactions
 	for _, tx := range promoted {
 		addr, _ := types.Sender(pool.signer, tx)
@@ -1388,12 +1537,115 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	}
 }
 
+// dropConditionalViolations removes the conditional transactions which can no
+// longer be included on top of the current head, either because their block
+// number or timestamp limit is past, or because the accounts they expect to
+// find changed.
+func (pool *LegacyPool) dropConditionalViolations() {
+	head := pool.currentHead.Load()
+	if head == nil || pool.currentState == nil {
+		return
+	}
+	var drops []common.Hash
+	pool.all.Range(func(hash common.Hash, tx *types.Transaction) bool {
+		opts := tx.Options()
+		if opts == nil {
+			return true
+		}
+		if opts.Expired(head) {
+			log.Trace("Removing expired conditional transaction", "hash", hash)
+			drops = append(drops, hash)
+		} else if err := pool.currentState.ValidateKnownAccounts(opts.KnownAccounts); err != nil {
+			log.Trace("Removing conditional transaction", "hash", hash, "err", err)
+			drops = append(drops, hash)
+		}
+		return true
+	})
+	for _, hash := range drops {
+		if tx := pool.all.Get(hash); tx != nil {
+			pool.removeTx(hash, true, true)
+			pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonConditional, tx)
+		}
+	}
+	conditionalViolationMeter.Mark(int64(len(drops)))
+}
+
+// expirePrivateTxs handles the private transactions which can no longer be
+// included privately on top of the current head: the ones asking for it are
+// made public and returned for announcing, the others are removed.
+func (pool *LegacyPool) expirePrivateTxs() []*types.Transaction {
+	head := pool.currentHead.Load()
+	if head == nil {
+		return nil
+	}
+	var (
+		drops     []common.Hash
+		published []*types.Transaction
+	)
+	pool.all.Range(func(hash common.Hash, tx *types.Transaction) bool {
+		opts := tx.Private()
+		if opts == nil || !opts.Expired(head) {
+			return true
+		}
+		if opts.Fallback {
+			log.Trace("Publishing expired private transaction", "hash", hash)
+			published = append(published, tx)
+		} else {
+			log.Trace("Removing expired private transaction", "hash", hash)
+			drops = append(drops, hash)
+		}
+		return true
+	})
+	for _, hash := range drops {
+		if tx := pool.all.Get(hash); tx != nil {
+			pool.removeTx(hash, true, true)
+			pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonPrivateExpired, tx)
+		}
+	}
+	for _, tx := range published {
+		tx.SetPrivate(nil)
+	}
+	// The overflowed transactions are not announced, the ones falling back to
+	// public are announced once transferred into the pool.
+	var overflowed []*types.Transaction
+	pool.localBufferPool.Range(func(tx *types.Transaction) bool {
+		if opts := tx.Private(); opts != nil && opts.Expired(head) {
+			overflowed = append(overflowed, tx)
+		}
+		return true
+	})
+	for _, tx := range overflowed {
+		if tx.Private().Fallback {
+			log.Trace("Publishing expired overflowed private transaction", "hash", tx.Hash())
+			tx.SetPrivate(nil)
+			continue
+		}
+		log.Trace("Removing expired overflowed private transaction", "hash", tx.Hash())
+		pool.localBufferPool.Remove(tx.Hash())
+		pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonPrivateExpired, tx)
+	}
+	privateExpiredMeter.Mark(int64(len(drops) + len(published) + len(overflowed)))
+	return published
+}
+
 // reset retrieves the current state of the blockchain and ensures the content
-// of the transaction pool is valid with regard to the chain state.
-func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
+// of the transaction pool is valid with regard to the chain state. The hashes
+// of the transactions included by the new head are returned if there are any
+// lifecycle event subscribers to report the inclusions to.
+func (pool *LegacyPool) reset(oldHead, newHead *types.Header) map[common.Hash]struct{} {
 	// If we're reorging an old state, reinject all dropped transactions
-	var reinject types.Transactions
-
+	var (
+		reinject   types.Transactions
+		inclusions map[common.Hash]struct{}
+	)
+	if oldHead != nil && oldHead.Hash() == newHead.ParentHash && pool.txEventScope.Count() > 0 {
+		if block := pool.chain.GetBlock(newHead.Hash(), newHead.Number.Uint64()); block != nil {
+			inclusions = make(map[common.Hash]struct{}, len(block.Transactions()))
+			for _, tx := range block.Transactions() {
+				inclusions[tx.Hash()] = struct{}{}
+			}
+		}
+	}
 	if oldHead != nil && oldHead.Hash() != newHead.ParentHash {
 		// If the reorg is too deep, avoid doing it (will happen during fast sync)
 		oldNum := oldHead.Number.Uint64()
@@ -1416,7 +1668,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 					// If we reorged to a same or higher number, then it's not a case of setHead
 					log.Warn("Transaction pool reset with missing old head",
 						"old", oldHead.Hash(), "oldnum", oldNum, "new", newHead.Hash(), "newnum", newNum)
-					return
+					return nil
 				}
 				// If the reorg ended up on a lower number, it's indicative of setHead being the cause
 				log.Debug("Skipping transaction reset caused by setHead",
@@ -1429,33 +1681,33 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 					// reorg caused by sync-reversion or explicit sethead back to an
 					// earlier block.
 					log.Warn("Transaction pool reset with missing new head", "number", newHead.Number, "hash", newHead.Hash())
-					return
+					return nil
 				}
 				var discarded, included types.Transactions
 				for rem.NumberU64() > add.NumberU64() {
 					discarded = append(discarded, rem.Transactions()...)
 					if rem = pool.chain.GetBlock(rem.ParentHash(), rem.NumberU64()-1); rem == nil {
 						log.Error("Unrooted old chain seen by tx pool", "block", oldHead.Number, "hash", oldHead.Hash())
-						return
+						return nil
 					}
 				}
 				for add.NumberU64() > rem.NumberU64() {
 					included = append(included, add.Transactions()...)
 					if add = pool.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
 						log.Error("Unrooted new chain seen by tx pool", "block", newHead.Number, "hash", newHead.Hash())
-						return
+						return nil
 					}
 				}
 				for rem.Hash() != add.Hash() {
 					discarded = append(discarded, rem.Transactions()...)
 					if rem = pool.chain.GetBlock(rem.ParentHash(), rem.NumberU64()-1); rem == nil {
 						log.Error("Unrooted old chain seen by tx pool", "block", oldHead.Number, "hash", oldHead.Hash())
-						return
+						return nil
 					}
 					included = append(included, add.Transactions()...)
 					if add = pool.chain.GetBlock(add.ParentHash(), add.NumberU64()-1); add == nil {
 						log.Error("Unrooted new chain seen by tx pool", "block", newHead.Number, "hash", newHead.Hash())
-						return
+						return nil
 					}
 				}
 				lost := make([]*types.Transaction, 0, len(discarded))
@@ -1466,6 +1718,11 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 					}
 				}
 				reinject = lost
+
+				inclusions = make(map[common.Hash]struct{}, len(included))
+				for _, tx := range included {
+					inclusions[tx.Hash()] = struct{}{}
+				}
 			}
 		}
 	}
@@ -1476,7 +1733,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	statedb, err := pool.chain.StateAt(newHead.Root)
 	if err != nil {
 		log.Error("Failed to reset txpool state", "err", err)
-		return
+		return nil
 	}
 	pool.currentHead.Store(newHead)
 	pool.currentState = statedb
@@ -1486,6 +1743,7 @@ func (pool *LegacyPool) reset(oldHead, newHead *types.Header) {
 	log.Debug("Reinjecting stale transactions", "count", len(reinject))
 	core.SenderCacher().Recover(pool.signer, reinject)
 	pool.addTxsLocked(reinject)
+	return inclusions
 }
 
 // promoteExecutables moves transactions that have become processable from the
@@ -1506,12 +1764,14 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		forwards := list.Forward(pool.currentState.GetNonce(addr))
 		for _, tx := range forwards {
 			pool.all.Remove(tx.Hash())
+			pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonNonceTooLow, tx)
 		}
 		log.Trace("Removed old queued transactions", "count", len(forwards))
 		// Drop all transactions that are too costly (low balance or out of gas)
 		drops, _ := list.Filter(pool.currentState.GetBalance(addr), gasLimit)
 		for _, tx := range drops {
 			pool.all.Remove(tx.Hash())
+			pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonInsufficientFunds, tx)
 		}
 		log.Trace("Removed unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
@@ -1532,6 +1792,7 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		for _, tx := range caps {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonAccountLimit, tx)
 			log.Trace("Removed cap-exceeding queued transaction", "hash", hash)
 		}
 		queuedRateLimitMeter.Mark(int64(len(caps)))
@@ -1594,6 +1855,7 @@ func (pool *LegacyPool) truncatePending() {
 						// Drop the transaction from the global pools too
 						hash := tx.Hash()
 						pool.all.Remove(hash)
+						pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonPoolLimit, tx)
 
 						// Update the account nonce to the dropped transaction
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
@@ -1619,6 +1881,7 @@ func (pool *LegacyPool) truncatePending() {
 					// Drop the transaction from the global pools too
 					hash := tx.Hash()
 					pool.all.Remove(hash)
+					pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonPoolLimit, tx)
 
 					// Update the account nonce to the dropped transaction
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
@@ -1661,6 +1924,7 @@ func (pool *LegacyPool) truncateQueue() {
 		if size := uint64(list.Len()); size <= drop {
 			for _, tx := range list.Flatten() {
 				pool.removeTx(tx.Hash(), true, true)
+				pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonPoolLimit, tx)
 			}
 			drop -= size
 			queuedRateLimitMeter.Mark(int64(size))
@@ -1670,6 +1934,7 @@ func (pool *LegacyPool) truncateQueue() {
 		txs := list.Flatten()
 		for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
 			pool.removeTx(txs[i].Hash(), true, true)
+			pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonPoolLimit, txs[i])
 			drop--
 			queuedRateLimitMeter.Mark(1)
 		}
@@ -1683,7 +1948,7 @@ func (pool *LegacyPool) truncateQueue() {
 // Note: transactions are not marked as removed in the priced list because re-heaping
 // is always explicitly triggered by SetBaseFee and it would be unnecessary and wasteful
 // to trigger a re-heap is this function
-func (pool *LegacyPool) demoteUnexecutables() {
+func (pool *LegacyPool) demoteUnexecutables(inclusions map[common.Hash]struct{}) {
 	// Iterate over all accounts and demote any non-executable transactions
 	gasLimit := pool.currentHead.Load().GasLimit
 	for addr, list := range pool.pending {
@@ -1694,6 +1959,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 		for _, tx := range olds {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.recordInclusion(tx, inclusions)
 			log.Trace("Removed old pending transaction", "hash", hash)
 		}
 		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
@@ -1701,6 +1967,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 		for _, tx := range drops {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pool.recordTxEvent(txpool.TxEventDropped, txpool.TxReasonInsufficientFunds, tx)
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 		}
 		pendingNofundsMeter.Mark(int64(len(drops)))
@@ -1711,6 +1978,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 
 			// Internal shuffle shouldn't touch the lookup set.
 			pool.enqueueTx(hash, tx, false)
+			pool.recordTxEvent(txpool.TxEventDemoted, txpool.TxReasonNonceGap, tx)
 		}
 		pendingGauge.Dec(int64(len(olds) + len(drops) + len(invalids)))
 
@@ -1723,6 +1991,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 
 				// Internal shuffle shouldn't touch the lookup set.
 				pool.enqueueTx(hash, tx, false)
+				pool.recordTxEvent(txpool.TxEventDemoted, txpool.TxReasonNonceGap, tx)
 			}
 			pendingGauge.Dec(int64(len(gapped)))
 		}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"path/filepath"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -30,6 +31,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
@@ -362,6 +364,205 @@ func TestStateChangeDuringReset(t *testing.T) {
 	}
 }
 
+// Tests that the conditional transactions are dropped on reset once their
+// conditions of inclusion can't be met any more.
+func TestConditionalTransactionViolations(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	var (
+		expiredKey, _ = crypto.GenerateKey()
+		contract      = common.Address{0xc0}
+		slot          = common.Hash{0x1}
+		statedb       = pool.chain.(*testBlockChain).statedb
+	)
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(expiredKey.PublicKey), big.NewInt(1000000))
+	statedb.SetState(contract, slot, common.Hash{0x1})
+
+	known := transaction(0, 100000, key)
+	known.SetOptions(&types.TransactionOpts{
+		KnownAccounts: types.KnownAccounts{contract: {StorageSlots: map[common.Hash]common.Hash{slot: {0x1}}}},
+	})
+	maxNumber := hexutil.Uint64(0)
+	expired := transaction(0, 100000, expiredKey)
+	expired.SetOptions(&types.TransactionOpts{BlockNumberMax: &maxNumber})
+
+	if err := pool.addRemotesSync([]*types.Transaction{known, expired}); err[0] != nil || err[1] != nil {
+		t.Fatalf("failed to add conditional transactions: %v", err)
+	}
+	for _, lazies := range pool.Pending(txpool.PendingFilter{}) {
+		if lazies[0].Opts == nil {
+			t.Errorf("conditions of transaction %s not carried to the miner", lazies[0].Hash)
+		}
+	}
+	// No block after the head can include the expired transaction.
+	<-pool.requestReset(nil, nil)
+	if pool.Has(expired.Hash()) {
+		t.Error("expired conditional transaction not dropped")
+	}
+	if !pool.Has(known.Hash()) {
+		t.Error("valid conditional transaction dropped")
+	}
+	// The accounts changed, the conditions don't hold any more.
+	statedb.SetState(contract, slot, common.Hash{0x2})
+	<-pool.requestReset(nil, nil)
+	if pool.Has(known.Hash()) {
+		t.Error("violated conditional transaction not dropped")
+	}
+}
+
+// Tests that the expired private transactions are dropped on reset, or made
+// public and announced if their sender asked for it.
+func TestPrivateTransactionExpiry(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	events := make(chan core.ReannoTxsEvent, 1)
+	sub := pool.reannoTxFeed.Subscribe(events)
+	defer sub.Unsubscribe()
+
+	var (
+		droppedKey, _   = crypto.GenerateKey()
+		publishedKey, _ = crypto.GenerateKey()
+	)
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(droppedKey.PublicKey), big.NewInt(1000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(publishedKey.PublicKey), big.NewInt(1000000))
+
+	live := transaction(0, 100000, key)
+	live.SetPrivate(&types.PrivateOpts{MaxBlockNumber: 10})
+	dropped := transaction(0, 100000, droppedKey)
+	dropped.SetPrivate(&types.PrivateOpts{MaxBlockNumber: 0})
+	published := transaction(0, 100000, publishedKey)
+	published.SetPrivate(&types.PrivateOpts{MaxBlockNumber: 0, Fallback: true})
+	overflowed := transaction(1, 100000, droppedKey)
+	overflowed.SetPrivate(&types.PrivateOpts{MaxBlockNumber: 0})
+
+	if errs := pool.addRemotesSync([]*types.Transaction{live, dropped, published}); errs[0] != nil || errs[1] != nil || errs[2] != nil {
+		t.Fatalf("failed to add private transactions: %v", errs)
+	}
+	pool.localBufferPool.Add(overflowed)
+	// No block after the head can include the expired transactions privately.
+	<-pool.requestReset(nil, nil)
+	if pool.Has(dropped.Hash()) {
+		t.Error("expired private transaction not dropped")
+	}
+	if _, ok := pool.localBufferPool.Get(overflowed.Hash()); ok || pool.Has(overflowed.Hash()) {
+		t.Error("expired overflowed private transaction not dropped")
+	}
+	if tx := pool.Get(live.Hash()); tx == nil || tx.Private() == nil {
+		t.Error("live private transaction not kept private")
+	}
+	if tx := pool.Get(published.Hash()); tx == nil || tx.Private() != nil {
+		t.Error("expired private transaction with fallback not made public")
+	}
+	select {
+	case ev := <-events:
+		if len(ev.Txs) != 1 || ev.Txs[0].Hash() != published.Hash() {
+			t.Errorf("announced transactions mismatch: have %d, want the published one", len(ev.Txs))
+		}
+	case <-time.After(time.Second):
+		t.Error("published transaction not announced")
+	}
+}
+
+// Tests that the lifecycle events of the transactions are reported with their
+// reasons.
+func TestTransactionLifecycleEvents(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	events := make(chan []*txpool.TxEvent, 16)
+	sub := pool.SubscribeTxEvents(events)
+	defer sub.Unsubscribe()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000000))
+
+	expect := func(want ...txpool.TxEvent) {
+		t.Helper()
+
+		var have []txpool.TxEvent
+		for len(have) < len(want) {
+			select {
+			case batch := <-events:
+				for _, ev := range batch {
+					have = append(have, *ev)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("events missing: have %d, want %d", len(have), len(want))
+			}
+		}
+		// Events of the same batch may be recorded in any order
+		for _, w := range want {
+			if !slices.Contains(have, w) {
+				t.Errorf("event missing: %+v, have %+v", w, have)
+			}
+		}
+	}
+	var (
+		tx0      = pricedTransaction(0, 100000, big.NewInt(1), key)
+		tx0Again = pricedTransaction(0, 100000, big.NewInt(2), key)
+		tx2      = pricedTransaction(2, 100000, big.NewInt(2), key)
+	)
+	if err := pool.addRemoteSync(tx0); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	expect(
+		txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: tx0.Hash(), From: from, Nonce: 0},
+		txpool.TxEvent{Kind: txpool.TxEventPromoted, Hash: tx0.Hash(), From: from, Nonce: 0},
+	)
+	if err := pool.addRemoteSync(tx0Again); err != nil {
+		t.Fatalf("failed to replace transaction: %v", err)
+	}
+	expect(
+		txpool.TxEvent{Kind: txpool.TxEventReplaced, Hash: tx0.Hash(), From: from, Nonce: 0, ReplacedBy: tx0Again.Hash()},
+		txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: tx0Again.Hash(), From: from, Nonce: 0},
+	)
+	if err := pool.addRemoteSync(tx2); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	expect(txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: tx2.Hash(), From: from, Nonce: 2})
+
+	pool.SetGasTip(big.NewInt(3))
+	expect(
+		txpool.TxEvent{Kind: txpool.TxEventDropped, Reason: txpool.TxReasonUnderpriced, Hash: tx0Again.Hash(), From: from, Nonce: 0},
+		txpool.TxEvent{Kind: txpool.TxEventDropped, Reason: txpool.TxReasonUnderpriced, Hash: tx2.Hash(), From: from, Nonce: 2},
+	)
+	// A private replacement must not be disclosed
+	var (
+		tx1        = pricedTransaction(0, 100000, big.NewInt(4), key)
+		tx1Private = pricedTransaction(0, 100000, big.NewInt(5), key)
+	)
+	tx1Private.SetPrivate(&types.PrivateOpts{MaxBlockNumber: 10})
+
+	if err := pool.addRemoteSync(tx1); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	expect(
+		txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: tx1.Hash(), From: from, Nonce: 0},
+		txpool.TxEvent{Kind: txpool.TxEventPromoted, Hash: tx1.Hash(), From: from, Nonce: 0},
+	)
+	if err := pool.addRemoteSync(tx1Private); err != nil {
+		t.Fatalf("failed to replace transaction: %v", err)
+	}
+	expect(
+		txpool.TxEvent{Kind: txpool.TxEventReplaced, Hash: tx1.Hash(), From: from, Nonce: 0},
+		txpool.TxEvent{Kind: txpool.TxEventAdded, Hash: tx1Private.Hash(), From: from, Nonce: 0, Private: true},
+	)
+	// The nonce was used on chain by another transaction
+	testSetNonce(pool, from, 1)
+	<-pool.requestReset(nil, nil)
+	expect(txpool.TxEvent{Kind: txpool.TxEventDropped, Reason: txpool.TxReasonNonceTooLow, Hash: tx1Private.Hash(), From: from, Nonce: 0, Private: true})
+}
+
 func testAddBalance(pool *LegacyPool, addr common.Address, amount *big.Int) {
 	pool.mu.Lock()
 	pool.currentState.AddBalance(addr, uint256.MustFromBig(amount), tracing.BalanceChangeUnspecified)
@@ -1837,6 +2038,80 @@ func TestUnderpricingDynamicFee(t *testing.T) {
 	}
 }
 
+// Tests that the overflow pool is persisted into its journal, and that only the
+// transactions still valid against the current state are reloaded on restart.
+func TestOverflowPoolJournaling(t *testing.T) {
+	t.Parallel()
+
+	journal := filepath.Join(t.TempDir(), "overflow.rlp")
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.OverflowPoolSlots = 4
+	config.OverflowPoolJournal = journal
+
+	open := func() *LegacyPool {
+		pool := New(config, blockchain)
+		if err := pool.Init(config.PriceLimit, blockchain.CurrentBlock(), makeAddressReserver()); err != nil {
+			t.Fatalf("failed to init pool: %v", err)
+		}
+		<-pool.initDoneCh
+		return pool
+	}
+	pool := open()
+
+	keys := make([]*ecdsa.PrivateKey, 4)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(keys[i].PublicKey), big.NewInt(1000000000))
+	}
+	var (
+		kept    = pricedTransaction(0, 100000, big.NewInt(1), keys[0])
+		removed = pricedTransaction(0, 100000, big.NewInt(1), keys[1])
+		stale   = pricedTransaction(0, 100000, big.NewInt(1), keys[2])
+		private = pricedTransaction(0, 100000, big.NewInt(1), keys[3])
+	)
+	private.SetPrivate(&types.PrivateOpts{MaxBlockNumber: 100})
+	for _, tx := range []*types.Transaction{kept, removed, stale, private} {
+		if !pool.localBufferPool.Add(tx) {
+			t.Fatalf("failed to add transaction %x to the overflow pool", tx.Hash())
+		}
+	}
+	pool.localBufferPool.Remove(removed.Hash())
+	pool.Close()
+
+	// Use the nonce of one account on chain and restart the pool
+	statedb.SetNonce(crypto.PubkeyToAddress(keys[2].PublicKey), 1, tracing.NonceChangeUnspecified)
+
+	pool = open()
+	if _, ok := pool.localBufferPool.Get(kept.Hash()); !ok {
+		t.Errorf("valid transaction not reloaded from the journal")
+	}
+	if _, ok := pool.localBufferPool.Get(removed.Hash()); ok {
+		t.Errorf("removed transaction reloaded from the journal")
+	}
+	if _, ok := pool.localBufferPool.Get(stale.Hash()); ok {
+		t.Errorf("stale transaction reloaded from the journal")
+	}
+	if _, ok := pool.localBufferPool.Get(private.Hash()); ok {
+		t.Errorf("private transaction reloaded from the journal")
+	}
+	if n := pool.localBufferPool.Len(); n != 1 {
+		t.Errorf("overflow pool size mismatch: have %d, want %d", n, 1)
+	}
+	// Flush the pool and make sure the compacted journal tracks it
+	pool.localBufferPool.Flush(1)
+	pool.Close()
+
+	pool = open()
+	defer pool.Close()
+	if n := pool.localBufferPool.Len(); n != 0 {
+		t.Errorf("overflow pool size mismatch after flush: have %d, want %d", n, 0)
+	}
+}
+
 // Tests whether highest fee cap transaction is retained after a batch of high effective
 // tip transactions are added and vice versa
 func TestDualHeapEviction(t *testing.T) {
@@ -2627,7 +2902,7 @@ func benchmarkPendingDemotion(b *testing.B, size int) {
 	// Benchmark the speed of pool validation
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		pool.demoteUnexecutables()
+		pool.demoteUnexecutables(nil)
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -68,6 +69,8 @@ type TxOverflowPool struct {
 	mu        sync.RWMutex
 	maxSize   uint64 // Maximum slots
 	totalSize uint64 // Total number of slots currently
+
+	journal *txpool.Journal[*overflowJournalEntry] // Optional journal of the changes, nil if disabled
 }
 
 func NewTxOverflowPoolHeap(estimatedMaxSize uint64) *TxOverflowPool {
@@ -110,6 +113,7 @@ func (tp *TxOverflowPool) Add(tx *types.Transaction) bool {
 		}
 		delete(tp.index, oldestItem.tx.Hash())
 		tp.totalSize -= uint64(numSlots(oldestItem.tx))
+		tp.journalRemove(oldestItem.tx.Hash())
 		OverflowPoolGauge.Dec(1)
 	}
 
@@ -121,6 +125,7 @@ func (tp *TxOverflowPool) Add(tx *types.Transaction) bool {
 	heap.Push(&tp.txHeap, item)
 	tp.index[tx.Hash()] = item
 	tp.totalSize += txSlots
+	tp.journalAdd(tx)
 	OverflowPoolGauge.Inc(1)
 
 	return true
@@ -142,10 +147,22 @@ func (tp *TxOverflowPool) Remove(hash common.Hash) {
 		heap.Remove(&tp.txHeap, item.index)
 		delete(tp.index, hash)
 		tp.totalSize -= uint64(numSlots(item.tx))
+		tp.journalRemove(hash)
 		OverflowPoolGauge.Dec(1)
 	}
 }
 
+// Range calls f on each transaction in the pool until f returns false.
+func (tp *TxOverflowPool) Range(f func(tx *types.Transaction) bool) {
+	tp.mu.RLock()
+	defer tp.mu.RUnlock()
+	for _, item := range tp.txHeap {
+		if !f(item.tx) {
+			return
+		}
+	}
+}
+
 func (tp *TxOverflowPool) Flush(n int) []*types.Transaction {
 	tp.mu.Lock()
 	defer tp.mu.Unlock()
@@ -161,6 +178,7 @@ func (tp *TxOverflowPool) Flush(n int) []*types.Transaction {
 		txs[i] = item.tx
 		delete(tp.index, item.tx.Hash())
 		tp.totalSize -= uint64(numSlots(item.tx))
+		tp.journalRemove(item.tx.Hash())
 	}
 
 	OverflowPoolGauge.Dec(int64(n))
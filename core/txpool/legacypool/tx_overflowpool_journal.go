@@ -0,0 +1,176 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package legacypool
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// overflowJournalEntry is a change of the overflow pool contents: either a
+// transaction added to the pool, or the hash of a transaction removed from it.
+type overflowJournalEntry struct {
+	Tx      []byte      // Binary encoding of the added transaction, empty for removals
+	Removed common.Hash // Hash of the removed transaction, zero for additions
+}
+
+// journalAdd records the addition of a transaction into the journal, if any.
+// Private transactions are not journaled, the encoding doesn't carry the private
+// flag and they would be restored as public ones. The caller must hold tp.mu.
+func (tp *TxOverflowPool) journalAdd(tx *types.Transaction) {
+	if tp.journal == nil || tx.Private() != nil {
+		return
+	}
+	blob, err := tx.MarshalBinary()
+	if err == nil {
+		err = tp.journal.Insert(&overflowJournalEntry{Tx: blob})
+	}
+	if err != nil {
+		log.Warn("Failed to journal overflowed transaction", "hash", tx.Hash(), "err", err)
+	}
+}
+
+// journalRemove records the removal of a transaction into the journal, if any.
+// The caller must hold tp.mu.
+func (tp *TxOverflowPool) journalRemove(hash common.Hash) {
+	if tp.journal == nil {
+		return
+	}
+	if err := tp.journal.Insert(&overflowJournalEntry{Removed: hash}); err != nil {
+		log.Warn("Failed to journal overflow pool removal", "hash", hash, "err", err)
+	}
+}
+
+// loadJournal replays the journal at the given path, re-adding the transactions
+// still alive at shutdown which pass the validation against the current state.
+// The journal is compacted afterwards and records all the subsequent changes,
+// even if it was only partially loaded.
+func (tp *TxOverflowPool) loadJournal(path string, validate func(tx *types.Transaction) error) error {
+	type journaledTx struct {
+		tx  *types.Transaction
+		seq int
+	}
+	var (
+		journal = txpool.NewJournal[*overflowJournalEntry](path)
+		live    = make(map[common.Hash]*journaledTx)
+		seq     int
+	)
+	total, err := journal.Load(func(entries []*overflowJournalEntry) {
+		for _, entry := range entries {
+			seq++
+			if len(entry.Tx) == 0 {
+				delete(live, entry.Removed)
+				continue
+			}
+			tx := new(types.Transaction)
+			if err := tx.UnmarshalBinary(entry.Tx); err != nil {
+				log.Debug("Failed to decode journaled overflow transaction", "err", err)
+				continue
+			}
+			live[tx.Hash()] = &journaledTx{tx: tx, seq: seq}
+		}
+	})
+	// Re-add the surviving transactions in their original order, so that the
+	// oldest ones are still the first to be evicted or flushed
+	txs := make([]*journaledTx, 0, len(live))
+	for _, jtx := range live {
+		txs = append(txs, jtx)
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].seq < txs[j].seq })
+
+	var dropped int
+	for _, jtx := range txs {
+		if err := validate(jtx.tx); err != nil {
+			log.Debug("Dropping journaled overflow transaction", "hash", jtx.tx.Hash(), "err", err)
+			dropped++
+			continue
+		}
+		if !tp.Add(jtx.tx) {
+			dropped++
+		}
+	}
+	log.Info("Loaded overflow pool journal", "entries", total, "transactions", len(txs), "dropped", dropped)
+
+	// Compact the journal to the current contents and start recording
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	tp.journal = journal
+	if rerr := tp.rotateJournalLocked(); rerr != nil {
+		return fmt.Errorf("failed to regenerate journal: %w", rerr)
+	}
+	return err
+}
+
+// rotateJournal regenerates the journal from the current contents of the pool,
+// dropping the entries of all the removed transactions.
+func (tp *TxOverflowPool) rotateJournal() error {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	if tp.journal == nil {
+		return nil
+	}
+	return tp.rotateJournalLocked()
+}
+
+// rotateJournalLocked is the internal version of rotateJournal which assumes
+// tp.mu is held.
+func (tp *TxOverflowPool) rotateJournalLocked() error {
+	items := make([]*txHeapItem, len(tp.txHeap))
+	copy(items, tp.txHeap)
+	sort.Slice(items, func(i, j int) bool { return items[i].timestamp < items[j].timestamp })
+
+	entries := make([]*overflowJournalEntry, 0, len(items))
+	for _, item := range items {
+		if item.tx.Private() != nil {
+			continue
+		}
+		blob, err := item.tx.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		entries = append(entries, &overflowJournalEntry{Tx: blob})
+	}
+	if err := tp.journal.Rotate(entries); err != nil {
+		return err
+	}
+	logger := log.Info
+	if len(entries) == 0 {
+		logger = log.Debug
+	}
+	logger("Regenerated overflow pool journal", "transactions", len(entries))
+	return nil
+}
+
+// closeJournal flushes the journal contents to disk and closes the file.
+func (tp *TxOverflowPool) closeJournal() error {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+
+	if tp.journal == nil {
+		return nil
+	}
+	err := tp.journal.Close()
+	tp.journal = nil
+	return err
+}
@@ -42,6 +42,8 @@ type LazyTransaction struct {
 
 	Gas     uint64 // Amount of gas required by the transaction
 	BlobGas uint64 // Amount of blob gas required by the transaction
+
+	Opts *types.TransactionOpts // Conditions of inclusion of a conditional transaction, nil otherwise
 }
 
 // Resolve retrieves the full transaction belonging to a lazy handle if it is still
@@ -150,6 +152,10 @@ type SubPool interface {
 	// ReannoTxsEvent and send events to the given channel.
 	SubscribeReannoTxsEvent(chan<- core.ReannoTxsEvent) event.Subscription
 
+	// SubscribeTxEvents subscribes to the lifecycle events of the pooled
+	// transactions, sent in batches.
+	SubscribeTxEvents(ch chan<- []*TxEvent) event.Subscription
+
 	// Nonce returns the next nonce of an account, with all transactions executable
 	// by the pool already applied on top.
 	Nonce(addr common.Address) uint64
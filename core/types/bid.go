@@ -198,6 +198,18 @@ type BidIssue struct {
 	Message   string
 }
 
+// BuilderStats represents the reputation of a builder, derived from its bids.
+type BuilderStats struct {
+	Builder          common.Address `json:"builder"`
+	Score            int64          `json:"score"`
+	Simulated        uint64         `json:"simulated"`        // Bids simulated successfully
+	Failed           uint64         `json:"failed"`           // Bids failing the simulation
+	Late             uint64         `json:"late"`             // Bids arriving after the deadline of their block
+	Overstated       uint64         `json:"overstated"`       // Bids paying less than their announced gas fee
+	Quarantines      uint64         `json:"quarantines"`      // Times the builder was quarantined
+	QuarantinedUntil uint64         `json:"quarantinedUntil"` // Last block of the latest quarantine
+}
+
 type MevParams struct {
 	ValidatorCommission   uint64 // 100 means 1%
 	BidSimulationLeftOver time.Duration
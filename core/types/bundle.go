@@ -0,0 +1,82 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SendBundleArgs represents the arguments to submit a bundle.
+type SendBundleArgs struct {
+	Txs               []hexutil.Bytes `json:"txs"`
+	MinBlockNumber    hexutil.Uint64  `json:"minBlockNumber"`
+	MaxBlockNumber    hexutil.Uint64  `json:"maxBlockNumber"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes"`
+}
+
+// ToBundle decodes the transactions of the bundle.
+func (args *SendBundleArgs) ToBundle() (*Bundle, error) {
+	if len(args.Txs) == 0 {
+		return nil, errors.New("bundle missing txs")
+	}
+	if args.MaxBlockNumber == 0 {
+		return nil, errors.New("bundle missing maxBlockNumber")
+	}
+	if args.MinBlockNumber > args.MaxBlockNumber {
+		return nil, fmt.Errorf("invalid block range %d-%d", args.MinBlockNumber, args.MaxBlockNumber)
+	}
+	txs := make(Transactions, len(args.Txs))
+	for i, enc := range args.Txs {
+		tx := new(Transaction)
+		if err := tx.UnmarshalBinary(enc); err != nil {
+			return nil, fmt.Errorf("invalid transaction %d: %v", i, err)
+		}
+		txs[i] = tx
+	}
+	return &Bundle{
+		Txs:               txs,
+		MinBlockNumber:    uint64(args.MinBlockNumber),
+		MaxBlockNumber:    uint64(args.MaxBlockNumber),
+		RevertingTxHashes: args.RevertingTxHashes,
+	}, nil
+}
+
+// Bundle is an ordered list of transactions included together in a block, or
+// not at all.
+type Bundle struct {
+	Txs               Transactions
+	MinBlockNumber    uint64        // First block which may include the bundle, zero if any
+	MaxBlockNumber    uint64        // Last block which may include the bundle
+	RevertingTxHashes []common.Hash // Transactions allowed to revert without failing the bundle
+
+	hash atomic.Pointer[common.Hash]
+}
+
+// Hash returns the hash of the bundle, derived from the hashes of its transactions.
+func (b *Bundle) Hash() common.Hash {
+	if hash := b.hash.Load(); hash != nil {
+		return *hash
+	}
+	hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+	for _, tx := range b.Txs {
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+	hash := crypto.Keccak256Hash(hashes)
+	b.hash.Store(&hash)
+	return hash
+}
+
+// Targets reports whether the block of the given number may include the bundle.
+func (b *Bundle) Targets(number uint64) bool {
+	return number >= b.MinBlockNumber && number <= b.MaxBlockNumber
+}
+
+// MayRevert reports whether the transaction may revert without failing the bundle.
+func (b *Bundle) MayRevert(hash common.Hash) bool {
+	return slices.Contains(b.RevertingTxHashes, hash)
+}
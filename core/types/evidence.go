@@ -0,0 +1,39 @@
+package types
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DoubleSignEvidence is a pair of conflicting headers sealed by the same validator
+// on top of the same parent. The encoded headers can be passed as is to the
+// double sign evidence submission of the slash indicator contract.
+type DoubleSignEvidence struct {
+	Validator  common.Address `json:"validator"`
+	Number     uint64         `json:"number"`
+	Header1    hexutil.Bytes  `json:"header1"`    // RLP encoded first header
+	Header2    hexutil.Bytes  `json:"header2"`    // RLP encoded second header
+	DetectedAt uint64         `json:"detectedAt"` // Unix timestamp of the detection
+}
+
+// MaliciousVoteEvidence is a pair of votes from the same validator that violates
+// the fast finality voting rules, together with the transaction that submitted it
+// to the slash indicator contract.
+type MaliciousVoteEvidence struct {
+	VoteA       *VoteEnvelope
+	VoteB       *VoteEnvelope
+	TxHash      common.Hash // Last submission transaction, zero until the evidence is submitted
+	SubmittedAt uint64      `rlp:"optional"` // Unix timestamp of the last submission
+	Included    bool        `rlp:"optional"` // Whether the submission was included in the chain
+}
+
+// Hash returns the identifier of the vote pair, regardless of the order of the votes.
+func (e *MaliciousVoteEvidence) Hash() common.Hash {
+	hashA, hashB := e.VoteA.Hash(), e.VoteB.Hash()
+	if bytes.Compare(hashA[:], hashB[:]) > 0 {
+		hashA, hashB = hashB, hashA
+	}
+	return rlpHash([]common.Hash{hashA, hashB})
+}
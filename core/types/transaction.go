@@ -53,8 +53,10 @@ const (
 
 // Transaction is an Ethereum transaction.
 type Transaction struct {
-	inner TxData    // Consensus contents of a transaction
-	time  time.Time // Time first seen locally (spam avoidance)
+	inner   TxData                      // Consensus contents of a transaction
+	time    time.Time                   // Time first seen locally (spam avoidance)
+	options *TransactionOpts            // Conditions of inclusion of a conditional transaction
+	private atomic.Pointer[PrivateOpts] // Options of a private transaction, kept out of the gossip
 
 	// caches
 	hash atomic.Pointer[common.Hash]
@@ -563,6 +565,30 @@ func (tx *Transaction) Time() time.Time {
 	return tx.time
 }
 
+// SetOptions sets the conditions of inclusion of a conditional transaction. It
+// is local to the node and not part of the transaction encoding.
+func (tx *Transaction) SetOptions(opts *TransactionOpts) {
+	tx.options = opts
+}
+
+// Options returns the conditions of inclusion of the transaction, or nil if it
+// is not conditional.
+func (tx *Transaction) Options() *TransactionOpts {
+	return tx.options
+}
+
+// SetPrivate marks the transaction as private, to be kept out of the p2p gossip
+// until its expiry. A nil opts makes the transaction public. Like the options,
+// it is local to the node and not part of the transaction encoding.
+func (tx *Transaction) SetPrivate(opts *PrivateOpts) {
+	tx.private.Store(opts)
+}
+
+// Private returns the options of the transaction if private, or nil if public.
+func (tx *Transaction) Private() *PrivateOpts {
+	return tx.private.Load()
+}
+
 // Hash returns the transaction hash.
 func (tx *Transaction) Hash() common.Hash {
 	if hash := tx.hash.Load(); hash != nil {
@@ -2,11 +2,21 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
+var (
+	ErrBlockNumberMin   = errors.New("BlockNumberMin condition not met")
+	ErrBlockNumberMax   = errors.New("BlockNumberMax condition not met")
+	ErrTimestampMin     = errors.New("TimestampMin condition not met")
+	ErrTimestampMax     = errors.New("TimestampMax condition not met")
+	ErrKnownStorageRoot = errors.New("storage root hash condition not met")
+	ErrKnownStorageSlot = errors.New("storage slot value condition not met")
+)
+
 type AccountStorage struct {
 	StorageRoot  *common.Hash
 	StorageSlots map[common.Hash]common.Hash
@@ -41,3 +51,43 @@ type TransactionOpts struct {
 	TimestampMin   *hexutil.Uint64 `json:"timestampMin,omitempty"`
 	TimestampMax   *hexutil.Uint64 `json:"timestampMax,omitempty"`
 }
+
+// CheckBlock checks the block number and timestamp conditions against a block
+// including the transaction.
+func (o *TransactionOpts) CheckBlock(number uint64, timestamp uint64) error {
+	if o.BlockNumberMin != nil && number < uint64(*o.BlockNumberMin) {
+		return ErrBlockNumberMin
+	}
+	if o.BlockNumberMax != nil && number > uint64(*o.BlockNumberMax) {
+		return ErrBlockNumberMax
+	}
+	if o.TimestampMin != nil && timestamp < uint64(*o.TimestampMin) {
+		return ErrTimestampMin
+	}
+	if o.TimestampMax != nil && timestamp > uint64(*o.TimestampMax) {
+		return ErrTimestampMax
+	}
+	return nil
+}
+
+// Expired reports whether no block after the given head can include the
+// transaction any more.
+func (o *TransactionOpts) Expired(head *Header) bool {
+	if o.BlockNumberMax != nil && uint64(*o.BlockNumberMax) <= head.Number.Uint64() {
+		return true
+	}
+	return o.TimestampMax != nil && uint64(*o.TimestampMax) < head.Time
+}
+
+// PrivateOpts are the options of a private transaction, which is not announced
+// to the peers but included by the local miner or forwarded to trusted nodes.
+type PrivateOpts struct {
+	MaxBlockNumber uint64 // Last block which may include the transaction privately
+	Fallback       bool   // Whether to broadcast the transaction once expired, rather than dropping it
+}
+
+// Expired reports whether no block after the given head can include the
+// transaction privately any more.
+func (o *PrivateOpts) Expired(head *Header) bool {
+	return o.MaxBlockNumber <= head.Number.Uint64()
+}
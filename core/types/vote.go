@@ -51,6 +51,14 @@ type VoteAttestation struct {
 	Extra          []byte           // Reserved for future usage.
 }
 
+// VoteWatermark is the highest source and target number a BLS key is known to
+// have voted for. It is the slashing protection history imported from another
+// host, no vote below it may be signed again.
+type VoteWatermark struct {
+	SourceNumber uint64
+	TargetNumber uint64
+}
+
 // Hash returns the vote's hash.
 func (v *VoteEnvelope) Hash() common.Hash {
 	if hash := v.hash.Load(); hash != nil {
@@ -0,0 +1,193 @@
+package vote
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	cmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxCoverageRange is the maximum number of blocks of a coverage request, the
+// vote pool doesn't keep the votes of older blocks anyway.
+const maxCoverageRange = lowerLimitOfVoteBlockNumber
+
+// voteAddressReader is implemented by the engines able to tell the validators
+// allowed to vote for a block.
+type voteAddressReader interface {
+	VoteAddresses(chain consensus.ChainHeaderReader, header *types.Header) (map[common.Address]types.BLSPublicKey, error)
+}
+
+// API exposes the content of the vote pool over RPC.
+type API struct {
+	pool *VotePool
+}
+
+// NewAPI creates the RPC service of the vote pool.
+func NewAPI(pool *VotePool) *API {
+	return &API{pool: pool}
+}
+
+// PoolStatus is the number of votes in the pool.
+type PoolStatus struct {
+	CurVotes     int `json:"curVotes"`     // Votes for the known blocks
+	CurBlocks    int `json:"curBlocks"`    // Known blocks with votes
+	FutureVotes  int `json:"futureVotes"`  // Votes for blocks not imported yet
+	FutureBlocks int `json:"futureBlocks"` // Blocks not imported yet with votes
+	Received     int `json:"received"`     // Votes received, used to drop duplicates
+}
+
+// VoteArrival is a vote received for a block.
+type VoteArrival struct {
+	Validator   *common.Address    `json:"validator,omitempty"` // Unknown if the voter is not a validator of the block
+	VoteAddress types.BLSPublicKey `json:"voteAddress"`
+	ReceivedAt  int64              `json:"receivedAt"` // Time in milliseconds the vote was received
+	Delay       int64              `json:"delay"`      // Milliseconds from the block time to the reception
+}
+
+// MissingVote is a validator of a block whose vote was not received.
+type MissingVote struct {
+	Validator   common.Address     `json:"validator"`
+	VoteAddress types.BLSPublicKey `json:"voteAddress"`
+}
+
+// BlockCoverage reports the votes received for a block.
+type BlockCoverage struct {
+	Number     hexutil.Uint64 `json:"number"`
+	Hash       common.Hash    `json:"hash"`
+	BlockTime  uint64         `json:"blockTime"`  // Time of the block in milliseconds
+	Validators int            `json:"validators"` // Number of validators allowed to vote, zero if unknown
+	Quorum     bool           `json:"quorum"`     // Whether enough votes arrived to attest the block
+	Votes      []*VoteArrival `json:"votes"`
+	Missing    []*MissingVote `json:"missing"`
+}
+
+// GetPoolStatus returns the number of votes in the pool.
+func (api *API) GetPoolStatus() *PoolStatus {
+	pool := api.pool
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	status := &PoolStatus{
+		CurBlocks:    len(pool.curVotes),
+		FutureBlocks: len(pool.futureVotes),
+		Received:     pool.receivedVotes.Cardinality(),
+	}
+	for _, box := range pool.curVotes {
+		status.CurVotes += len(box.voteMessages)
+	}
+	for _, box := range pool.futureVotes {
+		status.FutureVotes += len(box.voteMessages)
+	}
+	return status
+}
+
+// GetVotesForBlock returns the votes in the pool targeting the block.
+func (api *API) GetVotesForBlock(hash common.Hash) []*types.VoteEnvelope {
+	pool := api.pool
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	votes := make([]*types.VoteEnvelope, 0)
+	if box, ok := pool.curVotes[hash]; ok {
+		votes = append(votes, box.voteMessages...)
+	}
+	if box, ok := pool.futureVotes[hash]; ok {
+		votes = append(votes, box.voteMessages...)
+	}
+	return votes
+}
+
+// GetCoverage reports, for each canonical block of the range, which validators'
+// votes arrived and how late they were relative to the block time.
+func (api *API) GetCoverage(from, to rpc.BlockNumber) ([]*BlockCoverage, error) {
+	chain := api.pool.chain
+	resolve := func(number rpc.BlockNumber) uint64 {
+		if number < 0 {
+			return chain.CurrentBlock().Number.Uint64()
+		}
+		return uint64(number)
+	}
+	start, end := resolve(from), resolve(to)
+	if start > end {
+		return nil, fmt.Errorf("invalid range %d-%d", start, end)
+	}
+	if end-start >= maxCoverageRange {
+		return nil, fmt.Errorf("range too large, at most %d blocks", maxCoverageRange)
+	}
+	if start == 0 {
+		return nil, errors.New("genesis block is not voted")
+	}
+	coverage := make([]*BlockCoverage, 0, end-start+1)
+	for number := start; number <= end; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			break
+		}
+		blockCoverage, err := api.blockCoverage(header)
+		if err != nil {
+			return nil, err
+		}
+		coverage = append(coverage, blockCoverage)
+	}
+	return coverage, nil
+}
+
+func (api *API) blockCoverage(header *types.Header) (*BlockCoverage, error) {
+	var (
+		pool      = api.pool
+		blockTime = header.MilliTimestamp()
+		coverage  = &BlockCoverage{
+			Number:    hexutil.Uint64(header.Number.Uint64()),
+			Hash:      header.Hash(),
+			BlockTime: blockTime,
+			Votes:     make([]*VoteArrival, 0),
+			Missing:   make([]*MissingVote, 0),
+		}
+		validators map[common.Address]types.BLSPublicKey
+	)
+	if reader, ok := pool.engine.(voteAddressReader); ok {
+		var err error
+		if validators, err = reader.VoteAddresses(pool.chain, header); err != nil {
+			return nil, err
+		}
+	}
+	voters := make(map[types.BLSPublicKey]common.Address, len(validators))
+	for val, voteAddr := range validators {
+		voters[voteAddr] = val
+	}
+	coverage.Validators = len(validators)
+
+	pool.mu.RLock()
+	if box, ok := pool.curVotes[header.Hash()]; ok {
+		for i, vote := range box.voteMessages {
+			arrival := &VoteArrival{
+				VoteAddress: vote.VoteAddress,
+				ReceivedAt:  box.recvTimes[i],
+				Delay:       box.recvTimes[i] - int64(blockTime),
+			}
+			if val, ok := voters[vote.VoteAddress]; ok {
+				arrival.Validator = &val
+				delete(voters, vote.VoteAddress)
+			}
+			coverage.Votes = append(coverage.Votes, arrival)
+		}
+	}
+	pool.mu.RUnlock()
+
+	for voteAddr, val := range voters {
+		coverage.Missing = append(coverage.Missing, &MissingVote{Validator: val, VoteAddress: voteAddr})
+	}
+	sort.Slice(coverage.Missing, func(i, j int) bool {
+		return coverage.Missing[i].Validator.Cmp(coverage.Missing[j].Validator) < 0
+	})
+	if len(validators) > 0 {
+		coverage.Quorum = len(validators)-len(coverage.Missing) >= cmath.CeilDiv(len(validators)*2, 3)
+	}
+	return coverage, nil
+}
@@ -0,0 +1,121 @@
+package vote
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// mockVotersPOSA is a mock engine knowing the validators allowed to vote.
+type mockVotersPOSA struct {
+	mockPOSA
+	validators map[common.Address]types.BLSPublicKey
+}
+
+func (mp *mockVotersPOSA) VoteAddresses(chain consensus.ChainHeaderReader, header *types.Header) (map[common.Address]types.BLSPublicKey, error) {
+	return mp.validators, nil
+}
+
+func newTestVote(key bls.SecretKey, number uint64, hash common.Hash) *types.VoteEnvelope {
+	vote := &types.VoteEnvelope{Data: &types.VoteData{TargetNumber: number, TargetHash: hash}}
+	copy(vote.VoteAddress[:], key.PublicKey().Marshal())
+	copy(vote.Signature[:], key.Sign(vote.Data.Hash().Bytes()).Marshal())
+	return vote
+}
+
+func TestVotePoolAPI(t *testing.T) {
+	var (
+		keys   = make([]bls.SecretKey, 3)
+		engine = &mockVotersPOSA{validators: make(map[common.Address]types.BLSPublicKey)}
+	)
+	for i := range keys {
+		key, err := bls.RandKey()
+		if err != nil {
+			t.Fatalf("failed to create bls key: %v", err)
+		}
+		keys[i] = key
+		var voteAddr types.BLSPublicKey
+		copy(voteAddr[:], key.PublicKey().Marshal())
+		engine.validators[common.Address{byte(i + 1)}] = voteAddr
+	}
+	genesis := &core.Genesis{Config: params.TestChainConfig}
+	db := rawdb.NewMemoryDatabase()
+	chain, _ := core.NewBlockChain(db, nil, genesis, nil, ethash.NewFullFaker(), vm.Config{}, nil, nil)
+	defer chain.Stop()
+
+	blocks, _ := core.GenerateChain(params.TestChainConfig, chain.Genesis(), ethash.NewFaker(), db, 3, nil)
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	pool := NewVotePool(chain, engine)
+	api := NewAPI(pool)
+
+	// Two validators vote for the second block, one for a block not known yet.
+	target := blocks[1]
+	for _, key := range keys[:2] {
+		if !pool.putIntoVotePool(newTestVote(key, target.NumberU64(), target.Hash())) {
+			t.Fatal("vote not accepted")
+		}
+	}
+	if !pool.putIntoVotePool(newTestVote(keys[2], 4, common.Hash{0x4})) {
+		t.Fatal("future vote not accepted")
+	}
+
+	status := api.GetPoolStatus()
+	if status.CurVotes != 2 || status.CurBlocks != 1 || status.FutureVotes != 1 || status.FutureBlocks != 1 || status.Received != 3 {
+		t.Errorf("unexpected pool status %+v", status)
+	}
+	if votes := api.GetVotesForBlock(target.Hash()); len(votes) != 2 {
+		t.Errorf("%d votes for the block, want 2", len(votes))
+	}
+	if votes := api.GetVotesForBlock(common.Hash{0x4}); len(votes) != 1 {
+		t.Errorf("%d votes for the future block, want 1", len(votes))
+	}
+
+	coverage, err := api.GetCoverage(1, rpc.LatestBlockNumber)
+	if err != nil {
+		t.Fatalf("failed to get coverage: %v", err)
+	}
+	if len(coverage) != 3 {
+		t.Fatalf("coverage of %d blocks, want 3", len(coverage))
+	}
+	voted := coverage[1]
+	if voted.Hash != target.Hash() || len(voted.Votes) != 2 || len(voted.Missing) != 1 || !voted.Quorum {
+		t.Errorf("unexpected coverage of the voted block %+v", voted)
+	}
+	if voted.Missing[0].Validator != (common.Address{0x3}) {
+		t.Errorf("missing validator %s, want %s", voted.Missing[0].Validator, common.Address{0x3})
+	}
+	for _, arrival := range voted.Votes {
+		if arrival.Validator == nil {
+			t.Error("vote not attributed to a validator")
+		}
+		if arrival.Delay != arrival.ReceivedAt-int64(target.Header().MilliTimestamp()) {
+			t.Errorf("delay %d not relative to the block time", arrival.Delay)
+		}
+	}
+	if unvoted := coverage[0]; len(unvoted.Votes) != 0 || len(unvoted.Missing) != 3 || unvoted.Quorum {
+		t.Errorf("unexpected coverage of the block without votes %+v", unvoted)
+	}
+
+	// Invalid ranges are rejected.
+	if _, err := api.GetCoverage(3, 1); err == nil {
+		t.Error("reversed range accepted")
+	}
+	if _, err := api.GetCoverage(0, 1); err == nil {
+		t.Error("genesis block accepted")
+	}
+	if _, err := api.GetCoverage(1, maxCoverageRange+1); err == nil {
+		t.Error("too large range accepted")
+	}
+}
@@ -0,0 +1,295 @@
+package vote
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// The endpoints of the remote signing service, modeled on the Web3Signer eth2 API.
+const (
+	remoteSignerPublicKeysPath = "/api/v1/eth2/publicKeys"
+	remoteSignerSignPath       = "/api/v1/eth2/sign/"
+
+	// remoteSignerVoteType is the signing type of the fast finality votes.
+	remoteSignerVoteType = "FAST_FINALITY_VOTE"
+
+	// maxRemoteSignerMessageSize is the maximum size of the messages exchanged
+	// with the remote signing service.
+	maxRemoteSignerMessageSize = 64 * 1024
+)
+
+// RemoteSignerConfig is the configuration of the remote signing service.
+type RemoteSignerConfig struct {
+	URL    string // Base URL of the signing service
+	PubKey string // Hex encoded BLS public key to vote with, the first key of the service if empty
+
+	ClientCert string // Client certificate file for mutual TLS
+	ClientKey  string // Client private key file for mutual TLS
+	CACert     string // CA certificate file to verify the signing service, the system roots if empty
+
+	Insecure bool // Allow plain HTTP and connections without a client certificate
+}
+
+// RemoteSignRequest is the body of the signing request sent to the remote service.
+// The vote data is sent along with the signing root, so that the service is able
+// to apply its own slashing protection.
+type RemoteSignRequest struct {
+	Type        string          `json:"type"`
+	SigningRoot common.Hash     `json:"signingRoot"`
+	Vote        *types.VoteData `json:"vote"`
+}
+
+// RemoteSignResponse is the body of the response of the remote service.
+type RemoteSignResponse struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// remoteSigner signs the votes with the key held by a remote signing service.
+type remoteSigner struct {
+	url    string
+	client *http.Client
+}
+
+// NewRemoteVoteSigner creates a vote signer backed by the remote signing service.
+func NewRemoteVoteSigner(config *RemoteSignerConfig) (*VoteSigner, error) {
+	client, err := newRemoteSignerClient(config)
+	if err != nil {
+		return nil, err
+	}
+	signer := &remoteSigner{
+		url:    strings.TrimSuffix(config.URL, "/"),
+		client: client,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), voteSignerTimeout)
+	defer cancel()
+
+	pubKeys, err := signer.publicKeys(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch public keys from remote signer")
+	}
+	if len(pubKeys) == 0 {
+		return nil, errors.New("no public key in remote signer")
+	}
+	pubKey := pubKeys[0]
+	if config.PubKey != "" {
+		want, err := hexutil.Decode(config.PubKey)
+		if err != nil || len(want) != types.BLSPublicKeyLength {
+			return nil, fmt.Errorf("invalid BLS public key %q", config.PubKey)
+		}
+		found := false
+		for _, key := range pubKeys {
+			if bytes.Equal(key[:], want) {
+				pubKey, found = key, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("BLS public key %s not in remote signer", config.PubKey)
+		}
+	}
+	if _, err := bls.PublicKeyFromBytes(pubKey[:]); err != nil {
+		return nil, errors.Wrap(err, "invalid BLS public key from remote signer")
+	}
+	log.Info("Connected to remote BLS signer", "url", signer.url, "pubkey", common.Bytes2Hex(pubKey[:]))
+
+	return &VoteSigner{
+		signer: signer,
+		PubKey: pubKey,
+	}, nil
+}
+
+// newRemoteSignerClient creates the HTTP client, authenticated with the client
+// certificate. Mutual TLS is mandatory unless the configuration is explicitly
+// marked insecure.
+func newRemoteSignerClient(config *RemoteSignerConfig) (*http.Client, error) {
+	secure := strings.HasPrefix(config.URL, "https://")
+	if !config.Insecure {
+		if !secure {
+			return nil, fmt.Errorf("remote signer URL %q is not https", config.URL)
+		}
+		if config.ClientCert == "" || config.ClientKey == "" {
+			return nil, errors.New("remote signer requires a client certificate and key for mutual TLS")
+		}
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if config.ClientCert != "" || config.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(config.ClientCert, config.ClientKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "load remote signer client certificate failed")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if config.CACert != "" {
+		pem, err := os.ReadFile(config.CACert)
+		if err != nil {
+			return nil, errors.Wrap(err, "read remote signer CA certificate failed")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("invalid remote signer CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if !secure {
+		log.Warn("Remote BLS signer is not protected by TLS", "url", config.URL)
+	}
+	return &http.Client{
+		Timeout:   voteSignerTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
+}
+
+func (s *remoteSigner) publicKeys(ctx context.Context) ([]types.BLSPublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url+remoteSignerPublicKeysPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	var keys []hexutil.Bytes
+	if err := s.do(req, &keys); err != nil {
+		return nil, err
+	}
+	pubKeys := make([]types.BLSPublicKey, 0, len(keys))
+	for _, key := range keys {
+		if len(key) != types.BLSPublicKeyLength {
+			return nil, fmt.Errorf("invalid BLS public key %x", key)
+		}
+		pubKeys = append(pubKeys, types.BLSPublicKey(key))
+	}
+	return pubKeys, nil
+}
+
+func (s *remoteSigner) Sign(ctx context.Context, pubKey types.BLSPublicKey, data *types.VoteData) (types.BLSSignature, error) {
+	signingRoot := data.Hash()
+	body, err := json.Marshal(&RemoteSignRequest{
+		Type:        remoteSignerVoteType,
+		SigningRoot: signingRoot,
+		Vote:        data,
+	})
+	if err != nil {
+		return types.BLSSignature{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url+remoteSignerSignPath+hexutil.Encode(pubKey[:]), bytes.NewReader(body))
+	if err != nil {
+		return types.BLSSignature{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var res RemoteSignResponse
+	if err := s.do(req, &res); err != nil {
+		return types.BLSSignature{}, err
+	}
+	// Never trust the remote service blindly, an invalid vote would only be
+	// dropped by the peers.
+	blsPubKey, err := bls.PublicKeyFromBytes(pubKey[:])
+	if err != nil {
+		return types.BLSSignature{}, errors.Wrap(err, "convert public key from bytes to bls failed")
+	}
+	signature, err := bls.SignatureFromBytes(res.Signature)
+	if err != nil {
+		return types.BLSSignature{}, errors.Wrap(err, "invalid signature from remote signer")
+	}
+	if !signature.Verify(blsPubKey, signingRoot[:]) {
+		return types.BLSSignature{}, errors.New("signature from remote signer mismatches the vote")
+	}
+	var sig types.BLSSignature
+	copy(sig[:], res.Signature)
+	return sig, nil
+}
+
+// do sends the request and decodes the JSON response into result.
+func (s *remoteSigner) do(req *http.Request, result interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteSignerMessageSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("remote signer responded %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, result)
+}
+
+// RemoteSignerHandler is a reference implementation of the remote signing service
+// holding the BLS keys in memory. It is meant to be a local stand-in of the real
+// service in tests, the TLS settings are left to the HTTP server serving it.
+type RemoteSignerHandler struct {
+	keys map[types.BLSPublicKey]bls.SecretKey
+	list []types.BLSPublicKey
+}
+
+// NewRemoteSignerHandler creates a signing service signing with the given keys.
+func NewRemoteSignerHandler(keys ...bls.SecretKey) *RemoteSignerHandler {
+	h := &RemoteSignerHandler{keys: make(map[types.BLSPublicKey]bls.SecretKey)}
+	for _, key := range keys {
+		pubKey := types.BLSPublicKey(key.PublicKey().Marshal())
+		h.keys[pubKey] = key
+		h.list = append(h.list, pubKey)
+	}
+	return h
+}
+
+func (h *RemoteSignerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == remoteSignerPublicKeysPath:
+		keys := make([]hexutil.Bytes, 0, len(h.list))
+		for _, pubKey := range h.list {
+			keys = append(keys, pubKey[:])
+		}
+		writeRemoteSignerJSON(w, keys)
+
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, remoteSignerSignPath):
+		pubKey, err := hexutil.Decode(strings.TrimPrefix(r.URL.Path, remoteSignerSignPath))
+		if err != nil || len(pubKey) != types.BLSPublicKeyLength {
+			http.Error(w, "invalid public key", http.StatusBadRequest)
+			return
+		}
+		key, ok := h.keys[types.BLSPublicKey(pubKey)]
+		if !ok {
+			http.Error(w, "public key not found", http.StatusNotFound)
+			return
+		}
+		var req RemoteSignRequest
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxRemoteSignerMessageSize)).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Type != remoteSignerVoteType || req.Vote == nil || req.Vote.Hash() != req.SigningRoot {
+			http.Error(w, "invalid signing request", http.StatusBadRequest)
+			return
+		}
+		writeRemoteSignerJSON(w, &RemoteSignResponse{Signature: key.Sign(req.SigningRoot[:]).Marshal()})
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeRemoteSignerJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Debug("Failed to write remote signer response", "err", err)
+	}
+}
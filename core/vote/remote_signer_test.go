@@ -0,0 +1,132 @@
+package vote
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// newTestCert issues a certificate signed by parent, or a self-signed CA if parent is nil.
+func newTestCert(t *testing.T, serial int64, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		template.IsCA, template.BasicConstraintsValid = true, true
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestRemoteVoteSigner(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0600))
+		return path
+	}
+	ca, caKey, caPEM, _ := newTestCert(t, 1, nil, nil)
+	_, _, serverPEM, serverKeyPEM := newTestCert(t, 2, ca, caKey)
+	_, _, clientPEM, clientKeyPEM := newTestCert(t, 3, ca, caKey)
+
+	serverCert, err := tls.X509KeyPair(serverPEM, serverKeyPEM)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca)
+
+	secretKey, err := bls.RandKey()
+	require.NoError(t, err)
+	var requests atomic.Int32
+	handler := NewRemoteSignerHandler(secretKey)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		handler.ServeHTTP(w, r)
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	config := &RemoteSignerConfig{
+		URL:        server.URL,
+		ClientCert: write("client.crt", clientPEM),
+		ClientKey:  write("client.key", clientKeyPEM),
+		CACert:     write("ca.crt", caPEM),
+	}
+	signer, err := NewRemoteVoteSigner(config)
+	require.NoError(t, err)
+	assert.Equal(t, secretKey.PublicKey().Marshal(), signer.PubKey[:])
+
+	vote := &types.VoteEnvelope{Data: &types.VoteData{SourceNumber: 9, SourceHash: common.Hash{0x9}, TargetNumber: 10, TargetHash: common.Hash{0xa}}}
+	require.NoError(t, signer.SignVote(vote))
+	assert.Equal(t, signer.PubKey[:], vote.VoteAddress[:])
+	assert.NoError(t, vote.Verify())
+
+	// The slashing protection is checked before reaching the remote signer.
+	signer.watermark = &types.VoteWatermark{SourceNumber: 9, TargetNumber: 10}
+	sent := requests.Load()
+	assert.Error(t, signer.SignVote(&types.VoteEnvelope{Data: &types.VoteData{SourceNumber: 9, TargetNumber: 10}}))
+	assert.Equal(t, sent, requests.Load())
+
+	// Unknown keys and clients without certificate are rejected.
+	config.PubKey = "0x" + common.Bytes2Hex(make([]byte, types.BLSPublicKeyLength))
+	_, err = NewRemoteVoteSigner(config)
+	assert.Error(t, err)
+
+	_, err = NewRemoteVoteSigner(&RemoteSignerConfig{URL: server.URL, CACert: config.CACert})
+	assert.Error(t, err)
+	_, err = NewRemoteVoteSigner(&RemoteSignerConfig{URL: server.URL, CACert: config.CACert, Insecure: true})
+	assert.Error(t, err)
+}
+
+func TestRemoteVoteSignerInsecure(t *testing.T) {
+	secretKey, err := bls.RandKey()
+	require.NoError(t, err)
+	server := httptest.NewServer(NewRemoteSignerHandler(secretKey))
+	defer server.Close()
+
+	// Plain HTTP is rejected unless explicitly allowed.
+	_, err = NewRemoteVoteSigner(&RemoteSignerConfig{URL: server.URL})
+	assert.Error(t, err)
+
+	signer, err := NewRemoteVoteSigner(&RemoteSignerConfig{URL: server.URL, Insecure: true})
+	require.NoError(t, err)
+	assert.Equal(t, secretKey.PublicKey().Marshal(), signer.PubKey[:])
+}
@@ -0,0 +1,153 @@
+package vote
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// InterchangeFormatVersion is the version of the slashing protection interchange
+// format, the format is modeled on EIP-3076 with the epochs replaced by the block
+// numbers of the fast finality votes.
+const InterchangeFormatVersion = "5"
+
+// SlashingProtection is the slashing protection interchange document, it records
+// the highest source and target number each BLS key has voted for, so that a vote
+// key can be moved to another host without the risk of a double vote.
+type SlashingProtection struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []InterchangeData   `json:"data"`
+}
+
+// InterchangeMetadata identifies the format and the chain of the interchange
+// document. The genesis hash takes the place of the genesis validators root.
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string      `json:"interchange_format_version"`
+	GenesisValidatorsRoot    common.Hash `json:"genesis_validators_root"`
+}
+
+// InterchangeData is the signing history of a single BLS key. Parlia validators
+// don't sign blocks with the BLS key, so SignedBlocks is always empty.
+type InterchangeData struct {
+	Pubkey             hexutil.Bytes            `json:"pubkey"`
+	SignedBlocks       []InterchangeBlock       `json:"signed_blocks"`
+	SignedAttestations []InterchangeAttestation `json:"signed_attestations"`
+}
+
+// InterchangeBlock is a signed block of the interchange format.
+type InterchangeBlock struct {
+	Slot        uint64       `json:"slot,string"`
+	SigningRoot *common.Hash `json:"signing_root,omitempty"`
+}
+
+// InterchangeAttestation is a signed vote of the interchange format, the source
+// and target epochs are the source and target block numbers of the vote.
+type InterchangeAttestation struct {
+	SourceEpoch uint64       `json:"source_epoch,string"`
+	TargetEpoch uint64       `json:"target_epoch,string"`
+	SigningRoot *common.Hash `json:"signing_root,omitempty"`
+}
+
+// ExportSlashingProtection exports the slashing protection history of all the BLS
+// keys known to the node, which is the imported history in db merged with the
+// votes in the journal. The journal is optional.
+func ExportSlashingProtection(db ethdb.Iteratee, journal *VoteJournal, genesisHash common.Hash) (*SlashingProtection, error) {
+	watermarks := rawdb.ReadAllVoteWatermarks(db)
+	if journal != nil {
+		voted, err := journal.Watermarks()
+		if err != nil {
+			return nil, err
+		}
+		for pubKey, watermark := range voted {
+			watermarks[pubKey] = mergeWatermark(watermarks[pubKey], watermark)
+		}
+	}
+	protection := &SlashingProtection{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    genesisHash,
+		},
+		Data: make([]InterchangeData, 0, len(watermarks)),
+	}
+	for pubKey, watermark := range watermarks {
+		protection.Data = append(protection.Data, InterchangeData{
+			Pubkey:       common.CopyBytes(pubKey[:]),
+			SignedBlocks: []InterchangeBlock{},
+			SignedAttestations: []InterchangeAttestation{{
+				SourceEpoch: watermark.SourceNumber,
+				TargetEpoch: watermark.TargetNumber,
+			}},
+		})
+	}
+	return protection, nil
+}
+
+// ImportSlashingProtection imports the slashing protection history into db, the
+// imported watermark of a BLS key only ever moves forward. It returns the number
+// of BLS keys imported.
+func ImportSlashingProtection(db ethdb.KeyValueStore, protection *SlashingProtection, genesisHash common.Hash) (int, error) {
+	if protection.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return 0, fmt.Errorf("unsupported interchange format version %q", protection.Metadata.InterchangeFormatVersion)
+	}
+	if protection.Metadata.GenesisValidatorsRoot != genesisHash {
+		return 0, fmt.Errorf("genesis mismatch: have %x, want %x", protection.Metadata.GenesisValidatorsRoot, genesisHash)
+	}
+	watermarks := make(map[types.BLSPublicKey]*types.VoteWatermark)
+	for _, data := range protection.Data {
+		if len(data.Pubkey) != types.BLSPublicKeyLength {
+			return 0, fmt.Errorf("invalid BLS public key %x", data.Pubkey)
+		}
+		var pubKey types.BLSPublicKey
+		copy(pubKey[:], data.Pubkey)
+		for _, attestation := range data.SignedAttestations {
+			if attestation.SourceEpoch > attestation.TargetEpoch {
+				return 0, fmt.Errorf("invalid attestation of %x: source %d above target %d", data.Pubkey, attestation.SourceEpoch, attestation.TargetEpoch)
+			}
+			watermarks[pubKey] = mergeWatermark(watermarks[pubKey], &types.VoteWatermark{
+				SourceNumber: attestation.SourceEpoch,
+				TargetNumber: attestation.TargetEpoch,
+			})
+		}
+	}
+	batch := db.NewBatch()
+	for pubKey, watermark := range watermarks {
+		rawdb.WriteVoteWatermark(batch, pubKey, mergeWatermark(rawdb.ReadVoteWatermark(db, pubKey), watermark))
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	return len(watermarks), nil
+}
+
+// checkWatermark reports whether the vote conflicts with the imported slashing
+// protection history, in which case it must not be signed.
+func checkWatermark(watermark *types.VoteWatermark, vote *types.VoteData) error {
+	if watermark == nil {
+		return nil
+	}
+	if vote.SourceNumber < watermark.SourceNumber {
+		return fmt.Errorf("source %d below the slashing protection watermark %d", vote.SourceNumber, watermark.SourceNumber)
+	}
+	if vote.TargetNumber <= watermark.TargetNumber {
+		return fmt.Errorf("target %d not above the slashing protection watermark %d", vote.TargetNumber, watermark.TargetNumber)
+	}
+	return nil
+}
+
+// mergeWatermark returns the higher source and target number of a and b.
+func mergeWatermark(a, b *types.VoteWatermark) *types.VoteWatermark {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	return &types.VoteWatermark{
+		SourceNumber: max(a.SourceNumber, b.SourceNumber),
+		TargetNumber: max(a.TargetNumber, b.TargetNumber),
+	}
+}
@@ -0,0 +1,70 @@
+package vote
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestSlashingProtectionInterchange(t *testing.T) {
+	var (
+		genesis = common.Hash{0x1}
+		keyA    = types.BLSPublicKey{0xa}
+		keyB    = types.BLSPublicKey{0xb}
+	)
+	journal, err := NewVoteJournal(filepath.Join(t.TempDir(), "voteJournal"))
+	require.NoError(t, err)
+	for _, vote := range []*types.VoteEnvelope{
+		{VoteAddress: keyA, Data: &types.VoteData{SourceNumber: 9, TargetNumber: 10}},
+		{VoteAddress: keyA, Data: &types.VoteData{SourceNumber: 10, TargetNumber: 11}},
+		{VoteAddress: keyB, Data: &types.VoteData{SourceNumber: 3, TargetNumber: 5}},
+	} {
+		require.NoError(t, journal.WriteVote(vote))
+	}
+
+	// The history imported before is merged with the journal.
+	oldDB := rawdb.NewMemoryDatabase()
+	rawdb.WriteVoteWatermark(oldDB, keyB, &types.VoteWatermark{SourceNumber: 6, TargetNumber: 7})
+
+	exported, err := ExportSlashingProtection(oldDB, journal, genesis)
+	require.NoError(t, err)
+	require.Len(t, exported.Data, 2)
+
+	enc, err := json.Marshal(exported)
+	require.NoError(t, err)
+	assert.Contains(t, string(enc), `"source_epoch":"10","target_epoch":"11"`)
+
+	protection := new(SlashingProtection)
+	require.NoError(t, json.Unmarshal(enc, protection))
+
+	newDB := rawdb.NewMemoryDatabase()
+	_, err = ImportSlashingProtection(newDB, protection, common.Hash{0x2})
+	assert.Error(t, err, "history of another chain must be rejected")
+
+	imported, err := ImportSlashingProtection(newDB, protection, genesis)
+	require.NoError(t, err)
+	assert.Equal(t, 2, imported)
+	assert.Equal(t, &types.VoteWatermark{SourceNumber: 10, TargetNumber: 11}, rawdb.ReadVoteWatermark(newDB, keyA))
+	assert.Equal(t, &types.VoteWatermark{SourceNumber: 6, TargetNumber: 7}, rawdb.ReadVoteWatermark(newDB, keyB))
+
+	// Importing an older history never lowers the watermark.
+	protection.Data[0].SignedAttestations[0] = InterchangeAttestation{SourceEpoch: 1, TargetEpoch: 2}
+	protection.Data[1].SignedAttestations[0] = InterchangeAttestation{SourceEpoch: 1, TargetEpoch: 2}
+	_, err = ImportSlashingProtection(newDB, protection, genesis)
+	require.NoError(t, err)
+	assert.Equal(t, &types.VoteWatermark{SourceNumber: 10, TargetNumber: 11}, rawdb.ReadVoteWatermark(newDB, keyA))
+
+	// Votes conflicting with the watermark are refused.
+	watermark := rawdb.ReadVoteWatermark(newDB, keyA)
+	assert.Error(t, checkWatermark(watermark, &types.VoteData{SourceNumber: 10, TargetNumber: 11}))
+	assert.Error(t, checkWatermark(watermark, &types.VoteData{SourceNumber: 9, TargetNumber: 12}))
+	assert.NoError(t, checkWatermark(watermark, &types.VoteData{SourceNumber: 11, TargetNumber: 12}))
+	assert.NoError(t, checkWatermark(nil, &types.VoteData{SourceNumber: 0, TargetNumber: 1}))
+}
@@ -118,3 +118,31 @@ func (journal *VoteJournal) ReadVote(index uint64) (*types.VoteEnvelope, error)
 
 	return vote, nil
 }
+
+// Watermarks returns the highest source and target number voted by each BLS key
+// among the votes kept in the journal.
+func (journal *VoteJournal) Watermarks() (map[types.BLSPublicKey]*types.VoteWatermark, error) {
+	firstIndex, err := journal.walLog.FirstIndex()
+	if err != nil {
+		return nil, err
+	}
+	lastIndex, err := journal.walLog.LastIndex()
+	if err != nil {
+		return nil, err
+	}
+	watermarks := make(map[types.BLSPublicKey]*types.VoteWatermark)
+	for index := firstIndex; index <= lastIndex && index > 0; index++ {
+		vote, err := journal.ReadVote(index)
+		if err != nil {
+			return nil, err
+		}
+		if vote == nil || vote.Data == nil {
+			continue
+		}
+		watermarks[vote.VoteAddress] = mergeWatermark(watermarks[vote.VoteAddress], &types.VoteWatermark{
+			SourceNumber: vote.Data.SourceNumber,
+			TargetNumber: vote.Data.TargetNumber,
+		})
+	}
+	return watermarks, nil
+}
@@ -10,8 +10,10 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/parlia"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
@@ -58,24 +60,23 @@ type VoteManager struct {
 	engine consensus.PoSA
 }
 
-func NewVoteManager(eth Backend, chain *core.BlockChain, pool *VotePool, journalPath, blsPasswordPath, blsWalletPath string, engine consensus.PoSA) (*VoteManager, error) {
+func NewVoteManager(eth Backend, chain *core.BlockChain, db ethdb.KeyValueReader, pool *VotePool, journalPath string, signer *VoteSigner, engine consensus.PoSA) (*VoteManager, error) {
 	voteManager := &VoteManager{
 		eth:                    eth,
 		chain:                  chain,
 		highestVerifiedBlockCh: make(chan core.HighestVerifiedBlockEvent, highestVerifiedBlockChanSize),
 		syncVoteCh:             make(chan core.NewVoteEvent, voteBufferForPut),
 		pool:                   pool,
+		signer:                 signer,
 		engine:                 engine,
 	}
+	metrics.GetOrRegisterLabel("miner-info", nil).Mark(map[string]interface{}{"VoteKey": common.Bytes2Hex(voteManager.signer.PubKey[:])})
 
-	// Create voteSigner.
-	voteSigner, err := NewVoteSigner(blsPasswordPath, blsWalletPath)
-	if err != nil {
-		return nil, err
+	// Load the slashing protection history imported for the vote key.
+	if watermark := rawdb.ReadVoteWatermark(db, signer.PubKey); watermark != nil {
+		log.Info("Load slashing protection history", "source", watermark.SourceNumber, "target", watermark.TargetNumber)
+		signer.watermark = watermark
 	}
-	log.Info("Create voteSigner successfully")
-	voteManager.signer = voteSigner
-	metrics.GetOrRegisterLabel("miner-info", nil).Mark(map[string]interface{}{"VoteKey": common.Bytes2Hex(voteManager.signer.PubKey[:])})
 
 	// Create voteJournal
 	voteJournal, err := NewVoteJournal(journalPath)
@@ -276,6 +277,12 @@ func (voteManager *VoteManager) UnderRules(header *types.Header) (bool, uint64,
 
 	targetNumber := header.Number.Uint64()
 
+	// The vote must not conflict with the slashing protection history imported from other hosts.
+	if err := checkWatermark(voteManager.signer.watermark, &types.VoteData{SourceNumber: sourceNumber, TargetNumber: targetNumber}); err != nil {
+		log.Debug("Refuse to vote conflicting with the slashing protection history", "err", err)
+		return false, 0, common.Hash{}
+	}
+
 	voteDataBuffer := voteManager.journal.voteDataBuffer
 	//Rule 1:  A validator must not publish two distinct votes for the same height.
 	if voteDataBuffer.Contains(targetNumber) {
@@ -44,18 +44,24 @@ type VoteBox struct {
 	blockNumber  uint64
 	blockHash    common.Hash
 	voteMessages []*types.VoteEnvelope
+	recvTimes    []int64 // Time in milliseconds the votes were received, in the order of the messages
 }
 
+// trySetRecvVoteTime records the time the last vote of the box was received, and
+// updates the vote statistics of the block.
 func (v *VoteBox) trySetRecvVoteTime(chain *core.BlockChain) {
+	now := time.Now().UnixMilli()
+	v.recvTimes = append(v.recvTimes, now)
+
 	stats := chain.GetBlockStats(v.blockHash)
 	if len(v.voteMessages) == 1 {
-		stats.FirstRecvVoteTime.Store(time.Now().UnixMilli())
+		stats.FirstRecvVoteTime.Store(now)
 	}
 	if stats.RecvMajorityVoteTime.Load() > 0 {
 		return
 	}
 	if len(v.voteMessages) >= defaultMajorityThreshold {
-		stats.RecvMajorityVoteTime.Store(time.Now().UnixMilli())
+		stats.RecvMajorityVoteTime.Store(now)
 	}
 }
 
@@ -272,7 +278,8 @@ func (pool *VotePool) transfer(blockHash common.Hash) {
 	}
 
 	validVotes := make([]*types.VoteEnvelope, 0, len(voteBox.voteMessages))
-	for _, vote := range voteBox.voteMessages {
+	recvTimes := make([]int64, 0, len(voteBox.voteMessages))
+	for i, vote := range voteBox.voteMessages {
 		// Verify if the vote comes from valid validators based on voteAddress (BLSPublicKey).
 		if pool.engine.VerifyVote(pool.chain, vote) != nil {
 			pool.receivedVotes.Remove(vote.Hash())
@@ -283,6 +290,7 @@ func (pool *VotePool) transfer(blockHash common.Hash) {
 		voteEv := core.NewVoteEvent{Vote: vote}
 		pool.votesFeed.Send(voteEv)
 		validVotes = append(validVotes, vote)
+		recvTimes = append(recvTimes, voteBox.recvTimes[i])
 	}
 
 	// may len(curVotes[blockHash].voteMessages) extra maxCurVoteAmountPerBlock, but it doesn't matter
@@ -292,10 +300,12 @@ func (pool *VotePool) transfer(blockHash common.Hash) {
 			blockNumber:  voteBox.blockNumber,
 			blockHash:    voteBox.blockHash,
 			voteMessages: validVotes,
+			recvTimes:    recvTimes,
 		}
 		localCurVotesPqGauge.Update(int64(curPq.Len()))
 	} else {
 		curVotes[blockHash].voteMessages = append(curVotes[blockHash].voteMessages, validVotes...)
+		curVotes[blockHash].recvTimes = append(curVotes[blockHash].recvTimes, recvTimes...)
 	}
 
 	delete(futureVotes, blockHash)
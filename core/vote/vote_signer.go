@@ -24,9 +24,18 @@ const (
 
 var votesSigningErrorCounter = metrics.NewRegisteredCounter("votesSigner/error", nil)
 
+// BLSSigner is the backend holding the BLS vote key, which is either the local
+// wallet or a remote signing service.
+type BLSSigner interface {
+	// Sign signs the vote data with the BLS key of pubKey.
+	Sign(ctx context.Context, pubKey types.BLSPublicKey, data *types.VoteData) (types.BLSSignature, error)
+}
+
 type VoteSigner struct {
-	km     *keymanager.IKeymanager
+	signer BLSSigner
 	PubKey [48]byte
+
+	watermark *types.VoteWatermark // imported slashing protection history of the vote key
 }
 
 func NewVoteSigner(blsPasswordPath, blsWalletPath string) (*VoteSigner, error) {
@@ -73,33 +82,49 @@ func NewVoteSigner(blsPasswordPath, blsWalletPath string) (*VoteSigner, error) {
 	}
 
 	return &VoteSigner{
-		km:     &km,
+		signer: &localSigner{km: km},
 		PubKey: pubKeys[0],
 	}, nil
 }
 
+// SignVote signs the vote with the vote key. The vote is refused if it conflicts
+// with the imported slashing protection history, before reaching the backend.
 func (signer *VoteSigner) SignVote(vote *types.VoteEnvelope) error {
-	// Sign the vote, fetch the first pubKey as validator's bls public key.
-	pubKey := signer.PubKey
-	blsPubKey, err := bls.PublicKeyFromBytes(pubKey[:])
-	if err != nil {
-		return errors.Wrap(err, "convert public key from bytes to bls failed")
+	if err := checkWatermark(signer.watermark, vote.Data); err != nil {
+		return err
 	}
 
-	voteDataHash := vote.Data.Hash()
-
 	ctx, cancel := context.WithTimeout(context.Background(), voteSignerTimeout)
 	defer cancel()
 
-	signature, err := (*signer.km).Sign(ctx, &validatorpb.SignRequest{
-		PublicKey:   pubKey[:],
-		SigningRoot: voteDataHash[:],
-	})
+	signature, err := signer.signer.Sign(ctx, signer.PubKey, vote.Data)
 	if err != nil {
 		return err
 	}
 
-	copy(vote.VoteAddress[:], blsPubKey.Marshal()[:])
-	copy(vote.Signature[:], signature.Marshal()[:])
+	copy(vote.VoteAddress[:], signer.PubKey[:])
+	copy(vote.Signature[:], signature[:])
 	return nil
 }
+
+// localSigner signs the votes with the key in the local BLS wallet.
+type localSigner struct {
+	km keymanager.IKeymanager
+}
+
+func (s *localSigner) Sign(ctx context.Context, pubKey types.BLSPublicKey, data *types.VoteData) (types.BLSSignature, error) {
+	if _, err := bls.PublicKeyFromBytes(pubKey[:]); err != nil {
+		return types.BLSSignature{}, errors.Wrap(err, "convert public key from bytes to bls failed")
+	}
+	voteDataHash := data.Hash()
+	signature, err := s.km.Sign(ctx, &validatorpb.SignRequest{
+		PublicKey:   pubKey[:],
+		SigningRoot: voteDataHash[:],
+	})
+	if err != nil {
+		return types.BLSSignature{}, err
+	}
+	var sig types.BLSSignature
+	copy(sig[:], signature.Marshal())
+	return sig, nil
+}
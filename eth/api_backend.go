@@ -40,6 +40,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -206,7 +207,7 @@ func (b *EthAPIBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.B
 	if header == nil {
 		return nil, nil, errors.New("header not found")
 	}
-	stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+	stateDb, err := b.stateAt(header.Root)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -228,7 +229,7 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 		if blockNrOrHash.RequireCanonical && b.eth.blockchain.GetCanonicalHash(header.Number.Uint64()) != hash {
 			return nil, nil, errors.New("hash is not currently canonical")
 		}
-		stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+		stateDb, err := b.stateAt(header.Root)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -237,6 +238,21 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 	return nil, nil, errors.New("invalid arguments; neither block nor hash specified")
 }
 
+// stateAt returns the state with the given root, falling back to the indexed
+// state histories for the historic states no longer held by the trie database.
+func (b *EthAPIBackend) stateAt(root common.Hash) (*state.StateDB, error) {
+	stateDb, err := b.eth.BlockChain().StateAt(root)
+	if err == nil || !b.eth.config.StateIndexing {
+		return stateDb, err
+	}
+	historic, herr := b.eth.BlockChain().HistoricState(root)
+	if herr != nil {
+		log.Debug("Historic state is not available", "root", root, "err", herr)
+		return nil, err
+	}
+	return historic, nil
+}
+
 func (b *EthAPIBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
 	return b.eth.blockchain.GetReceiptsByHash(hash), nil
 }
@@ -289,10 +305,21 @@ func (b *EthAPIBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscri
 }
 
 func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
-	if locals := b.eth.localTxTracker; locals != nil {
+	// Conditional and private transactions are not tracked, the tracker would
+	// resubmit them once dropped for violating their conditions or expiring,
+	// and lose the conditions when journaled.
+	private := signedTx.Private()
+	if locals := b.eth.localTxTracker; locals != nil && signedTx.Options() == nil && private == nil {
 		locals.Track(signedTx)
 	}
-	return b.eth.txPool.Add([]*types.Transaction{signedTx}, false)[0]
+	if err := b.eth.txPool.Add([]*types.Transaction{signedTx}, false)[0]; err != nil {
+		return err
+	}
+	// Private transactions are shared with the trusted endpoints only
+	if private != nil && b.eth.privateTxs != nil {
+		b.eth.privateTxs.forward(signedTx, private)
+	}
+	return nil
 }
 
 func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
@@ -300,7 +327,7 @@ func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	var txs types.Transactions
 	for _, batch := range pending {
 		for _, lazy := range batch {
-			if tx := lazy.Resolve(); tx != nil {
+			if tx := lazy.Resolve(); tx != nil && tx.Private() == nil {
 				txs = append(txs, tx)
 			}
 		}
@@ -309,7 +336,10 @@ func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 }
 
 func (b *EthAPIBackend) GetPoolTransaction(hash common.Hash) *types.Transaction {
-	return b.eth.txPool.Get(hash)
+	if tx := b.eth.txPool.Get(hash); tx != nil && tx.Private() == nil {
+		return tx
+	}
+	return nil
 }
 
 // GetTransaction retrieves the lookup along with the transaction itself associate
@@ -342,11 +372,37 @@ func (b *EthAPIBackend) Stats() (runnable int, blocked int) {
 }
 
 func (b *EthAPIBackend) TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
-	return b.eth.txPool.Content()
+	pending, queued := b.eth.txPool.Content()
+	return publicTxsByAccount(pending), publicTxsByAccount(queued)
 }
 
 func (b *EthAPIBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
-	return b.eth.txPool.ContentFrom(addr)
+	pending, queued := b.eth.txPool.ContentFrom(addr)
+	return publicTxs(pending), publicTxs(queued)
+}
+
+// publicTxs filters out the private transactions, which are not exposed through
+// the RPC until they are included.
+func publicTxs(txs []*types.Transaction) []*types.Transaction {
+	public := make([]*types.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		if tx.Private() == nil {
+			public = append(public, tx)
+		}
+	}
+	return public
+}
+
+// publicTxsByAccount filters out the private transactions of each account, and
+// the accounts left without transactions.
+func publicTxsByAccount(txs map[common.Address][]*types.Transaction) map[common.Address][]*types.Transaction {
+	public := make(map[common.Address][]*types.Transaction, len(txs))
+	for addr, list := range txs {
+		if list = publicTxs(list); len(list) > 0 {
+			public[addr] = list
+		}
+	}
+	return public
 }
 
 func (b *EthAPIBackend) TxPool() *txpool.TxPool {
@@ -357,6 +413,10 @@ func (b *EthAPIBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.S
 	return b.eth.txPool.SubscribeTransactions(ch, true)
 }
 
+func (b *EthAPIBackend) SubscribeTxPoolEvents(ch chan<- []*txpool.TxEvent) event.Subscription {
+	return b.eth.txPool.SubscribeTxEvents(ch)
+}
+
 func (b *EthAPIBackend) SubscribeNewVoteEvent(ch chan<- core.NewVoteEvent) event.Subscription {
 	if b.eth.VotePool() == nil {
 		return nil
@@ -501,6 +561,10 @@ func (b *EthAPIBackend) HasBuilder(builder common.Address) bool {
 	return b.Miner().HasBuilder(builder)
 }
 
+func (b *EthAPIBackend) BuilderStats() []*types.BuilderStats {
+	return b.Miner().BuilderStats()
+}
+
 func (b *EthAPIBackend) SendBid(ctx context.Context, bid *types.BidArgs) (common.Hash, error) {
 	return b.Miner().SendBid(ctx, bid)
 }
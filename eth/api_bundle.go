@@ -0,0 +1,151 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core/txpool/bundlepool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BundleAPI provides an API to submit and simulate transaction bundles.
+type BundleAPI struct {
+	e *Ethereum
+}
+
+// NewBundleAPI creates a new BundleAPI instance.
+func NewBundleAPI(e *Ethereum) *BundleAPI {
+	return &BundleAPI{e}
+}
+
+// SendBundle adds the bundle to the pool merged into the blocks built locally,
+// and returns its hash. The transactions of the bundle are included in order
+// in a block of the given range, or not at all.
+func (api *BundleAPI) SendBundle(ctx context.Context, args types.SendBundleArgs) (common.Hash, error) {
+	bundle, err := args.ToBundle()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := api.e.bundlePool.Add(bundle); err != nil {
+		return common.Hash{}, err
+	}
+	return bundle.Hash(), nil
+}
+
+// CallBundleArgs represents the arguments to simulate a bundle.
+type CallBundleArgs struct {
+	Txs               []hexutil.Bytes        `json:"txs"`
+	StateBlock        *rpc.BlockNumberOrHash `json:"stateBlock"`
+	Timestamp         *hexutil.Uint64        `json:"timestamp"`
+	Coinbase          *common.Address        `json:"coinbase"`
+	RevertingTxHashes []common.Hash          `json:"revertingTxHashes"`
+}
+
+// CallBundleTxResult is the outcome of a transaction of a simulated bundle.
+type CallBundleTxResult struct {
+	TxHash   common.Hash    `json:"txHash"`
+	GasUsed  hexutil.Uint64 `json:"gasUsed"`
+	Reverted bool           `json:"reverted"`
+}
+
+// CallBundleResult is the outcome of a simulated bundle.
+type CallBundleResult struct {
+	BundleHash  common.Hash           `json:"bundleHash"`
+	BlockNumber hexutil.Uint64        `json:"blockNumber"`
+	Results     []*CallBundleTxResult `json:"results"`
+	GasUsed     hexutil.Uint64        `json:"gasUsed"`
+	Reward      *hexutil.Big          `json:"reward"`
+	GasPrice    *hexutil.Big          `json:"gasPrice"`
+}
+
+// CallBundle simulates the bundle in the block following the given state block,
+// the latest one by default, without adding it to the pool.
+func (api *BundleAPI) CallBundle(ctx context.Context, args CallBundleArgs) (*CallBundleResult, error) {
+	if len(args.Txs) == 0 {
+		return nil, errors.New("bundle missing txs")
+	}
+	stateBlock := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if args.StateBlock != nil {
+		stateBlock = *args.StateBlock
+	}
+	statedb, parent, err := api.e.APIBackend.StateAndHeaderByNumberOrHash(ctx, stateBlock)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	number := parent.Number.Uint64() + 1
+	bundle, err := (&types.SendBundleArgs{
+		Txs:               args.Txs,
+		MinBlockNumber:    hexutil.Uint64(number),
+		MaxBlockNumber:    hexutil.Uint64(number),
+		RevertingTxHashes: args.RevertingTxHashes,
+	}).ToBundle()
+	if err != nil {
+		return nil, err
+	}
+	// Reject the bundles the pool would refuse
+	for _, tx := range bundle.Txs {
+		if tx.Type() == types.BlobTxType {
+			return nil, bundlepool.ErrBlobTxInBundle
+		}
+	}
+	config := api.e.blockchain.Config()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).SetUint64(number),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + 1,
+		Difficulty: parent.Difficulty,
+		Coinbase:   parent.Coinbase,
+	}
+	if args.Timestamp != nil {
+		header.Time = uint64(*args.Timestamp)
+	}
+	if args.Coinbase != nil {
+		header.Coinbase = *args.Coinbase
+	}
+	if config.IsLondon(header.Number) {
+		header.BaseFee = eip1559.CalcBaseFee(config, parent)
+	}
+	sim, err := miner.SimulateBundle(config, api.e.blockchain, statedb, header, bundle)
+	if err != nil {
+		return nil, err
+	}
+	result := &CallBundleResult{
+		BundleHash:  bundle.Hash(),
+		BlockNumber: hexutil.Uint64(number),
+		Results:     make([]*CallBundleTxResult, len(sim.Receipts)),
+		GasUsed:     hexutil.Uint64(sim.GasUsed),
+		Reward:      (*hexutil.Big)(sim.Reward),
+		GasPrice:    (*hexutil.Big)(sim.GasPrice),
+	}
+	for i, receipt := range sim.Receipts {
+		result.Results[i] = &CallBundleTxResult{
+			TxHash:   receipt.TxHash,
+			GasUsed:  hexutil.Uint64(receipt.GasUsed),
+			Reverted: receipt.Status == types.ReceiptStatusFailed,
+		}
+	}
+	return result, nil
+}
@@ -359,6 +359,98 @@ func (api *DebugAPI) getModifiedAccounts(startBlock, endBlock *types.Block) ([]c
 	return dirty, nil
 }
 
+// StateDiffMaxSlots is the maximum number of changed storage slots to be
+// returned per call of debug_getStateDiff.
+const StateDiffMaxSlots = 4096
+
+// StateDiffOptions specifies the accounts to compare in a debug_getStateDiff call.
+type StateDiffOptions struct {
+	Addresses  []common.Address `json:"addresses"`
+	Start      hexutil.Bytes    `json:"start"`
+	MaxResults int              `json:"maxResults"`
+	MaxSlots   int              `json:"maxSlots"`
+}
+
+// StateDiffResult is the result of a debug_getStateDiff API call.
+type StateDiffResult struct {
+	Accounts []*state.AccountDiff `json:"accounts"`
+	Next     hexutil.Bytes        `json:"next,omitempty"` // nil if no more accounts changed
+}
+
+// GetStateDiff returns the accounts created, deleted or modified between the
+// states of the given blocks, in the order of the account hashes. The number of
+// accounts returned is capped by AccountRangeMaxResults and the number of slots
+// by StateDiffMaxSlots, the comparison can be resumed from the returned next
+// account hash. The storage changes of the last account are marked truncated if
+// they exceed the slot cap.
+func (api *DebugAPI) GetStateDiff(ctx context.Context, from, to rpc.BlockNumberOrHash, opts *StateDiffOptions) (*StateDiffResult, error) {
+	rootA, err := api.stateRoot(from)
+	if err != nil {
+		return nil, err
+	}
+	rootB, err := api.stateRoot(to)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = new(StateDiffOptions)
+	}
+	conf := &state.DiffConfig{
+		Addresses: opts.Addresses,
+		Start:     opts.Start,
+		Max:       uint64(opts.MaxResults),
+		MaxSlots:  uint64(opts.MaxSlots),
+	}
+	if opts.MaxResults > AccountRangeMaxResults || opts.MaxResults <= 0 {
+		conf.Max = AccountRangeMaxResults
+	}
+	if opts.MaxSlots > StateDiffMaxSlots || opts.MaxSlots <= 0 {
+		conf.MaxSlots = StateDiffMaxSlots
+	}
+	var (
+		result = &StateDiffResult{Accounts: []*state.AccountDiff{}}
+		differ = state.NewStateDiffer(api.eth.blockchain.TrieDB(), api.eth.blockchain.Snapshots())
+	)
+	result.Next, err = differ.Diff(ctx, rootA, rootB, conf, func(diff *state.AccountDiff) error {
+		result.Accounts = append(result.Accounts, diff)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// stateRoot resolves the state root of the given block.
+func (api *DebugAPI) stateRoot(blockNrOrHash rpc.BlockNumberOrHash) (common.Hash, error) {
+	var header *types.Header
+	if number, ok := blockNrOrHash.Number(); ok {
+		switch number {
+		case rpc.PendingBlockNumber:
+			return common.Hash{}, errors.New("pending state is not supported")
+		case rpc.LatestBlockNumber:
+			header = api.eth.blockchain.CurrentBlock()
+		case rpc.FinalizedBlockNumber:
+			header = api.eth.blockchain.CurrentFinalBlock()
+		case rpc.SafeBlockNumber:
+			header = api.eth.blockchain.CurrentSafeBlock()
+		default:
+			header = api.eth.blockchain.GetHeaderByNumber(uint64(number))
+		}
+		if header == nil {
+			return common.Hash{}, fmt.Errorf("block #%d not found", number)
+		}
+	} else if hash, ok := blockNrOrHash.Hash(); ok {
+		header = api.eth.blockchain.GetHeaderByHash(hash)
+		if header == nil {
+			return common.Hash{}, fmt.Errorf("block %s not found", hash.Hex())
+		}
+	} else {
+		return common.Hash{}, errors.New("either block number or block hash must be specified")
+	}
+	return header.Root, nil
+}
+
 // GetAccessibleState returns the first number where the node has accessible
 // state on disk. Note this being the post-state of that block and the pre-state
 // of the next block.
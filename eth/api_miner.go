@@ -24,6 +24,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/miner"
 )
 
 // MinerAPI provides an API to control the miner.
@@ -117,3 +118,9 @@ func (api *MinerAPI) AddBuilder(builder common.Address, url string) error {
 func (api *MinerAPI) RemoveBuilder(builder common.Address) error {
 	return api.e.APIBackend.RemoveBuilder(builder)
 }
+
+// GetBlockTemplate builds a block on the given parent in a dry run, the way the
+// local worker would, and returns it along with the best bid of the parent.
+func (api *MinerAPI) GetBlockTemplate(parentHash common.Hash) (*miner.BlockTemplate, error) {
+	return api.e.Miner().BlockTemplate(parentHash)
+}
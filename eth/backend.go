@@ -41,6 +41,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state/pruner"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
+	"github.com/ethereum/go-ethereum/core/txpool/bundlepool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/txpool/locals"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -55,6 +56,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/remotedb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/shutdowncheck"
@@ -102,14 +104,17 @@ type Ethereum struct {
 	// core protocol objects
 	config         *ethconfig.Config
 	txPool         *txpool.TxPool
+	bundlePool     *bundlepool.BundlePool
 	localTxTracker *locals.TxTracker
+	privateTxs     *privateTxForwarder // Nil if no trusted endpoints are configured
 	blockchain     *core.BlockChain
 
 	handler *handler
 	discmix *enode.FairMix
 
 	// DB interfaces
-	chainDb ethdb.Database // Block chain database
+	chainDb ethdb.Database    // Block chain database
+	replica *remotedb.Replica // Nil unless the database is replicated from a primary node
 
 	eventMux       *event.TypeMux
 	engine         consensus.Engine
@@ -152,12 +157,31 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		log.Warn("Sanitizing invalid miner gas price", "provided", config.Miner.GasPrice, "updated", ethconfig.Defaults.Miner.GasPrice)
 		config.Miner.GasPrice = new(big.Int).Set(ethconfig.Defaults.Miner.GasPrice)
 	}
+	if err := config.Miner.Ordering.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid miner ordering policy: %v", err)
+	}
 
 	// Assemble the Ethereum object
 	chainDb, err := stack.OpenAndMergeDatabase(ChainData, ChainDBNamespace, false, config)
 	if err != nil {
 		return nil, err
 	}
+	var replica *remotedb.Replica
+	if config.DatabaseReplica != "" {
+		if rawdb.ReadReplicaStale(chainDb) {
+			return nil, remotedb.ErrReplicaStale
+		}
+		replica, err = remotedb.DialReplica(config.DatabaseReplica, chainDb, config.DatabaseReplicaCache)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial primary database: %v", err)
+		}
+		log.Info("Following primary database as read replica", "url", config.DatabaseReplica, "cache", config.DatabaseReplicaCache)
+		chainDb = replica
+	}
+	if config.DatabasePublish {
+		log.Info("Publishing database updates to read replicas")
+		chainDb = remotedb.NewPublisher(chainDb)
+	}
 	config.StateScheme, err = rawdb.ParseStateScheme(config.StateScheme, chainDb)
 	if err != nil {
 		return nil, err
@@ -258,6 +282,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		discmix:           enode.NewFairMix(0),
 		shutdownTracker:   shutdowncheck.NewShutdownTracker(chainDb),
 		stopCh:            make(chan struct{}),
+		replica:           replica,
 	}
 
 	eth.APIBackend = &EthAPIBackend{stack.Config().ExtRPCEnabled(), stack.Config().AllowUnprotectedTxs, eth, nil}
@@ -269,6 +294,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
+	if p, ok := eth.engine.(*parlia.Parlia); ok {
+		p.SetSnapshotRetention(config.ParliaSnapshotRetention)
+	}
 
 	bcVersion := rawdb.ReadDatabaseVersion(chainDb)
 	var dbVer = "<nil>"
@@ -313,10 +341,12 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			TriesInMemory:       config.TriesInMemory,
 			Preimages:           config.Preimages,
 			StateHistory:        config.StateHistory,
+			StateIndexing:       config.StateIndexing,
 			StateScheme:         config.StateScheme,
 			PathSyncFlush:       config.PathSyncFlush,
 			JournalFilePath:     journalFilePath,
 			JournalFile:         config.JournalFileEnabled,
+			Replica:             replica != nil,
 		}
 	)
 	if config.VMTrace != "" {
@@ -346,6 +376,11 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		log.Warn("The TxIndexer is disabled. Please note that the next time you re-enable it, it may affect the node performance because of rebuilding the tx index.")
 		txLookupLimit = nil
 	}
+	if replica != nil {
+		// The chain is processed and indexed by the primary node
+		cacheConfig.SnapshotNoBuild = true
+		txLookupLimit = nil
+	}
 	eth.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, config.Genesis, &overrides, eth.engine, vmConfig, shouldPreserve, txLookupLimit, bcOps...)
 	if err != nil {
 		return nil, err
@@ -360,6 +395,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
+	if config.TxPool.OverflowPoolJournal != "" {
+		config.TxPool.OverflowPoolJournal = stack.ResolvePath(config.TxPool.OverflowPoolJournal)
+	}
 	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
 
 	eth.txPool, err = txpool.New(config.TxPool.PriceLimit, eth.blockchain, []txpool.SubPool{legacyPool, blobPool})
@@ -376,6 +414,11 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		eth.localTxTracker = locals.New(config.TxPool.Journal, rejournal, eth.blockchain.Config(), eth.txPool)
 		stack.RegisterLifecycle(eth.localTxTracker)
 	}
+	if len(config.PrivateTxEndpoints) > 0 {
+		if eth.privateTxs, err = newPrivateTxForwarder(config.PrivateTxEndpoints); err != nil {
+			return nil, err
+		}
+	}
 	// Permit the downloader to use the trie cache allowance during fast sync
 	cacheLimit := cacheConfig.TrieCleanLimit + cacheConfig.TrieDirtyLimit + cacheConfig.SnapshotLimit
 	if eth.handler, err = newHandler(&handlerConfig{
@@ -399,10 +442,29 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		return nil, err
 	}
 
+	if config.Miner.Mev.Journal != "" {
+		config.Miner.Mev.Journal = stack.ResolvePath(config.Miner.Mev.Journal)
+	}
+	if config.Miner.Mev.BuilderStats != "" {
+		config.Miner.Mev.BuilderStats = stack.ResolvePath(config.Miner.Mev.BuilderStats)
+	}
 	eth.miner = miner.New(eth, &config.Miner, eth.EventMux(), eth.engine)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
 	eth.miner.SetPrioAddresses(config.TxPool.Locals)
 
+	eth.bundlePool = bundlepool.New(bundlepool.DefaultConfig, eth.blockchain)
+	eth.miner.SetBundlePool(eth.bundlePool)
+
+	if config.Miner.Bidder.Enabled {
+		wallet, err := eth.accountManager.Find(accounts.Account{Address: config.Miner.Bidder.Account})
+		if err != nil {
+			return nil, fmt.Errorf("bidder account missing: %v", err)
+		}
+		if err := eth.miner.StartBidder(wallet.SignData, wallet.SignTx); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create voteManager instance
 	if posa, ok := eth.engine.(consensus.PoSA); ok {
 		// Create votePool instance
@@ -421,14 +483,61 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		if stack.Config().EnableMaliciousVoteMonitor {
 			eth.handler.maliciousVoteMonitor = monitor.NewMaliciousVoteMonitor()
 			log.Info("Create MaliciousVoteMonitor successfully")
+
+			if submitter := stack.Config().MaliciousVoteSubmitter; submitter != "" {
+				if !common.IsHexAddress(submitter) {
+					return nil, fmt.Errorf("invalid malicious vote submitter: %s", submitter)
+				}
+				signTxFn := func(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+					wallet, err := eth.accountManager.Find(account)
+					if err != nil {
+						return nil, err
+					}
+					return wallet.SignTx(account, tx, chainID)
+				}
+				evidenceSubmitter, err := monitor.NewEvidenceSubmitter(chainDb, eth.txPool, chainConfig.ChainID,
+					config.Miner.GasPrice, common.HexToAddress(submitter), signTxFn)
+				if err != nil {
+					return nil, err
+				}
+				eth.handler.maliciousVoteMonitor.SetEvidenceSubmitter(evidenceSubmitter)
+				log.Info("Enable malicious vote evidence submission", "submitter", submitter)
+			}
 		}
 
 		if config.Miner.VoteEnable {
 			conf := stack.Config()
-			blsPasswordPath := stack.ResolvePath(conf.BLSPasswordFile)
-			blsWalletPath := stack.ResolvePath(conf.BLSWalletDir)
+			var (
+				voteSigner *vote.VoteSigner
+				err        error
+			)
+			if conf.BLSRemoteSigner != "" {
+				resolvePath := func(path string) string {
+					if path == "" {
+						return ""
+					}
+					return stack.ResolvePath(path)
+				}
+				voteSigner, err = vote.NewRemoteVoteSigner(&vote.RemoteSignerConfig{
+					URL:        conf.BLSRemoteSigner,
+					PubKey:     conf.BLSRemoteSignerPubKey,
+					ClientCert: resolvePath(conf.BLSRemoteSignerCert),
+					ClientKey:  resolvePath(conf.BLSRemoteSignerKey),
+					CACert:     resolvePath(conf.BLSRemoteSignerCA),
+					Insecure:   conf.BLSRemoteSignerInsecure,
+				})
+			} else {
+				blsPasswordPath := stack.ResolvePath(conf.BLSPasswordFile)
+				blsWalletPath := stack.ResolvePath(conf.BLSWalletDir)
+				voteSigner, err = vote.NewVoteSigner(blsPasswordPath, blsWalletPath)
+			}
+			if err != nil {
+				log.Error("Failed to Initialize voteSigner", "err", err)
+				return nil, err
+			}
+			log.Info("Create voteSigner successfully")
 			voteJournalPath := stack.ResolvePath(conf.VoteJournalDir)
-			if _, err := vote.NewVoteManager(eth, eth.blockchain, votePool, voteJournalPath, blsPasswordPath, blsWalletPath, posa); err != nil {
+			if _, err := vote.NewVoteManager(eth, eth.blockchain, chainDb, votePool, voteJournalPath, voteSigner, posa); err != nil {
 				log.Error("Failed to Initialize voteManager", "err", err)
 				return nil, err
 			}
@@ -476,11 +585,22 @@ func (s *Ethereum) APIs() []rpc.API {
 	// Append any APIs exposed explicitly by the consensus engine
 	apis = append(apis, s.engine.APIs(s.BlockChain())...)
 
+	// Append the vote pool APIs if the engine votes
+	if s.votePool != nil {
+		apis = append(apis, rpc.API{
+			Namespace: "vote",
+			Service:   vote.NewAPI(s.votePool),
+		})
+	}
+
 	// Append all the local APIs and return
 	return append(apis, []rpc.API{
 		{
 			Namespace: "eth",
 			Service:   NewEthereumAPI(s),
+		}, {
+			Namespace: "eth",
+			Service:   NewBundleAPI(s),
 		}, {
 			Namespace: "miner",
 			Service:   NewMinerAPI(s),
@@ -683,6 +803,9 @@ func (s *Ethereum) handleAdditions(parlia *parlia.Parlia, nonce uint64, register
 // is already running, this method adjust the number of threads allowed to use
 // and updates the minimum price required by the transaction pool.
 func (s *Ethereum) StartMining() error {
+	if s.replica != nil {
+		return errors.New("mining is disabled in replica mode")
+	}
 	// If the miner was not running, initialize it
 	if !s.IsMining() {
 		// Propagate the initial price point to the transaction pool
@@ -754,6 +877,10 @@ func (s *Ethereum) SyncMode() downloader.SyncMode {
 // Protocols returns all the currently configured
 // network protocols to start.
 func (s *Ethereum) Protocols() []p2p.Protocol {
+	// A replica follows the chain of the primary node, without syncing it
+	if s.replica != nil {
+		return nil
+	}
 	protos := eth.MakeProtocols((*ethHandler)(s.handler), s.networkID, s.discmix)
 	if !s.config.DisableSnapProtocol && s.config.SnapshotCache > 0 {
 		protos = append(protos, snap.MakeProtocols((*snapHandler)(s.handler))...)
@@ -778,10 +905,28 @@ func (s *Ethereum) Start() error {
 	// Start the networking layer
 	s.handler.Start(s.p2pServer.MaxPeers, s.p2pServer.MaxPeersPerIP)
 
+	if s.replica != nil {
+		go s.replicaHeadLoop()
+	}
 	go s.reportRecentBlocksLoop()
 	return nil
 }
 
+// replicaHeadLoop reloads the chain head whenever the head block replicated
+// from the primary node changes.
+func (s *Ethereum) replicaHeadLoop() {
+	for {
+		select {
+		case <-s.replica.Heads():
+			if err := s.blockchain.ReloadHead(); err != nil {
+				log.Warn("Failed to reload replicated chain head", "err", err)
+			}
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
 func (s *Ethereum) setupDiscovery() error {
 	eth.StartENRUpdater(s.blockchain, s.p2pServer.LocalNode())
 
@@ -837,6 +982,10 @@ func (s *Ethereum) Stop() error {
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
 	s.txPool.Close()
+	s.bundlePool.Close()
+	if s.privateTxs != nil {
+		s.privateTxs.close()
+	}
 	s.miner.Close()
 	s.blockchain.Stop()
 	s.engine.Close()
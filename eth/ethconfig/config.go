@@ -50,29 +50,30 @@ var FullNodeGPO = gasprice.Config{
 
 // Defaults contains default settings for use on the BSC main net.
 var Defaults = Config{
-	SyncMode:            SnapSync,
-	NetworkId:           0, // enable auto configuration of networkID == chainID
-	TxLookupLimit:       2350000,
-	TransactionHistory:  2350000,
-	BlockHistory:        0,
-	StateHistory:        params.FullImmutabilityThreshold,
-	DatabaseCache:       512,
-	EnableSharedStorage: false,
-	TrieCleanCache:      154,
-	TrieDirtyCache:      256,
-	TrieTimeout:         10 * time.Minute,
-	TriesInMemory:       128,
-	TriesVerifyMode:     core.LocalVerify,
-	SnapshotCache:       102,
-	FilterLogCacheSize:  32,
-	Miner:               minerconfig.DefaultConfig,
-	TxPool:              legacypool.DefaultConfig,
-	BlobPool:            blobpool.DefaultConfig,
-	RPCGasCap:           50000000,
-	RPCEVMTimeout:       5 * time.Second,
-	GPO:                 FullNodeGPO,
-	RPCTxFeeCap:         1,                                         // 1 ether
-	BlobExtraReserve:    params.DefaultExtraReserveForBlobRequests, // Extra reserve threshold for blob, blob never expires when -1 is set, default 28800
+	SyncMode:             SnapSync,
+	NetworkId:            0, // enable auto configuration of networkID == chainID
+	TxLookupLimit:        2350000,
+	TransactionHistory:   2350000,
+	BlockHistory:         0,
+	StateHistory:         params.FullImmutabilityThreshold,
+	DatabaseCache:        512,
+	DatabaseReplicaCache: 100000,
+	EnableSharedStorage:  false,
+	TrieCleanCache:       154,
+	TrieDirtyCache:       256,
+	TrieTimeout:          10 * time.Minute,
+	TriesInMemory:        128,
+	TriesVerifyMode:      core.LocalVerify,
+	SnapshotCache:        102,
+	FilterLogCacheSize:   32,
+	Miner:                minerconfig.DefaultConfig,
+	TxPool:               legacypool.DefaultConfig,
+	BlobPool:             blobpool.DefaultConfig,
+	RPCGasCap:            50000000,
+	RPCEVMTimeout:        5 * time.Second,
+	GPO:                  FullNodeGPO,
+	RPCTxFeeCap:          1,                                         // 1 ether
+	BlobExtraReserve:     params.DefaultExtraReserveForBlobRequests, // Extra reserve threshold for blob, blob never expires when -1 is set, default 28800
 }
 
 //go:generate go run github.com/fjl/gencodec -type Config -formats toml -out gen_config.go
@@ -117,6 +118,7 @@ type Config struct {
 	TransactionHistory uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
 	BlockHistory       uint64 `toml:",omitempty"` // The maximum number of blocks from head whose block body/header/receipt/diff/hash are reserved.
 	StateHistory       uint64 `toml:",omitempty"` // The maximum number of blocks from head whose state histories are reserved.
+	StateIndexing      bool   `toml:",omitempty"` // Whether to index the state histories for serving historic states (path scheme only).
 	// State scheme represents the scheme used to store ethereum states and trie
 	// nodes on top. It can be 'hash', 'path', or none which means use the scheme
 	// consistent with persistent state.
@@ -136,6 +138,13 @@ type Config struct {
 	DatabaseHandles    int  `toml:"-"`
 	DatabaseCache      int
 	DatabaseFreezer    string
+
+	// Database replication options, a read replica follows the database of a
+	// primary node publishing its updates.
+	DatabasePublish      bool   // Whether to publish the database updates to read replicas
+	DatabaseReplica      string // URL of the primary node to replicate the database from
+	DatabaseReplicaCache int    // Number of database entries cached by the read replica
+
 	// PruneAncientData is an optional config and disabled by default, and usually you do not need it.
 	// When this flag is enabled, only keep the latest 9w blocks' data, the older blocks' data will be
 	// pruned instead of being dumped to freezerdb, the pruned data includes CanonicalHash, Header, Block,
@@ -155,6 +164,9 @@ type Config struct {
 	TriesVerifyMode     core.VerifyMode
 	Preimages           bool
 
+	// Number of parlia checkpoint snapshots kept on disk, zero keeps them all.
+	ParliaSnapshotRetention uint64
+
 	// This is the number of blocks for which logs will be cached in the filter system.
 	FilterLogCacheSize int
 
@@ -185,6 +197,10 @@ type Config struct {
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64
 
+	// PrivateTxEndpoints are the RPC endpoints of the trusted validators or EVN
+	// nodes the private transactions are forwarded to.
+	PrivateTxEndpoints []string `toml:",omitempty"`
+
 	// OverridePassedForkTime
 	OverridePassedForkTime *uint64 `toml:",omitempty"`
 
@@ -35,6 +35,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		TransactionHistory      uint64 `toml:",omitempty"`
 		BlockHistory            uint64 `toml:",omitempty"`
 		StateHistory            uint64 `toml:",omitempty"`
+		StateIndexing           bool   `toml:",omitempty"`
 		StateScheme             string `toml:",omitempty"`
 		PathSyncFlush           bool   `toml:",omitempty"`
 		JournalFileEnabled      bool
@@ -44,6 +45,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		DatabaseHandles         int                    `toml:"-"`
 		DatabaseCache           int
 		DatabaseFreezer         string
+		DatabasePublish         bool
+		DatabaseReplica         string
+		DatabaseReplicaCache    int
 		PruneAncientData        bool
 		TrieCleanCache          int
 		TrieDirtyCache          int
@@ -52,6 +56,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		TriesInMemory           uint64
 		TriesVerifyMode         core.VerifyMode
 		Preimages               bool
+		ParliaSnapshotRetention uint64
 		FilterLogCacheSize      int
 		Miner                   minerconfig.Config
 		TxPool                  legacypool.Config
@@ -63,11 +68,12 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		RPCGasCap               uint64
 		RPCEVMTimeout           time.Duration
 		RPCTxFeeCap             float64
-		OverridePassedForkTime  *uint64 `toml:",omitempty"`
-		OverrideLorentz         *uint64 `toml:",omitempty"`
-		OverrideMaxwell         *uint64 `toml:",omitempty"`
-		OverrideFermi           *uint64 `toml:",omitempty"`
-		OverrideVerkle          *uint64 `toml:",omitempty"`
+		PrivateTxEndpoints      []string `toml:",omitempty"`
+		OverridePassedForkTime  *uint64  `toml:",omitempty"`
+		OverrideLorentz         *uint64  `toml:",omitempty"`
+		OverrideMaxwell         *uint64  `toml:",omitempty"`
+		OverrideFermi           *uint64  `toml:",omitempty"`
+		OverrideVerkle          *uint64  `toml:",omitempty"`
 		BlobExtraReserve        uint64
 	}
 	var enc Config
@@ -89,6 +95,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.TransactionHistory = c.TransactionHistory
 	enc.BlockHistory = c.BlockHistory
 	enc.StateHistory = c.StateHistory
+	enc.StateIndexing = c.StateIndexing
 	enc.StateScheme = c.StateScheme
 	enc.PathSyncFlush = c.PathSyncFlush
 	enc.JournalFileEnabled = c.JournalFileEnabled
@@ -98,6 +105,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.DatabaseHandles = c.DatabaseHandles
 	enc.DatabaseCache = c.DatabaseCache
 	enc.DatabaseFreezer = c.DatabaseFreezer
+	enc.DatabasePublish = c.DatabasePublish
+	enc.DatabaseReplica = c.DatabaseReplica
+	enc.DatabaseReplicaCache = c.DatabaseReplicaCache
 	enc.PruneAncientData = c.PruneAncientData
 	enc.TrieCleanCache = c.TrieCleanCache
 	enc.TrieDirtyCache = c.TrieDirtyCache
@@ -106,6 +116,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.TriesInMemory = c.TriesInMemory
 	enc.TriesVerifyMode = c.TriesVerifyMode
 	enc.Preimages = c.Preimages
+	enc.ParliaSnapshotRetention = c.ParliaSnapshotRetention
 	enc.FilterLogCacheSize = c.FilterLogCacheSize
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
@@ -117,6 +128,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RPCGasCap = c.RPCGasCap
 	enc.RPCEVMTimeout = c.RPCEVMTimeout
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
+	enc.PrivateTxEndpoints = c.PrivateTxEndpoints
 	enc.OverridePassedForkTime = c.OverridePassedForkTime
 	enc.OverrideLorentz = c.OverrideLorentz
 	enc.OverrideMaxwell = c.OverrideMaxwell
@@ -147,6 +159,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		TransactionHistory      *uint64 `toml:",omitempty"`
 		BlockHistory            *uint64 `toml:",omitempty"`
 		StateHistory            *uint64 `toml:",omitempty"`
+		StateIndexing           *bool   `toml:",omitempty"`
 		StateScheme             *string `toml:",omitempty"`
 		PathSyncFlush           *bool   `toml:",omitempty"`
 		JournalFileEnabled      *bool
@@ -156,6 +169,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		DatabaseHandles         *int                   `toml:"-"`
 		DatabaseCache           *int
 		DatabaseFreezer         *string
+		DatabasePublish         *bool
+		DatabaseReplica         *string
+		DatabaseReplicaCache    *int
 		PruneAncientData        *bool
 		TrieCleanCache          *int
 		TrieDirtyCache          *int
@@ -164,6 +180,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		TriesInMemory           *uint64
 		TriesVerifyMode         *core.VerifyMode
 		Preimages               *bool
+		ParliaSnapshotRetention *uint64
 		FilterLogCacheSize      *int
 		Miner                   *minerconfig.Config
 		TxPool                  *legacypool.Config
@@ -175,11 +192,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		RPCGasCap               *uint64
 		RPCEVMTimeout           *time.Duration
 		RPCTxFeeCap             *float64
-		OverridePassedForkTime  *uint64 `toml:",omitempty"`
-		OverrideLorentz         *uint64 `toml:",omitempty"`
-		OverrideMaxwell         *uint64 `toml:",omitempty"`
-		OverrideFermi           *uint64 `toml:",omitempty"`
-		OverrideVerkle          *uint64 `toml:",omitempty"`
+		PrivateTxEndpoints      []string `toml:",omitempty"`
+		OverridePassedForkTime  *uint64  `toml:",omitempty"`
+		OverrideLorentz         *uint64  `toml:",omitempty"`
+		OverrideMaxwell         *uint64  `toml:",omitempty"`
+		OverrideFermi           *uint64  `toml:",omitempty"`
+		OverrideVerkle          *uint64  `toml:",omitempty"`
 		BlobExtraReserve        *uint64
 	}
 	var dec Config
@@ -240,6 +258,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.StateHistory != nil {
 		c.StateHistory = *dec.StateHistory
 	}
+	if dec.StateIndexing != nil {
+		c.StateIndexing = *dec.StateIndexing
+	}
 	if dec.StateScheme != nil {
 		c.StateScheme = *dec.StateScheme
 	}
@@ -267,6 +288,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.DatabaseFreezer != nil {
 		c.DatabaseFreezer = *dec.DatabaseFreezer
 	}
+	if dec.DatabasePublish != nil {
+		c.DatabasePublish = *dec.DatabasePublish
+	}
+	if dec.DatabaseReplica != nil {
+		c.DatabaseReplica = *dec.DatabaseReplica
+	}
+	if dec.DatabaseReplicaCache != nil {
+		c.DatabaseReplicaCache = *dec.DatabaseReplicaCache
+	}
 	if dec.PruneAncientData != nil {
 		c.PruneAncientData = *dec.PruneAncientData
 	}
@@ -291,6 +321,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.Preimages != nil {
 		c.Preimages = *dec.Preimages
 	}
+	if dec.ParliaSnapshotRetention != nil {
+		c.ParliaSnapshotRetention = *dec.ParliaSnapshotRetention
+	}
 	if dec.FilterLogCacheSize != nil {
 		c.FilterLogCacheSize = *dec.FilterLogCacheSize
 	}
@@ -324,6 +357,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RPCTxFeeCap != nil {
 		c.RPCTxFeeCap = *dec.RPCTxFeeCap
 	}
+	if dec.PrivateTxEndpoints != nil {
+		c.PrivateTxEndpoints = dec.PrivateTxEndpoints
+	}
 	if dec.OverridePassedForkTime != nil {
 		c.OverridePassedForkTime = dec.OverridePassedForkTime
 	}
@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/gopool"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -255,6 +256,78 @@ func (api *FilterAPI) NewVotes(ctx context.Context) (*rpc.Subscription, error) {
 	return rpcSub, nil
 }
 
+// TxPoolEventsCriteria selects the transaction pool events to stream.
+type TxPoolEventsCriteria struct {
+	From []common.Address `json:"from"` // Senders of the transactions, all of them if empty
+}
+
+// RPCTxPoolEvent is a change in the lifecycle of a pooled transaction.
+type RPCTxPoolEvent struct {
+	Kind       txpool.TxEventKind   `json:"kind"`
+	Reason     txpool.TxEventReason `json:"reason,omitempty"`
+	Hash       common.Hash          `json:"hash"`
+	From       common.Address       `json:"from"`
+	Nonce      hexutil.Uint64       `json:"nonce"`
+	ReplacedBy *common.Hash         `json:"replacedBy,omitempty"`
+}
+
+// TxPoolEvents creates a subscription that is triggered each time a transaction
+// is added to the pool, changes state in it or leaves it, with the reason of the
+// demotions and drops. The private transactions are not reported.
+func (api *FilterAPI) TxPoolEvents(ctx context.Context, crit *TxPoolEventsCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	var senders map[common.Address]struct{}
+	if crit != nil && len(crit.From) > 0 {
+		senders = make(map[common.Address]struct{}, len(crit.From))
+		for _, addr := range crit.From {
+			senders[addr] = struct{}{}
+		}
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	gopool.Submit(func() {
+		events := make(chan []*txpool.TxEvent, txChanSize)
+		eventSub := api.sys.backend.SubscribeTxPoolEvents(events)
+		defer eventSub.Unsubscribe()
+
+		for {
+			select {
+			case batch := <-events:
+				for _, ev := range batch {
+					if ev.Private {
+						continue
+					}
+					if senders != nil {
+						if _, ok := senders[ev.From]; !ok {
+							continue
+						}
+					}
+					rpcEv := &RPCTxPoolEvent{
+						Kind:   ev.Kind,
+						Reason: ev.Reason,
+						Hash:   ev.Hash,
+						From:   ev.From,
+						Nonce:  hexutil.Uint64(ev.Nonce),
+					}
+					if ev.ReplacedBy != (common.Hash{}) {
+						rpcEv.ReplacedBy = &ev.ReplacedBy
+					}
+					notifier.Notify(rpcSub.ID, rpcEv)
+				}
+			case <-rpcSub.Err():
+				return
+			case <-eventSub.Err():
+				return
+			}
+		}
+	})
+
+	return rpcSub, nil
+}
+
 // NewBlockFilter creates a filter that fetches blocks that are imported into the chain.
 // It is part of the filter package since polling goes with eth_getFilterChanges.
 func (api *FilterAPI) NewBlockFilter() rpc.ID {
@@ -57,6 +57,10 @@ const (
 	// voteChanSize is the size of channel listening to NewVotesEvent.
 	voteChanSize = 256
 
+	// evidenceResubmitInterval is the interval to retry the malicious vote evidence
+	// that failed to be submitted.
+	evidenceResubmitInterval = time.Minute
+
 	// deltaTdThreshold is the threshold of TD difference for peers to broadcast votes.
 	deltaTdThreshold = 20
 
@@ -735,11 +739,16 @@ func (h *handler) startMaliciousVoteMonitor() {
 	defer h.wg.Done()
 	voteCh := make(chan core.NewVoteEvent, voteChanSize)
 	h.voteMonitorSub = h.votepool.SubscribeNewVoteEvent(voteCh)
+	resubmitTicker := time.NewTicker(evidenceResubmitInterval)
+	defer resubmitTicker.Stop()
 	for {
 		select {
 		case event := <-voteCh:
 			pendingBlockNumber := h.chain.CurrentHeader().Number.Uint64() + 1
 			h.maliciousVoteMonitor.ConflictDetect(event.Vote, pendingBlockNumber)
+		case <-resubmitTicker.C:
+			pendingBlockNumber := h.chain.CurrentHeader().Number.Uint64() + 1
+			h.maliciousVoteMonitor.ResubmitPending(pendingBlockNumber)
 		case <-h.voteMonitorSub.Err():
 			return
 		case <-h.stopCh:
@@ -884,6 +893,7 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 	var (
 		blobTxs  int // Number of blob transactions to announce only
 		largeTxs int // Number of large transactions to announce only
+		condTxs  int // Number of conditional transactions also sent to EVN peers
 
 		directCount int // Number of transactions sent directly to peers (duplicates included)
 		annCount    int // Number of transactions announced across all peers (duplicates included)
@@ -904,6 +914,20 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 		hash   = make([]byte, 32)
 	)
 	for _, tx := range txs {
+		// Private transactions are never announced
+		if tx.Private() != nil {
+			continue
+		}
+		// Conditional transactions are only sent directly to the EVN peers,
+		// never gossiped publicly: the conditions are not part of the encoding,
+		// so any other pool would include them unconditionally.
+		if tx.Options() != nil {
+			condTxs++
+			for _, peer := range h.peers.evnPeersWithoutTransaction(tx.Hash()) {
+				txset[peer] = append(txset[peer], tx.Hash())
+			}
+			continue
+		}
 		var maybeDirect bool
 		switch {
 		case tx.Type() == types.BlobTxType:
@@ -950,8 +974,8 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 		annCount += len(hashes)
 		peer.AsyncSendPooledTransactionHashes(hashes)
 	}
-	log.Debug("Distributed transactions", "plaintxs", len(txs)-blobTxs-largeTxs, "blobtxs", blobTxs, "largetxs", largeTxs,
-		"bcastpeers", len(txset), "bcastcount", directCount, "annpeers", len(annos), "anncount", annCount)
+	log.Debug("Distributed transactions", "plaintxs", len(txs)-blobTxs-largeTxs-condTxs, "blobtxs", blobTxs, "largetxs", largeTxs,
+		"condtxs", condTxs, "bcastpeers", len(txset), "bcastcount", directCount, "annpeers", len(annos), "anncount", annCount)
 }
 
 // ReannounceTransactions will announce a batch of local pending transactions
@@ -959,8 +983,14 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 func (h *handler) ReannounceTransactions(txs types.Transactions) {
 	hashes := make([]common.Hash, 0, txs.Len())
 	for _, tx := range txs {
+		if tx.Private() != nil || tx.Options() != nil {
+			continue
+		}
 		hashes = append(hashes, tx.Hash())
 	}
+	if len(hashes) == 0 {
+		return
+	}
 
 	// Announce transactions hash to a batch of peers
 	peersCount := uint(math.Sqrt(float64(h.peers.len())))
@@ -447,6 +447,21 @@ func (ps *peerSet) peersWithoutTransaction(hash common.Hash) []*ethPeer {
 	return list
 }
 
+// evnPeersWithoutTransaction retrieves a list of EVN peers that do not have a
+// given transaction in their set of known hashes.
+func (ps *peerSet) evnPeersWithoutTransaction(hash common.Hash) []*ethPeer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	list := make([]*ethPeer, 0, len(ps.peers))
+	for _, p := range ps.peers {
+		if p.EVNPeerFlag.Load() && !p.KnownTransaction(hash) {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
 // peersWithoutVote retrieves a list of peers that do not have a given
 // vote in their set of known hashes.
 func (ps *peerSet) peersWithoutVote(hash common.Hash) []*ethPeer {
@@ -0,0 +1,100 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// privateTxForwardTimeout bounds the forwarding of a private transaction to an
+// endpoint.
+const privateTxForwardTimeout = 5 * time.Second
+
+var (
+	privateTxForwardedMeter = metrics.NewRegisteredMeter("eth/privatetx/forwarded", nil)
+	privateTxFailedMeter    = metrics.NewRegisteredMeter("eth/privatetx/failed", nil)
+)
+
+// privateTxForwarder sends the private transactions to the trusted validators
+// or EVN nodes, which keep them out of the gossip as well.
+type privateTxForwarder struct {
+	urls    []string
+	clients []*rpc.Client
+	wg      sync.WaitGroup
+}
+
+// newPrivateTxForwarder dials the trusted endpoints.
+func newPrivateTxForwarder(urls []string) (*privateTxForwarder, error) {
+	f := &privateTxForwarder{urls: urls}
+	for _, url := range urls {
+		client, err := rpc.DialContext(context.Background(), url)
+		if err != nil {
+			f.close()
+			return nil, fmt.Errorf("failed to dial private transaction endpoint %s: %v", url, err)
+		}
+		f.clients = append(f.clients, client)
+	}
+	return f, nil
+}
+
+// forward sends the private transaction to all the endpoints in the background.
+// The endpoints are never asked to publish the transaction once expired, the
+// local pool is in charge of it.
+func (f *privateTxForwarder) forward(tx *types.Transaction, opts *types.PrivateOpts) {
+	blob, err := tx.MarshalBinary()
+	if err != nil {
+		log.Error("Failed to encode private transaction", "hash", tx.Hash(), "err", err)
+		return
+	}
+	args := map[string]interface{}{
+		"maxBlockNumber": hexutil.Uint64(opts.MaxBlockNumber),
+		"fallback":       false,
+	}
+	for i, client := range f.clients {
+		f.wg.Add(1)
+		go func(url string, client *rpc.Client) {
+			defer f.wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), privateTxForwardTimeout)
+			defer cancel()
+
+			if err := client.CallContext(ctx, nil, "eth_sendPrivateRawTransaction", hexutil.Bytes(blob), args); err != nil {
+				privateTxFailedMeter.Mark(1)
+				log.Debug("Failed to forward private transaction", "hash", tx.Hash(), "endpoint", url, "err", err)
+				return
+			}
+			privateTxForwardedMeter.Mark(1)
+		}(f.urls[i], client)
+	}
+}
+
+// close waits for the pending forwards and closes the connections.
+func (f *privateTxForwarder) close() {
+	f.wg.Wait()
+	for _, client := range f.clients {
+		client.Close()
+	}
+}
@@ -451,9 +451,9 @@ func answerGetPooledTransactions(backend Backend, query GetPooledTransactionsReq
 		if bytes >= softResponseLimit {
 			break
 		}
-		// Retrieve the requested transaction, skipping if unknown to us
+		// Retrieve the requested transaction, skipping if unknown to us or private
 		tx := backend.TxPool().Get(hash)
-		if tx == nil {
+		if tx == nil || tx.Private() != nil {
 			continue
 		}
 		// If known, encode and queue for response packet
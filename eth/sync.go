@@ -39,6 +39,9 @@ func (h *handler) syncTransactions(p *eth.Peer) {
 	var hashes []common.Hash
 	for _, batch := range h.txpool.Pending(txpool.PendingFilter{OnlyPlainTxs: true}) {
 		for _, tx := range batch {
+			if tx.Tx != nil && tx.Tx.Private() != nil {
+				continue
+			}
 			hashes = append(hashes, tx.Hash)
 		}
 	}
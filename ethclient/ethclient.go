@@ -786,6 +786,13 @@ func (ec *Client) HasBuilder(ctx context.Context, address common.Address) (bool,
 	return result, err
 }
 
+// BuilderStats returns the scores of the builders
+func (ec *Client) BuilderStats(ctx context.Context) ([]*types.BuilderStats, error) {
+	var result []*types.BuilderStats
+	err := ec.c.CallContext(ctx, &result, "mev_getBuilderStats")
+	return result, err
+}
+
 // SendBid sends a bid
 func (ec *Client) SendBid(ctx context.Context, args types.BidArgs) (common.Hash, error) {
 	var hash common.Hash
@@ -0,0 +1,631 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulated
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	cmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/consensus/parlia"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/forkid"
+	"github.com/ethereum/go-ethereum/core/systemcontracts"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// parliaTimeBudget is how far in the past the simulated chain starts at
+	// least. Blocks are produced back to back until their time catches up with
+	// the wall clock, from then on the chain waits for the block interval as a
+	// real network. It leaves room to skip a day to the next breathe block.
+	parliaTimeBudget = 26 * time.Hour
+
+	// parliaVoteHistory is the number of blocks the votes are kept for.
+	parliaVoteHistory = 256
+
+	// parliaGasLimit is the gas limit of the genesis block.
+	parliaGasLimit = 100_000_000
+)
+
+var (
+	// The system contracts called by the simulated network.
+	validatorSet = common.HexToAddress(systemcontracts.ValidatorContract)
+	stakeHub     = common.HexToAddress(systemcontracts.StakeHubContract)
+
+	errNoProposer   = errors.New("no validator is able to propose the block")
+	errFutureBlock  = errors.New("block time in the future")
+	errBackwardTime = errors.New("block time before its parent")
+)
+
+// ParliaConfig is the configuration of the validators of a simulated Parlia network.
+type ParliaConfig struct {
+	Validators int   // Number of validators registered to the stake hub, 3 if zero
+	MaxElected int   // Maximum number of validators elected, all of them if zero
+	TurnLength uint8 // Number of consecutive blocks proposed by a validator, 1 or from 3 to 9, 1 if zero
+}
+
+// ParliaValidator is a validator of the simulated Parlia network.
+type ParliaValidator struct {
+	Address     common.Address
+	VoteAddress types.BLSPublicKey
+
+	key     *ecdsa.PrivateKey
+	voteKey bls.SecretKey
+	offline bool
+}
+
+// ParliaBackend is a simulated network of Parlia validators running in memory.
+// The validators propose the blocks in turn, and the online ones vote on every
+// block so that the chain is fast finalized. Blocks are produced back to back
+// on Commit instead of waiting for the block interval.
+//
+// The system contracts are the genesis contracts of the Chapel testnet, upgraded
+// to the forks enabled at genesis, so the rewards and slashes are accounted as on
+// a real network. The validators are registered to the stake hub with the same
+// self delegation, and the validator set is elected by voting power at the breathe
+// blocks, the first one being the first block. The election is recorded by the
+// validator contract right away, the proposers switch on the next epoch block.
+type ParliaBackend struct {
+	node   *node.Node
+	eth    *eth.Ethereum
+	engine *parlia.Parlia
+	api    *parlia.API
+	client simClient
+	admin  *ecdsa.PrivateKey
+
+	validators []*ParliaValidator
+	votes      map[common.Hash][]*types.VoteEnvelope // Votes of the validators by block hash
+	lock       sync.Mutex
+}
+
+// NewParliaBackend creates a simulated Parlia network with the given validators,
+// which can be used as a backend for contract bindings in unit tests.
+//
+// The simulated network uses chainID 2 and activates the forks up to Pascal at
+// genesis.
+func NewParliaBackend(alloc types.GenesisAlloc, config ParliaConfig, options ...func(nodeConf *node.Config, ethConf *ethconfig.Config)) (*ParliaBackend, error) {
+	if config.Validators == 0 {
+		config.Validators = 3
+	}
+	if config.MaxElected == 0 || config.MaxElected > config.Validators {
+		config.MaxElected = config.Validators
+	}
+	if config.TurnLength == 0 {
+		config.TurnLength = 1
+	}
+	b := &ParliaBackend{votes: make(map[common.Hash][]*types.VoteEnvelope)}
+
+	var err error
+	if b.admin, err = crypto.GenerateKey(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < config.Validators; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		voteKey, err := bls.RandKey()
+		if err != nil {
+			return nil, err
+		}
+		b.validators = append(b.validators, &ParliaValidator{
+			Address:     crypto.PubkeyToAddress(key.PublicKey),
+			VoteAddress: types.BLSPublicKey(voteKey.PublicKey().Marshal()),
+			key:         key,
+			voteKey:     voteKey,
+		})
+	}
+	sort.Slice(b.validators, func(i, j int) bool {
+		return bytes.Compare(b.validators[i].Address[:], b.validators[j].Address[:]) < 0
+	})
+
+	// Create the default configurations for the outer node shell and the Ethereum
+	// service to mutate with the options afterwards
+	nodeConf := node.DefaultConfig
+	nodeConf.DataDir = ""
+	nodeConf.P2P = p2p.Config{NoDiscovery: true}
+
+	ethConf := ethconfig.Defaults
+	if ethConf.Genesis, err = b.genesis(alloc, config); err != nil {
+		return nil, err
+	}
+	ethConf.SyncMode = ethconfig.FullSync
+	ethConf.TxPool.NoLocals = true
+
+	for _, option := range options {
+		option(&nodeConf, &ethConf)
+	}
+	stack, err := node.New(&nodeConf)
+	if err != nil {
+		return nil, err
+	}
+	if b.eth, err = eth.New(stack, &ethConf); err != nil {
+		stack.Close()
+		return nil, err
+	}
+	filterSystem := filters.NewFilterSystem(b.eth.APIBackend, filters.Config{})
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: "eth",
+		Service:   filters.NewFilterAPI(filterSystem, false),
+	}})
+	if err := stack.Start(); err != nil {
+		stack.Close()
+		return nil, err
+	}
+	b.node = stack
+	b.engine = b.eth.Engine().(*parlia.Parlia)
+	for _, api := range b.engine.APIs(b.eth.BlockChain()) {
+		if service, ok := api.Service.(*parlia.API); ok {
+			b.api = service
+		}
+	}
+	b.client = simClient{ethclient.NewClient(stack.Attach())}
+	return b, nil
+}
+
+// genesis returns the genesis of the network, with the elected validators voting
+// from the start. The genesis is timed a second before midnight in UTC for the
+// first block to be a breathe block.
+func (b *ParliaBackend) genesis(alloc types.GenesisAlloc, config ParliaConfig) (*core.Genesis, error) {
+	chainConfig := *params.ParliaTestChainConfig
+	chainConfig.HaberTime = new(uint64)
+	chainConfig.HaberFixTime = new(uint64)
+	chainConfig.BohrTime = new(uint64)
+	chainConfig.PascalTime = new(uint64)
+	chainConfig.PragueTime = new(uint64)
+	chainConfig.BlobScheduleConfig = &params.BlobScheduleConfig{
+		Cancun: params.DefaultCancunBlobConfig,
+		Prague: params.DefaultPragueBlobConfigBSC,
+	}
+
+	start := uint64(time.Now().Add(-parliaTimeBudget).Unix())
+	start = start - start%params.BreatheBlockInterval - 1
+
+	genesisAlloc, err := systemContracts(&chainConfig, start, b.validators, config)
+	if err != nil {
+		return nil, err
+	}
+	for addr, account := range alloc {
+		genesisAlloc[addr] = account
+	}
+	genesisAlloc[params.HistoryStorageAddress] = types.Account{Nonce: 1, Code: params.HistoryStorageCode, Balance: common.Big0}
+	genesisAlloc[crypto.PubkeyToAddress(b.admin.PublicKey)] = types.Account{Balance: new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(1_000_000))}
+
+	// The validators have the same voting power, the engine elects them in the
+	// order of their checksummed addresses.
+	elected := make([]*ParliaValidator, len(b.validators))
+	copy(elected, b.validators)
+	sort.Slice(elected, func(i, j int) bool { return elected[i].Address.Hex() < elected[j].Address.Hex() })
+	elected = elected[:config.MaxElected]
+	sort.Slice(elected, func(i, j int) bool { return bytes.Compare(elected[i].Address[:], elected[j].Address[:]) < 0 })
+
+	extra := make([]byte, 32, 32+1+len(elected)*(common.AddressLength+types.BLSPublicKeyLength)+1+65)
+	extra = append(extra, byte(len(elected)))
+	for _, val := range elected {
+		extra = append(extra, val.Address.Bytes()...)
+		extra = append(extra, val.VoteAddress.Bytes()...)
+	}
+	extra = append(extra, config.TurnLength)
+	extra = append(extra, make([]byte, 65)...)
+
+	return &core.Genesis{
+		Config:     &chainConfig,
+		Timestamp:  start,
+		ExtraData:  extra,
+		GasLimit:   parliaGasLimit,
+		Difficulty: big.NewInt(1),
+		Alloc:      genesisAlloc,
+	}, nil
+}
+
+// Close shuts down the simulated network, which can't be used afterwards.
+func (b *ParliaBackend) Close() error {
+	if b.client.Client != nil {
+		b.client.Close()
+		b.client = simClient{}
+	}
+	var err error
+	if b.node != nil {
+		err = b.node.Close()
+		b.node = nil
+	}
+	return err
+}
+
+// Client returns a client that accesses the simulated network.
+func (b *ParliaBackend) Client() Client {
+	return b.client
+}
+
+// Validators returns all the validators of the network, in ascending order of
+// their addresses.
+func (b *ParliaBackend) Validators() []*ParliaValidator {
+	return b.validators
+}
+
+// SetOnline switches the validator at the given index online or offline. An
+// offline validator neither proposes blocks nor votes.
+func (b *ParliaBackend) SetOnline(index int, online bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.validators[index].offline = !online
+}
+
+// Delegate delegates the amount to the validator at the given index from a funded
+// account. The voting power of the validator is increased for the election of the
+// next breathe block.
+func (b *ParliaBackend) Delegate(index int, amount *big.Int) error {
+	if index < 0 || index >= len(b.validators) {
+		return fmt.Errorf("unknown validator %d", index)
+	}
+	return b.transact(stakeHub, amount, append(selector("delegate(address,bool)"), abiEncode([]string{"address", "bool"}, b.validators[index].Address, false)...))
+}
+
+// transact sends a transaction from the funded account.
+func (b *ParliaBackend) transact(to common.Address, value *big.Int, data []byte) error {
+	ctx := context.Background()
+	from := crypto.PubkeyToAddress(b.admin.PublicKey)
+	nonce, err := b.client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return err
+	}
+	gasPrice, err := b.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return err
+	}
+	gas, err := b.client.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &to, Value: value, Data: data})
+	if err != nil {
+		return err
+	}
+	// The system contracts account per day, leave room for the transaction to be
+	// included on another day than estimated.
+	gas += gas / 2
+	tx, err := types.SignNewTx(b.admin, types.LatestSigner(b.eth.BlockChain().Config()), &types.LegacyTx{
+		Nonce:    nonce,
+		To:       &to,
+		Value:    value,
+		Gas:      gas,
+		GasPrice: gasPrice,
+		Data:     data,
+	})
+	if err != nil {
+		return err
+	}
+	return b.client.SendTransaction(ctx, tx)
+}
+
+// Votes returns the votes of the validators for the block.
+func (b *ParliaBackend) Votes(hash common.Hash) []*types.VoteEnvelope {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.votes[hash]
+}
+
+// Commit lets the validator whose turn it is propose a block with the pending
+// transactions, or the online validator with the shortest back-off time if it
+// is offline. The online validators vote on the block afterwards.
+func (b *ParliaBackend) Commit() (common.Hash, error) {
+	return b.commit(0)
+}
+
+// AdjustTime commits a block shifted in time by the adjustment, which may be used
+// to reach the next breathe block. The block can't be in the future.
+func (b *ParliaBackend) AdjustTime(adjustment time.Duration) error {
+	_, err := b.commit(adjustment)
+	return err
+}
+
+// commit proposes and votes on a block shifted in time by the adjustment.
+func (b *ParliaBackend) commit(adjustment time.Duration) (common.Hash, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	chain := b.eth.BlockChain()
+	parent := chain.CurrentBlock()
+	snap, err := b.api.GetSnapshotAtHash(parent.Hash())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + snap.BlockInterval/1000,
+	}
+	proposer, backOff, err := b.proposer(header, snap)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	b.engine.Authorize(proposer.Address, proposer.signFn, proposer.signTxFn)
+
+	config := chain.Config()
+	header.BaseFee = eip1559.CalcBaseFee(config, parent)
+	if err := b.engine.Prepare(chain, header); err != nil {
+		return common.Hash{}, err
+	}
+	// The engine times the block by the wall clock, as the chain is produced in
+	// the past retime it by the block interval and back-off time.
+	blockTime := int64(parent.MilliTimestamp()+snap.BlockInterval+backOff) + adjustment.Milliseconds()
+	if adjustment != 0 {
+		if blockTime <= int64(parent.MilliTimestamp()) {
+			return common.Hash{}, errBackwardTime
+		}
+		if blockTime > time.Now().UnixMilli() {
+			return common.Hash{}, errFutureBlock
+		}
+	}
+	if config.IsLorentz(header.Number, uint64(blockTime)/1000) {
+		header.Time = uint64(blockTime) / 1000
+		header.SetMilliseconds(uint64(blockTime) % 1000)
+	} else {
+		header.Time = uint64(cmath.CeilDiv(int(blockTime), 1000))
+		header.MixDigest = common.Hash{}
+	}
+	nextForkHash := forkid.NextForkHash(config, chain.Genesis().Hash(), chain.Genesis().Time(), header.Number.Uint64(), header.Time)
+	copy(header.Extra[32-len(nextForkHash):32], nextForkHash[:])
+
+	if config.IsCancun(header.Number, header.Time) {
+		var excessBlobGas uint64
+		if config.IsCancun(parent.Number, parent.Time) {
+			excessBlobGas = eip4844.CalcExcessBlobGas(config, parent, header.Time)
+		}
+		header.BlobGasUsed = new(uint64)
+		header.ExcessBlobGas = &excessBlobGas
+		header.WithdrawalsHash = &types.EmptyWithdrawalsHash
+		if config.IsBohr(header.Number, header.Time) {
+			header.ParentBeaconRoot = new(common.Hash)
+		}
+		if config.IsPrague(header.Number, header.Time) {
+			header.RequestsHash = &types.EmptyRequestsHash
+		}
+	}
+	if wait := time.Until(time.Unix(int64(header.Time), 0)); wait > 0 {
+		time.Sleep(wait)
+	}
+
+	block, err := b.assemble(parent, header)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	sealed := block.Header()
+	if err := b.attest(sealed); err != nil {
+		return common.Hash{}, err
+	}
+	sig, err := crypto.Sign(crypto.Keccak256(parlia.ParliaRLP(sealed, config.ChainID)), proposer.key)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	copy(sealed.Extra[len(sealed.Extra)-65:], sig)
+	block = block.WithSeal(sealed)
+
+	if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+		return common.Hash{}, err
+	}
+	if err := b.vote(sealed); err != nil {
+		return common.Hash{}, err
+	}
+	return block.Hash(), nil
+}
+
+// proposer returns the online validator proposing the header and its back-off
+// time in milliseconds.
+func (b *ParliaBackend) proposer(header *types.Header, snap *parlia.Snapshot) (*ParliaValidator, uint64, error) {
+	var (
+		proposer *ParliaValidator
+		backOff  uint64
+	)
+	for _, val := range b.validators {
+		if _, ok := snap.Validators[val.Address]; !ok || val.offline || snap.SignRecently(val.Address) {
+			continue
+		}
+		delay, err := b.engine.BackOffTime(b.eth.BlockChain(), header, val.Address)
+		if err != nil {
+			return nil, 0, err
+		}
+		if proposer == nil || delay < backOff {
+			proposer, backOff = val, delay
+		}
+	}
+	if proposer == nil {
+		return nil, 0, errNoProposer
+	}
+	return proposer, backOff, nil
+}
+
+// assemble executes the pending transactions on top of the parent and finalizes
+// the block with the system transactions of the proposer.
+func (b *ParliaBackend) assemble(parent *types.Header, header *types.Header) (*types.Block, error) {
+	chain := b.eth.BlockChain()
+	config := chain.Config()
+
+	statedb, err := chain.StateAt(parent.Root)
+	if err != nil {
+		return nil, err
+	}
+	evm := vm.NewEVM(core.NewEVMBlockContext(header, chain, nil), statedb, config, vm.Config{})
+	systemcontracts.TryUpdateBuildInSystemContract(config, header.Number, parent.Time, header.Time, statedb, true)
+	if header.ParentBeaconRoot != nil {
+		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, evm)
+	}
+	if config.IsPrague(header.Number, header.Time) {
+		core.ProcessParentBlockHash(header.ParentHash, evm)
+	}
+
+	gasPool := new(core.GasPool).AddGas(header.GasLimit)
+	gasPool.SubGas(b.engine.EstimateGasReservedForSystemTxs(chain, header))
+
+	pending := b.eth.TxPool().Pending(txpool.PendingFilter{
+		BaseFee:      uint256.MustFromBig(header.BaseFee),
+		OnlyPlainTxs: true,
+	})
+	senders := make([]common.Address, 0, len(pending))
+	for sender := range pending {
+		senders = append(senders, sender)
+	}
+	sort.Slice(senders, func(i, j int) bool { return bytes.Compare(senders[i][:], senders[j][:]) < 0 })
+
+	var (
+		txs      []*types.Transaction
+		receipts []*types.Receipt
+	)
+	for _, sender := range senders {
+		for _, ltx := range pending[sender] {
+			tx := ltx.Resolve()
+			if tx == nil {
+				break
+			}
+			snapshot, gas := statedb.Snapshot(), gasPool.Gas()
+			statedb.SetTxContext(tx.Hash(), len(txs))
+			receipt, err := core.ApplyTransaction(evm, gasPool, statedb, header, tx, &header.GasUsed, core.NewReceiptBloomGenerator())
+			if err != nil {
+				statedb.RevertToSnapshot(snapshot)
+				gasPool.SetGas(gas)
+				break
+			}
+			txs = append(txs, tx)
+			receipts = append(receipts, receipt)
+		}
+	}
+	body := &types.Body{Transactions: txs}
+	if config.IsShanghai(header.Number, header.Time) {
+		body.Withdrawals = make([]*types.Withdrawal, 0)
+	}
+	block, _, err := b.engine.FinalizeAndAssemble(chain, header, statedb, body, receipts, nil)
+	return block, err
+}
+
+// attest adds the attestation of the votes for the parent to the header, if
+// enough validators voted.
+func (b *ParliaBackend) attest(header *types.Header) error {
+	chain := b.eth.BlockChain()
+	parent := chain.GetHeaderByHash(header.ParentHash)
+	if parent == nil || parent.Number.Uint64() == 0 {
+		return nil
+	}
+	votes := b.votes[parent.Hash()]
+	snap, err := b.api.GetSnapshotAtHash(parent.ParentHash)
+	if err != nil {
+		return err
+	}
+	if len(votes) < cmath.CeilDiv(len(snap.Validators)*2, 3) {
+		return nil
+	}
+	attestation := &types.VoteAttestation{Data: votes[0].Data}
+	signatures := make([]bls.Signature, 0, len(votes))
+	for _, vote := range votes {
+		sig, err := bls.SignatureFromBytes(vote.Signature[:])
+		if err != nil {
+			return err
+		}
+		signatures = append(signatures, sig)
+		for _, info := range snap.Validators {
+			if info.VoteAddress == vote.VoteAddress {
+				attestation.VoteAddressSet |= 1 << (info.Index - 1) // Index is offset by 1
+			}
+		}
+	}
+	copy(attestation.AggSignature[:], bls.AggregateSignatures(signatures).Marshal())
+
+	enc, err := rlp.EncodeToBytes(attestation)
+	if err != nil {
+		return err
+	}
+	seal := append([]byte{}, header.Extra[len(header.Extra)-65:]...)
+	header.Extra = append(append(header.Extra[:len(header.Extra)-65], enc...), seal...)
+	return nil
+}
+
+// vote lets the online validators vote on the new head, and hands the votes to
+// the vote pool of the node.
+func (b *ParliaBackend) vote(head *types.Header) error {
+	chain := b.eth.BlockChain()
+	snap, err := b.api.GetSnapshotAtHash(head.ParentHash)
+	if err != nil {
+		return err
+	}
+	number, hash, err := b.engine.GetJustifiedNumberAndHash(chain, []*types.Header{head})
+	if err != nil {
+		return err
+	}
+	data := &types.VoteData{
+		SourceNumber: number,
+		SourceHash:   hash,
+		TargetNumber: head.Number.Uint64(),
+		TargetHash:   head.Hash(),
+	}
+	var votes []*types.VoteEnvelope
+	for _, val := range b.validators {
+		if _, ok := snap.Validators[val.Address]; !ok || val.offline {
+			continue
+		}
+		vote := &types.VoteEnvelope{VoteAddress: val.VoteAddress, Data: data}
+		copy(vote.Signature[:], val.voteKey.Sign(data.Hash().Bytes()).Marshal())
+		votes = append(votes, vote)
+
+		if pool := b.eth.VotePool(); pool != nil {
+			pool.PutVote(vote)
+		}
+	}
+	b.votes[head.Hash()] = votes
+
+	for blockHash, votes := range b.votes {
+		if len(votes) == 0 || votes[0].Data.TargetNumber+parliaVoteHistory < head.Number.Uint64() {
+			delete(b.votes, blockHash)
+		}
+	}
+	return nil
+}
+
+// signFn signs the block headers as the validator.
+func (val *ParliaValidator) signFn(_ accounts.Account, _ string, data []byte) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(data), val.key)
+}
+
+// signTxFn signs the system transactions as the validator.
+func (val *ParliaValidator) signTxFn(_ accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), val.key)
+}
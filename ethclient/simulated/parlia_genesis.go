@@ -0,0 +1,324 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulated
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/systemcontracts"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// The system contracts initialized by the consensus engine upon Feynman.
+	feynmanContracts = []string{
+		systemcontracts.StakeHubContract,
+		systemcontracts.GovernorContract,
+		systemcontracts.GovTokenContract,
+		systemcontracts.TimelockContract,
+		systemcontracts.TokenRecoverPortalContract,
+	}
+
+	// validatorExtraSetSlot is the storage slot of the length of the extra records
+	// of the validators in the validator contract.
+	validatorExtraSetSlot = common.BigToHash(big.NewInt(11))
+
+	// validatorCommission is the commission of the validators registered by
+	// the simulated network, in hundredths of a percent.
+	validatorCommission = stakeHubCommission{Rate: 1000, MaxRate: 2000, MaxChangeRate: 100}
+)
+
+// stakeHubCommission is the Go binding of StakeHub.Commission.
+type stakeHubCommission struct {
+	Rate          uint64
+	MaxRate       uint64
+	MaxChangeRate uint64
+}
+
+// stakeHubDescription is the Go binding of StakeHub.Description.
+type stakeHubDescription struct {
+	Moniker  string
+	Identity string
+	Website  string
+	Details  string
+}
+
+// systemContracts returns the genesis state of the system contracts. These are
+// the genesis contracts of the Chapel testnet with its upgrades applied up to the
+// forks enabled at genesis. The Feynman contracts are initialized and the
+// validators registered to the stake hub as they would be on a live network.
+//
+// The contracts initialized by the first block, including the validator contract,
+// are left untouched. The genesis is timed for the first block to be a breathe
+// block, electing the registered validators right after the initialization.
+func systemContracts(chainConfig *params.ChainConfig, time uint64, validators []*ParliaValidator, config ParliaConfig) (types.GenesisAlloc, error) {
+	codes := make(map[common.Address][]byte)
+	for addr, account := range core.DefaultChapelGenesisBlock().Alloc {
+		if len(account.Code) > 0 {
+			codes[addr] = account.Code
+		}
+	}
+	upgrades, err := systemcontracts.GenesisUpgrades(params.ChapelGenesisHash, chainConfig)
+	if err != nil {
+		return nil, err
+	}
+	for addr, code := range upgrades {
+		codes[addr] = code
+	}
+	b, err := newGenesisBuilder(chainConfig, time, validators[0].Address, codes)
+	if err != nil {
+		return nil, err
+	}
+	for _, contract := range feynmanContracts {
+		if _, err := b.call(b.caller, common.HexToAddress(contract), common.Big0, selector("initialize()")); err != nil {
+			return nil, err
+		}
+	}
+	if err := b.govern(stakeHub, "maxElectedValidators", big.NewInt(int64(config.MaxElected))); err != nil {
+		return nil, err
+	}
+	if err := b.seedValidatorSet(codes, config.TurnLength); err != nil {
+		return nil, err
+	}
+	stake, err := b.selfDelegation()
+	if err != nil {
+		return nil, err
+	}
+	for i, val := range validators {
+		b.fund(val.Address, stake)
+		if _, err := b.call(val.Address, stakeHub, stake, val.createValidatorData(chainConfig.ChainID, fmt.Sprintf("Val%d", i))); err != nil {
+			return nil, err
+		}
+	}
+	return b.alloc(), nil
+}
+
+// genesisBuilder prepares the genesis state of the system contracts by running
+// the calls the network would have made before the simulation starts, such as
+// the initialization of the contracts and the registration of the validators.
+// The accounts modified by the calls are collected into the genesis allocation.
+type genesisBuilder struct {
+	statedb *state.StateDB
+	evm     *vm.EVM
+	caller  common.Address // Coinbase of the system calls
+
+	accounts map[common.Address]struct{}
+	slots    map[common.Address]map[common.Hash]struct{}
+}
+
+// newGenesisBuilder creates a builder on top of the code of the system contracts.
+func newGenesisBuilder(config *params.ChainConfig, time uint64, caller common.Address, codes map[common.Address][]byte) (*genesisBuilder, error) {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	if err != nil {
+		return nil, err
+	}
+	b := &genesisBuilder{
+		statedb:  statedb,
+		caller:   caller,
+		accounts: make(map[common.Address]struct{}),
+		slots:    make(map[common.Address]map[common.Hash]struct{}),
+	}
+	for addr, code := range codes {
+		statedb.SetCode(addr, code)
+		b.accounts[addr] = struct{}{}
+	}
+	hooks := &tracing.Hooks{
+		OnBalanceChange: func(addr common.Address, _, _ *big.Int, _ tracing.BalanceChangeReason) {
+			b.accounts[addr] = struct{}{}
+		},
+		OnNonceChange: func(addr common.Address, _, _ uint64) {
+			b.accounts[addr] = struct{}{}
+		},
+		OnCodeChange: func(addr common.Address, _ common.Hash, _ []byte, _ common.Hash, _ []byte) {
+			b.accounts[addr] = struct{}{}
+		},
+		OnStorageChange: func(addr common.Address, slot common.Hash, _, _ common.Hash) {
+			b.accounts[addr] = struct{}{}
+			if b.slots[addr] == nil {
+				b.slots[addr] = make(map[common.Hash]struct{})
+			}
+			b.slots[addr][slot] = struct{}{}
+		},
+	}
+	blockContext := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		GetHash:     func(uint64) common.Hash { return common.Hash{} },
+		Coinbase:    caller,
+		GasLimit:    parliaGasLimit,
+		BlockNumber: new(big.Int),
+		Time:        time,
+		Difficulty:  big.NewInt(1),
+		BaseFee:     new(big.Int),
+		BlobBaseFee: new(big.Int),
+	}
+	b.evm = vm.NewEVM(blockContext, state.NewHookedState(statedb, hooks), config, vm.Config{NoBaseFee: true})
+	return b, nil
+}
+
+// fund credits the account with the given amount.
+func (b *genesisBuilder) fund(addr common.Address, amount *big.Int) {
+	b.evm.StateDB.AddBalance(addr, uint256.MustFromBig(amount), tracing.BalanceChangeUnspecified)
+}
+
+// call runs a call with zero gas price, as the system transactions.
+func (b *genesisBuilder) call(from, to common.Address, value *big.Int, data []byte) ([]byte, error) {
+	b.evm.SetTxContext(vm.TxContext{Origin: from, GasPrice: new(big.Int)})
+	ret, _, err := b.evm.Call(vm.AccountRef(from), to, data, math.MaxUint64/2, uint256.MustFromBig(value))
+	if err != nil {
+		if reason, unpackErr := abi.UnpackRevert(ret); unpackErr == nil {
+			err = fmt.Errorf("%w: %s", err, reason)
+		}
+		return nil, fmt.Errorf("call %x of %s failed: %w", data[:min(len(data), 4)], to, err)
+	}
+	return ret, nil
+}
+
+// govern updates the parameter of the contract as the governance would.
+func (b *genesisBuilder) govern(contract common.Address, key string, value *big.Int) error {
+	data := append(selector("updateParam(string,bytes)"), abiEncode([]string{"string", "bytes"}, key, abiEncode([]string{"uint256"}, value))...)
+	_, err := b.call(common.HexToAddress(systemcontracts.GovHubContract), contract, common.Big0, data)
+	return err
+}
+
+// seedValidatorSet prepares the storage of the validator contract, initialized by
+// the first block. The contract initializes the validators of the Chapel genesis
+// without the extra records its updates expect, which the live network has from
+// the updates since. The turn length is also a parameter only updatable once the
+// contract is initialized. Both are set on an initialized copy of the contract
+// instead, and the storage seeded at genesis, untouched by the initialization.
+func (b *genesisBuilder) seedValidatorSet(codes map[common.Address][]byte, turnLength uint8) error {
+	scratch, err := newGenesisBuilder(b.evm.ChainConfig(), b.evm.Context.Time, b.caller, codes)
+	if err != nil {
+		return err
+	}
+	if _, err := scratch.call(scratch.caller, validatorSet, common.Big0, selector("init()")); err != nil {
+		return err
+	}
+	validators, err := scratch.call(scratch.caller, validatorSet, common.Big0, selector("getValidators()"))
+	if err != nil {
+		return err
+	}
+	b.evm.StateDB.SetState(validatorSet, validatorExtraSetSlot, common.BytesToHash(validators[32:64]))
+
+	if turnLength == 1 {
+		return nil
+	}
+	clear(scratch.slots)
+	if err := scratch.govern(validatorSet, "turnLength", big.NewInt(int64(turnLength))); err != nil {
+		return err
+	}
+	for slot := range scratch.slots[validatorSet] {
+		b.evm.StateDB.SetState(validatorSet, slot, scratch.statedb.GetState(validatorSet, slot))
+	}
+	return nil
+}
+
+// selfDelegation returns the stake required to register a validator.
+func (b *genesisBuilder) selfDelegation() (*big.Int, error) {
+	minSelfDelegation, err := b.call(b.caller, stakeHub, common.Big0, selector("minSelfDelegationBNB()"))
+	if err != nil {
+		return nil, err
+	}
+	lockAmount, err := b.call(b.caller, stakeHub, common.Big0, selector("LOCK_AMOUNT()"))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Add(new(big.Int).SetBytes(minSelfDelegation), new(big.Int).SetBytes(lockAmount)), nil
+}
+
+// alloc returns the accounts modified since the creation of the builder.
+func (b *genesisBuilder) alloc() types.GenesisAlloc {
+	alloc := make(types.GenesisAlloc, len(b.accounts))
+	for addr := range b.accounts {
+		if b.statedb.Empty(addr) {
+			continue
+		}
+		account := types.Account{
+			Code:    b.statedb.GetCode(addr),
+			Nonce:   b.statedb.GetNonce(addr),
+			Balance: b.statedb.GetBalance(addr).ToBig(),
+		}
+		for slot := range b.slots[addr] {
+			if value := b.statedb.GetState(addr, slot); value != (common.Hash{}) {
+				if account.Storage == nil {
+					account.Storage = make(map[common.Hash]common.Hash)
+				}
+				account.Storage[slot] = value
+			}
+		}
+		alloc[addr] = account
+	}
+	return alloc
+}
+
+// createValidatorData returns the calldata registering the validator to the
+// stake hub, operated by the validator itself.
+func (val *ParliaValidator) createValidatorData(chainID *big.Int, moniker string) []byte {
+	proof := val.voteKey.Sign(crypto.Keccak256(val.Address.Bytes(), val.VoteAddress.Bytes(), common.LeftPadBytes(chainID.Bytes(), 32)))
+	args := abi.Arguments{
+		{Type: abiType("address", nil)},
+		{Type: abiType("bytes", nil)},
+		{Type: abiType("bytes", nil)},
+		{Type: abiType("tuple", []abi.ArgumentMarshaling{{Name: "rate", Type: "uint64"}, {Name: "maxRate", Type: "uint64"}, {Name: "maxChangeRate", Type: "uint64"}})},
+		{Type: abiType("tuple", []abi.ArgumentMarshaling{{Name: "moniker", Type: "string"}, {Name: "identity", Type: "string"}, {Name: "website", Type: "string"}, {Name: "details", Type: "string"}})},
+	}
+	data, err := args.Pack(val.Address, val.VoteAddress.Bytes(), proof.Marshal(), validatorCommission, stakeHubDescription{Moniker: moniker})
+	if err != nil {
+		panic(err)
+	}
+	return append(selector("createValidator(address,bytes,bytes,(uint64,uint64,uint64),(string,string,string,string))"), data...)
+}
+
+// selector returns the selector of the method with the given signature.
+func selector(signature string) []byte {
+	return crypto.Keccak256([]byte(signature))[:4]
+}
+
+// abiEncode packs the values as the arguments of the given types.
+func abiEncode(typeNames []string, values ...interface{}) []byte {
+	args := make(abi.Arguments, len(typeNames))
+	for i, name := range typeNames {
+		args[i] = abi.Argument{Type: abiType(name, nil)}
+	}
+	data, err := args.Pack(values...)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// abiType returns the ABI type of the given name and components.
+func abiType(name string, components []abi.ArgumentMarshaling) abi.Type {
+	typ, err := abi.NewType(name, "", components)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
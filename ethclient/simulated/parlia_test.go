@@ -0,0 +1,240 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/parlia"
+	"github.com/ethereum/go-ethereum/core/systemcontracts"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func newParliaBackend(t *testing.T, config ParliaConfig) *ParliaBackend {
+	sim, err := NewParliaBackend(types.GenesisAlloc{testAddr: {Balance: big.NewInt(params.Ether)}}, config)
+	if err != nil {
+		t.Fatalf("failed to create simulated network: %v", err)
+	}
+	t.Cleanup(func() { sim.Close() })
+	return sim
+}
+
+func commitBlocks(t *testing.T, sim *ParliaBackend, n int) {
+	for i := 0; i < n; i++ {
+		if _, err := sim.Commit(); err != nil {
+			t.Fatalf("failed to commit block: %v", err)
+		}
+	}
+}
+
+func TestParliaBackendFinality(t *testing.T) {
+	sim := newParliaBackend(t, ParliaConfig{Validators: 4, TurnLength: 4})
+	client := sim.Client()
+	ctx := context.Background()
+
+	// Send a transaction paying fees to the validators, once they are elected by
+	// the first block.
+	commitBlocks(t, sim, 1)
+	chainID, _ := client.ChainID(ctx)
+	gasPrice, _ := client.SuggestGasPrice(ctx)
+	tx, _ := types.SignNewTx(testKey, types.LatestSignerForChainID(chainID), &types.LegacyTx{
+		To:       &common.Address{0x1},
+		Value:    big.NewInt(1),
+		Gas:      params.TxGas,
+		GasPrice: gasPrice,
+	})
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		t.Fatalf("failed to send transaction: %v", err)
+	}
+	commitBlocks(t, sim, 7)
+
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("transaction not included: %v", err)
+	}
+	// The validator contract burns 10% of the fees, sends 6.25% to the system
+	// reward contract and credits the rest to the proposer.
+	var (
+		fee    = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+		burn   = new(big.Int).Div(new(big.Int).Mul(fee, big.NewInt(1000)), big.NewInt(10000))
+		system = new(big.Int).Div(new(big.Int).Mul(fee, big.NewInt(625)), big.NewInt(10000))
+		reward = new(big.Int).Sub(new(big.Int).Sub(fee, burn), system)
+		parent = new(big.Int).Sub(receipt.BlockNumber, common.Big1)
+	)
+	for addr, want := range map[common.Address]*big.Int{
+		common.HexToAddress("0x000000000000000000000000000000000000dEaD"): burn,
+		common.HexToAddress(systemcontracts.SystemRewardContract):         system,
+		common.HexToAddress(systemcontracts.ValidatorContract):            reward,
+	} {
+		before, _ := client.BalanceAt(ctx, addr, parent)
+		after, _ := client.BalanceAt(ctx, addr, receipt.BlockNumber)
+		if got := new(big.Int).Sub(after, before); got.Cmp(want) != 0 {
+			t.Errorf("%s received %v, want %v", addr, got, want)
+		}
+	}
+	header, _ := client.HeaderByNumber(ctx, receipt.BlockNumber)
+	incoming, err := client.CallContract(ctx, ethereum.CallMsg{
+		To:   &validatorSet,
+		Data: append(selector("getIncoming(address)"), common.LeftPadBytes(header.Coinbase.Bytes(), 32)...),
+	}, receipt.BlockNumber)
+	if err != nil {
+		t.Fatalf("failed to get incoming: %v", err)
+	}
+	if got := new(big.Int).SetBytes(incoming); got.Cmp(reward) != 0 {
+		t.Errorf("proposer credited %v, want %v", got, reward)
+	}
+
+	// The validators propose by turns of four blocks, and every block is justified.
+	for number := uint64(1); number <= 8; number++ {
+		header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			t.Fatalf("failed to get header %d: %v", number, err)
+		}
+		if want := sim.Validators()[number/4%4].Address; header.Coinbase != want {
+			t.Errorf("block %d proposed by %s, want %s", number, header.Coinbase, want)
+		}
+		if header.Difficulty.Uint64() != 2 {
+			t.Errorf("block %d not proposed in turn", number)
+		}
+	}
+	finalized, err := client.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil {
+		t.Fatalf("failed to get finalized header: %v", err)
+	}
+	if finalized.Number.Uint64() != 6 {
+		t.Errorf("finalized block %d, want 6", finalized.Number)
+	}
+	if votes := sim.Votes(finalized.Hash()); len(votes) != 4 {
+		t.Errorf("%d votes for the finalized block, want 4", len(votes))
+	}
+}
+
+func TestParliaBackendOfflineValidators(t *testing.T) {
+	sim := newParliaBackend(t, ParliaConfig{Validators: 4})
+	client := sim.Client()
+	ctx := context.Background()
+
+	// With one validator offline, the others take over and still finalize.
+	sim.SetOnline(0, false)
+	commitBlocks(t, sim, 8)
+
+	var outOfTurn int
+	for number := uint64(1); number <= 8; number++ {
+		header, _ := client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+		if header.Coinbase == sim.Validators()[0].Address {
+			t.Errorf("block %d proposed by the offline validator", number)
+		}
+		if header.Difficulty.Uint64() == 1 {
+			outOfTurn++
+		}
+	}
+	if outOfTurn == 0 {
+		t.Error("no block proposed out of turn")
+	}
+	finalized, err := client.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil || finalized.Number.Uint64() < 5 {
+		t.Errorf("chain not finalized with one validator offline: %v", err)
+	}
+
+	// With two validators offline, the blocks proposed are no longer finalized.
+	sim.SetOnline(1, false)
+	for i := 0; i < 4; i++ {
+		if _, err := sim.Commit(); err != nil {
+			break
+		}
+	}
+	stalled, _ := client.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if stalled.Number.Uint64() > 8 {
+		t.Errorf("block %d finalized with half of the validators offline", stalled.Number)
+	}
+	sim.SetOnline(0, true)
+	sim.SetOnline(1, true)
+	commitBlocks(t, sim, 4)
+}
+
+func TestParliaBackendEpochRotation(t *testing.T) {
+	sim := newParliaBackend(t, ParliaConfig{Validators: 4, MaxElected: 3, TurnLength: 4})
+	rpcClient := sim.client.Client.Client()
+
+	var snap parlia.Snapshot
+	if err := rpcClient.Call(&snap, "parlia_getSnapshot", "latest"); err != nil {
+		t.Fatalf("failed to get snapshot: %v", err)
+	}
+	if len(snap.Validators) != 3 {
+		t.Fatalf("%d validators at genesis, want 3", len(snap.Validators))
+	}
+	var standby int
+	for i, val := range sim.Validators() {
+		if _, ok := snap.Validators[val.Address]; !ok {
+			standby = i
+		}
+	}
+	// Delegate to the standby validator, it gets elected at the next breathe
+	// block and takes over from the next epoch block.
+	if err := sim.Delegate(standby, big.NewInt(params.Ether)); err != nil {
+		t.Fatalf("failed to delegate: %v", err)
+	}
+	commitBlocks(t, sim, 2)
+	if err := sim.AdjustTime(24 * time.Hour); err != nil {
+		t.Fatalf("failed to adjust time: %v", err)
+	}
+	head, _ := sim.Client().HeaderByNumber(context.Background(), nil)
+	commitBlocks(t, sim, int(200-head.Number.Uint64()%200)+1)
+
+	snap = parlia.Snapshot{}
+	if err := rpcClient.Call(&snap, "parlia_getSnapshot", "latest"); err != nil {
+		t.Fatalf("failed to get snapshot: %v", err)
+	}
+	if len(snap.Validators) != 3 {
+		t.Fatalf("%d validators before the epoch block, want 3", len(snap.Validators))
+	}
+	if _, ok := snap.Validators[sim.Validators()[standby].Address]; ok {
+		t.Fatal("validator elected before the switch")
+	}
+	// The new validators take over once the blocks of a turn of the half of the
+	// old validators are past the epoch block.
+	commitBlocks(t, sim, 9)
+	snap = parlia.Snapshot{}
+	if err := rpcClient.Call(&snap, "parlia_getSnapshot", "latest"); err != nil {
+		t.Fatalf("failed to get snapshot: %v", err)
+	}
+	if len(snap.Validators) != 3 {
+		t.Errorf("%d validators after the epoch block, want 3", len(snap.Validators))
+	}
+	if _, ok := snap.Validators[sim.Validators()[standby].Address]; !ok {
+		t.Error("delegated validator not elected")
+	}
+	if snap.TurnLength != 4 {
+		t.Errorf("turn length %d, want 4", snap.TurnLength)
+	}
+	// The new validator set keeps finalizing the chain.
+	commitBlocks(t, sim, 10)
+	var finalized uint64
+	if err := rpcClient.Call(&finalized, "parlia_getFinalizedNumber", "latest"); err != nil {
+		t.Fatalf("failed to get finalized number: %v", err)
+	}
+	if finalized < snap.Number+8 {
+		t.Errorf("finalized block %d after the rotation, want at least %d", finalized, snap.Number+8)
+	}
+}
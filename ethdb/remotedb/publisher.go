@@ -0,0 +1,368 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package remotedb
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// OpKind is the type of a database modification.
+type OpKind string
+
+const (
+	OpPut               OpKind = "put"               // Key-value insertion
+	OpDelete            OpKind = "delete"            // Key-value deletion
+	OpDeleteRange       OpKind = "deleteRange"       // Key-value deletion of [Key, End)
+	OpAppend            OpKind = "append"            // Ancient item append
+	OpTruncateHead      OpKind = "truncateHead"      // Ancient head truncation to Number items
+	OpTruncateTail      OpKind = "truncateTail"      // Ancient tail truncation to Number items
+	OpTruncateTableTail OpKind = "truncateTableTail" // Ancient table tail truncation to Number items
+)
+
+// Op is a single database modification.
+type Op struct {
+	Kind   OpKind         `json:"kind"`
+	Key    hexutil.Bytes  `json:"key,omitempty"`
+	Value  hexutil.Bytes  `json:"value,omitempty"`
+	End    hexutil.Bytes  `json:"end,omitempty"`
+	Table  string         `json:"table,omitempty"`
+	Number hexutil.Uint64 `json:"number,omitempty"`
+}
+
+// Update is a set of database modifications committed atomically on the primary
+// node, pushed to the read replicas in the order of commitment.
+type Update struct {
+	Seq   uint64 `json:"seq"`             // Sequence number, increasing by one per update
+	State bool   `json:"state,omitempty"` // Whether the update targets the separate state store
+	Ops   []*Op  `json:"ops"`
+}
+
+// NotFoundErrorCode is the JSON-RPC error code of a lookup of data missing on
+// the primary node, telling it apart from a failure to serve the lookup.
+const NotFoundErrorCode = -39001
+
+// NotFoundError is returned by the database API of the primary node if the
+// requested data doesn't exist.
+type NotFoundError struct{ Message string }
+
+func (e *NotFoundError) Error() string  { return e.Message }
+func (e *NotFoundError) ErrorCode() int { return NotFoundErrorCode }
+
+// publisherSub is a subscriber of the published updates.
+type publisherSub struct {
+	ch      chan<- *Update
+	lagging bool // Whether the last update was dropped for the subscriber
+}
+
+// publisherFeed is the update feed shared by the chain and the state store.
+//
+// The updates are delivered without blocking the database writes: a subscriber
+// falling behind misses them, and detects the gap in the sequence numbers.
+type publisherFeed struct {
+	subs map[*publisherSub]struct{}
+	seq  uint64
+	lock sync.Mutex // Lock to keep the sequence numbers in the order of delivery
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newPublisherFeed() *publisherFeed {
+	return &publisherFeed{
+		subs:   make(map[*publisherSub]struct{}),
+		closed: make(chan struct{}),
+	}
+}
+
+// subscribe registers the channel to receive the updates until unsubscribed or
+// the feed is closed.
+func (f *publisherFeed) subscribe(ch chan<- *Update) event.Subscription {
+	sub := &publisherSub{ch: ch}
+
+	f.lock.Lock()
+	f.subs[sub] = struct{}{}
+	f.lock.Unlock()
+
+	return event.NewSubscription(func(unsubscribed <-chan struct{}) error {
+		select {
+		case <-unsubscribed:
+		case <-f.closed:
+		}
+		f.lock.Lock()
+		delete(f.subs, sub)
+		f.lock.Unlock()
+		return nil
+	})
+}
+
+// send assigns the next sequence number to a committed modification, and
+// delivers it to the subscribers. The sequence number is advanced even if
+// the operations weren't collected, which is signalled by a nil result, so
+// that the subscribers detect the gap.
+func (f *publisherFeed) send(state bool, ops func() []*Op) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.seq++
+	if len(f.subs) == 0 {
+		return
+	}
+	update := &Update{Seq: f.seq, State: state, Ops: ops()}
+	if update.Ops == nil {
+		return
+	}
+	for sub := range f.subs {
+		select {
+		case sub.ch <- update:
+			sub.lagging = false
+		default:
+			if !sub.lagging {
+				log.Warn("Dropping database updates for slow subscriber", "seq", update.Seq)
+			}
+			sub.lagging = true
+		}
+	}
+}
+
+// active returns whether there are any subscribers, in which case the
+// modifications should be collected.
+func (f *publisherFeed) active() bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return len(f.subs) > 0
+}
+
+// close terminates all the subscriptions.
+func (f *publisherFeed) close() {
+	f.closeOnce.Do(func() { close(f.closed) })
+}
+
+// Publisher is a database wrapper which publishes every committed key-value
+// write and ancient modification to the subscribed read replicas.
+//
+// The data migrated by the chain freezer in the background is not published,
+// the replica is expected to run its own freezer over the replicated data.
+type Publisher struct {
+	ethdb.Database
+	feed  *publisherFeed
+	state bool
+}
+
+// NewPublisher wraps the given database, including the separate state store if
+// it's configured, to publish the modifications made through it.
+func NewPublisher(db ethdb.Database) *Publisher {
+	p := &Publisher{
+		Database: db,
+		feed:     newPublisherFeed(),
+	}
+	if db.HasSeparateStateStore() {
+		db.SetStateStore(&Publisher{Database: db.GetStateStore(), feed: p.feed, state: true})
+	}
+	return p
+}
+
+// SubscribeUpdates subscribes to the modifications committed to the database.
+//
+// The updates are never waited for: if the channel is full, the update is dropped
+// for the subscriber, which detects the gap in the sequence numbers.
+func (p *Publisher) SubscribeUpdates(ch chan<- *Update) event.Subscription {
+	return p.feed.subscribe(ch)
+}
+
+// SetStateStore implements ethdb.StateStore, wrapping the state store to
+// publish its modifications too.
+func (p *Publisher) SetStateStore(state ethdb.Database) {
+	if _, ok := state.(*Publisher); !ok && state != nil {
+		state = &Publisher{Database: state, feed: p.feed, state: true}
+	}
+	p.Database.SetStateStore(state)
+}
+
+// Close terminates the subscriptions and closes the database.
+func (p *Publisher) Close() error {
+	p.feed.close()
+	return p.Database.Close()
+}
+
+// Put implements ethdb.KeyValueWriter.
+func (p *Publisher) Put(key []byte, value []byte) error {
+	if err := p.Database.Put(key, value); err != nil {
+		return err
+	}
+	p.feed.send(p.state, func() []*Op {
+		return []*Op{{Kind: OpPut, Key: common.CopyBytes(key), Value: common.CopyBytes(value)}}
+	})
+	return nil
+}
+
+// Delete implements ethdb.KeyValueWriter.
+func (p *Publisher) Delete(key []byte) error {
+	if err := p.Database.Delete(key); err != nil {
+		return err
+	}
+	p.feed.send(p.state, func() []*Op {
+		return []*Op{{Kind: OpDelete, Key: common.CopyBytes(key)}}
+	})
+	return nil
+}
+
+// DeleteRange implements ethdb.KeyValueRangeDeleter.
+func (p *Publisher) DeleteRange(start, end []byte) error {
+	if err := p.Database.DeleteRange(start, end); err != nil {
+		return err
+	}
+	p.feed.send(p.state, func() []*Op {
+		return []*Op{{Kind: OpDeleteRange, Key: common.CopyBytes(start), End: common.CopyBytes(end)}}
+	})
+	return nil
+}
+
+// NewBatch implements ethdb.Batcher.
+func (p *Publisher) NewBatch() ethdb.Batch {
+	return &publisherBatch{Batch: p.Database.NewBatch(), publisher: p}
+}
+
+// NewBatchWithSize implements ethdb.Batcher.
+func (p *Publisher) NewBatchWithSize(size int) ethdb.Batch {
+	return &publisherBatch{Batch: p.Database.NewBatchWithSize(size), publisher: p}
+}
+
+// ModifyAncients implements ethdb.AncientWriter.
+func (p *Publisher) ModifyAncients(fn func(ethdb.AncientWriteOp) error) (int64, error) {
+	if !p.feed.active() {
+		size, err := p.Database.ModifyAncients(fn)
+		if err == nil {
+			p.feed.send(p.state, func() []*Op { return nil })
+		}
+		return size, err
+	}
+	var ops []*Op
+	size, err := p.Database.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+		return fn(&publisherAncientOp{op: op, ops: &ops})
+	})
+	if err != nil {
+		return size, err
+	}
+	if len(ops) > 0 {
+		p.feed.send(p.state, func() []*Op { return ops })
+	}
+	return size, nil
+}
+
+// TruncateHead implements ethdb.AncientWriter.
+func (p *Publisher) TruncateHead(n uint64) (uint64, error) {
+	old, err := p.Database.TruncateHead(n)
+	if err != nil {
+		return old, err
+	}
+	p.feed.send(p.state, func() []*Op {
+		return []*Op{{Kind: OpTruncateHead, Number: hexutil.Uint64(n)}}
+	})
+	return old, nil
+}
+
+// TruncateTail implements ethdb.AncientWriter.
+func (p *Publisher) TruncateTail(n uint64) (uint64, error) {
+	old, err := p.Database.TruncateTail(n)
+	if err != nil {
+		return old, err
+	}
+	p.feed.send(p.state, func() []*Op {
+		return []*Op{{Kind: OpTruncateTail, Number: hexutil.Uint64(n)}}
+	})
+	return old, nil
+}
+
+// TruncateTableTail implements ethdb.AncientWriter.
+func (p *Publisher) TruncateTableTail(kind string, tail uint64) (uint64, error) {
+	old, err := p.Database.TruncateTableTail(kind, tail)
+	if err != nil {
+		return old, err
+	}
+	p.feed.send(p.state, func() []*Op {
+		return []*Op{{Kind: OpTruncateTableTail, Table: kind, Number: hexutil.Uint64(tail)}}
+	})
+	return old, nil
+}
+
+// publisherBatch is a batch which publishes its content once written.
+type publisherBatch struct {
+	ethdb.Batch
+	publisher *Publisher
+}
+
+// Write implements ethdb.Batch, publishing the content after flushing it.
+func (b *publisherBatch) Write() error {
+	if err := b.Batch.Write(); err != nil {
+		return err
+	}
+	if !b.publisher.feed.active() {
+		b.publisher.feed.send(b.publisher.state, func() []*Op { return nil })
+		return nil
+	}
+	var ops opRecorder
+	if err := b.Batch.Replay(&ops); err != nil {
+		return err
+	}
+	if len(ops) > 0 {
+		b.publisher.feed.send(b.publisher.state, func() []*Op { return ops })
+	}
+	return nil
+}
+
+// opRecorder is a key-value writer which records the modifications.
+type opRecorder []*Op
+
+func (r *opRecorder) Put(key []byte, value []byte) error {
+	*r = append(*r, &Op{Kind: OpPut, Key: common.CopyBytes(key), Value: common.CopyBytes(value)})
+	return nil
+}
+
+func (r *opRecorder) Delete(key []byte) error {
+	*r = append(*r, &Op{Kind: OpDelete, Key: common.CopyBytes(key)})
+	return nil
+}
+
+// publisherAncientOp is an ancient writer which records the appended items.
+type publisherAncientOp struct {
+	op  ethdb.AncientWriteOp
+	ops *[]*Op
+}
+
+func (w *publisherAncientOp) Append(kind string, number uint64, item interface{}) error {
+	blob, err := rlp.EncodeToBytes(item)
+	if err != nil {
+		return err
+	}
+	return w.AppendRaw(kind, number, blob)
+}
+
+func (w *publisherAncientOp) AppendRaw(kind string, number uint64, item []byte) error {
+	if err := w.op.AppendRaw(kind, number, item); err != nil {
+		return err
+	}
+	*w.ops = append(*w.ops, &Op{Kind: OpAppend, Table: kind, Number: hexutil.Uint64(number), Value: common.CopyBytes(item)})
+	return nil
+}
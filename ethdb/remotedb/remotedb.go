@@ -19,6 +19,10 @@
 // read-only database.
 // There really are no guarantees in this database, since the local geth does not
 // exclusive access, but it can be used for basic diagnostics of a remote node.
+//
+// The package also implements a read replica mode: the Publisher wraps the
+// database of a primary node to stream its committed modifications, which are
+// applied by a Replica to its own local database.
 package remotedb
 
 import (
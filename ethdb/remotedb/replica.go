@@ -0,0 +1,541 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package remotedb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// replicaSubscribeTimeout is the maximum time to wait for the primary node
+	// to accept the subscription.
+	replicaSubscribeTimeout = 10 * time.Second
+
+	// replicaResubscribeDelay is the time to wait before resubscribing to the
+	// primary node after the subscription failed.
+	replicaResubscribeDelay = 5 * time.Second
+)
+
+var (
+	// errReplicaNotFound is returned if the data is missing both locally and on
+	// the primary node.
+	errReplicaNotFound = errors.New("not found")
+
+	// ErrReplicaStale is returned by the lookups once the replica missed updates
+	// of the primary node, until it's reseeded.
+	ErrReplicaStale = errors.New("database replica is stale, reseed it from the primary")
+)
+
+// replicaStaleGauge reports whether the replica stopped serving for missing
+// updates of the primary node.
+var replicaStaleGauge = metrics.NewRegisteredGauge("remotedb/replica/stale", nil)
+
+// Replica is a read replica of the database of a primary node. It subscribes to
+// the modifications committed on the primary via `debug_subscribe("dbUpdates")`
+// and applies them to the local database, which is expected to be seeded with
+// a copy of the primary's database. Lookups are served by an LRU cache in front
+// of the local database, falling back to `debug_dbGet` and `debug_dbAncient`
+// for the data missing locally.
+//
+// Local writes are permitted and applied to the local database only. The updates
+// committed while the replica is disconnected from the primary, or dropped for
+// falling behind, are lost. Upon a gap in the update sequence or a failure to
+// apply an update, the replica stops following the primary and fails all the
+// lookups with ErrReplicaStale. The local database is flagged accordingly, to
+// be refused until reseeded.
+//
+// The replicated head block marker is signalled through Heads, for the node to
+// follow the chain of the primary. Note the state of the recent blocks is only
+// available once persisted by the primary node.
+type Replica struct {
+	ethdb.Database // Local database to apply the updates to
+
+	remote  *rpc.Client
+	cache   lru.BasicLRU[string, []byte]
+	missing lru.BasicLRU[string, struct{}] // Keys missing both locally and on the primary
+	gen     uint64                         // Generation of the caches, bumped upon every modification
+	lock    sync.Mutex                     // Lock protecting the caches and their generation
+	seq     uint64                         // Sequence number of the last applied update
+	stale   atomic.Bool                    // Whether updates were missed, failing the lookups
+
+	head   common.Hash   // Last head block hash replicated from the primary
+	headCh chan struct{} // Notification channel of the head block changes
+
+	closeOnce sync.Once
+	quit      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewReplica creates a read replica of the primary node behind the client on
+// top of the given local database, caching at most cache entries.
+func NewReplica(client *rpc.Client, local ethdb.Database, cache int) *Replica {
+	db := &Replica{
+		Database: local,
+		remote:   client,
+		cache:    lru.NewBasicLRU[string, []byte](max(cache, 1)),
+		missing:  lru.NewBasicLRU[string, struct{}](max(cache, 1)),
+		head:     rawdb.ReadHeadBlockHash(local),
+		headCh:   make(chan struct{}, 1),
+		quit:     make(chan struct{}),
+	}
+	db.wg.Add(1)
+	go db.loop()
+	return db
+}
+
+// DialReplica connects to the primary node at the given URL and creates a read
+// replica of its database on top of the given local database. The size of the
+// messages is not limited, since an update may carry a whole flushed buffer of
+// the primary's state, exceeding the default websocket limit.
+func DialReplica(url string, local ethdb.Database, cache int) (*Replica, error) {
+	client, err := rpc.DialOptions(context.Background(), url, rpc.WithWebsocketMessageSizeLimit(0))
+	if err != nil {
+		return nil, err
+	}
+	return NewReplica(client, local, cache), nil
+}
+
+// Heads returns a channel signalled whenever the head block marker replicated
+// from the primary node changes. Consecutive changes may be coalesced into a
+// single signal.
+func (db *Replica) Heads() <-chan struct{} {
+	return db.headCh
+}
+
+// Has implements ethdb.KeyValueReader.
+func (db *Replica) Has(key []byte) (bool, error) {
+	if _, err := db.Get(key); err != nil {
+		if errors.Is(err, errReplicaNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Get implements ethdb.KeyValueReader, resolving the key from the cache, the
+// local database and the primary node in order. The keys missing everywhere
+// are cached too, until modified.
+func (db *Replica) Get(key []byte) ([]byte, error) {
+	if db.stale.Load() {
+		return nil, ErrReplicaStale
+	}
+	db.lock.Lock()
+	blob, ok := db.cache.Get(string(key))
+	missing := db.missing.Contains(string(key))
+	gen := db.gen
+	db.lock.Unlock()
+
+	if ok {
+		return common.CopyBytes(blob), nil
+	}
+	if missing {
+		return nil, errReplicaNotFound
+	}
+	blob, err := db.resolve(key)
+	if err != nil && !errors.Is(err, errReplicaNotFound) {
+		return nil, err
+	}
+	// Cache the result unless the key was modified in the meantime
+	db.lock.Lock()
+	if db.gen == gen {
+		if err != nil {
+			db.missing.Add(string(key), struct{}{})
+		} else {
+			db.cache.Add(string(key), common.CopyBytes(blob))
+		}
+	}
+	db.lock.Unlock()
+	return blob, err
+}
+
+// resolve retrieves the value of the key from the local database, or from the
+// primary node if it's missing locally.
+func (db *Replica) resolve(key []byte) ([]byte, error) {
+	blob, err := db.Database.Get(key)
+	if err == nil {
+		return blob, nil
+	}
+	// Tell a key missing locally apart from a failure of the local database
+	if has, herr := db.Database.Has(key); herr != nil {
+		return nil, herr
+	} else if has {
+		return nil, err
+	}
+	var resp hexutil.Bytes
+	if err := db.remote.Call(&resp, "debug_dbGet", hexutil.Bytes(key)); err != nil {
+		if isRemoteNotFound(err) {
+			return nil, errReplicaNotFound
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// HasAncient implements ethdb.AncientReaderOp.
+func (db *Replica) HasAncient(kind string, number uint64) (bool, error) {
+	return db.hasAncient(db.Database, kind, number)
+}
+
+// Ancient implements ethdb.AncientReaderOp, falling back to the primary node
+// if the item is missing locally.
+func (db *Replica) Ancient(kind string, number uint64) ([]byte, error) {
+	return db.ancient(db.Database, kind, number)
+}
+
+// hasAncient reports whether the ancient item exists in the given local freezer
+// or on the primary node.
+func (db *Replica) hasAncient(local ethdb.AncientReaderOp, kind string, number uint64) (bool, error) {
+	if _, err := db.ancient(local, kind, number); err != nil {
+		if errors.Is(err, errReplicaNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// ancient retrieves the ancient item from the given local freezer, or from the
+// primary node if it's missing locally.
+func (db *Replica) ancient(local ethdb.AncientReaderOp, kind string, number uint64) ([]byte, error) {
+	if db.stale.Load() {
+		return nil, ErrReplicaStale
+	}
+	blob, err := local.Ancient(kind, number)
+	if err == nil {
+		return blob, nil
+	}
+	// Tell an item missing locally apart from a failure of the local freezer.
+	// A missing freezer fails the existence check too, deferring to the primary.
+	if has, herr := local.HasAncient(kind, number); herr == nil && has {
+		return nil, err
+	}
+	var resp hexutil.Bytes
+	if err := db.remote.Call(&resp, "debug_dbAncient", kind, number); err != nil {
+		if isRemoteNotFound(err) {
+			return nil, errReplicaNotFound
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// isRemoteNotFound reports whether the error returned by the primary node is a
+// lookup of missing data, as opposed to any other failure of the lookup.
+func isRemoteNotFound(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == NotFoundErrorCode
+}
+
+// ReadAncients implements ethdb.AncientReader.
+func (db *Replica) ReadAncients(fn func(op ethdb.AncientReaderOp) error) error {
+	return db.Database.ReadAncients(func(op ethdb.AncientReaderOp) error {
+		return fn(&replicaAncientReader{AncientReaderOp: op, db: db})
+	})
+}
+
+// StateStoreReader implements ethdb.StateStoreReader.
+func (db *Replica) StateStoreReader() ethdb.Reader {
+	if db.HasSeparateStateStore() {
+		return db.Database.StateStoreReader()
+	}
+	return db
+}
+
+// Put implements ethdb.KeyValueWriter.
+func (db *Replica) Put(key []byte, value []byte) error {
+	defer db.invalidate([][]byte{key})
+	return db.Database.Put(key, value)
+}
+
+// Delete implements ethdb.KeyValueWriter.
+func (db *Replica) Delete(key []byte) error {
+	defer db.invalidate([][]byte{key})
+	return db.Database.Delete(key)
+}
+
+// DeleteRange implements ethdb.KeyValueRangeDeleter.
+func (db *Replica) DeleteRange(start, end []byte) error {
+	defer db.invalidate(nil)
+	return db.Database.DeleteRange(start, end)
+}
+
+// NewBatch implements ethdb.Batcher.
+func (db *Replica) NewBatch() ethdb.Batch {
+	return &replicaBatch{Batch: db.Database.NewBatch(), db: db}
+}
+
+// NewBatchWithSize implements ethdb.Batcher.
+func (db *Replica) NewBatchWithSize(size int) ethdb.Batch {
+	return &replicaBatch{Batch: db.Database.NewBatchWithSize(size), db: db}
+}
+
+// Close stops replicating from the primary node and closes the local database.
+func (db *Replica) Close() error {
+	db.closeOnce.Do(func() {
+		close(db.quit)
+		db.wg.Wait()
+		db.remote.Close()
+	})
+	return db.Database.Close()
+}
+
+// invalidate drops the given keys from the caches, or the entire caches if the
+// list is nil, after a modification.
+func (db *Replica) invalidate(keys [][]byte) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.gen++
+	if keys == nil {
+		db.cache.Purge()
+		db.missing.Purge()
+		return
+	}
+	for _, key := range keys {
+		db.cache.Remove(string(key))
+		db.missing.Remove(string(key))
+	}
+}
+
+// loop keeps the subscription to the primary node alive, applying the updates
+// to the local database until the replica is closed.
+func (db *Replica) loop() {
+	defer db.wg.Done()
+
+	for {
+		updates := make(chan *Update, 1024)
+		ctx, cancel := context.WithTimeout(context.Background(), replicaSubscribeTimeout)
+		sub, err := db.remote.Subscribe(ctx, "debug", updates, "dbUpdates")
+		cancel()
+		if err != nil {
+			log.Warn("Failed to subscribe to database updates", "err", err)
+		} else {
+			log.Info("Subscribed to database updates")
+			err = db.follow(sub, updates)
+			sub.Unsubscribe()
+			if err == nil {
+				return
+			}
+			log.Warn("Database update subscription failed", "err", err)
+		}
+		select {
+		case <-time.After(replicaResubscribeDelay):
+		case <-db.quit:
+			return
+		}
+	}
+}
+
+// follow applies the updates received from the subscription until it fails, or
+// the replica is closed or becomes stale, in which case nil is returned.
+func (db *Replica) follow(sub *rpc.ClientSubscription, updates chan *Update) error {
+	for {
+		select {
+		case update := <-updates:
+			if db.seq != 0 && update.Seq != db.seq+1 {
+				log.Error("Missed database updates", "last", db.seq, "next", update.Seq)
+				db.markStale()
+				return nil
+			}
+			if err := db.apply(update); err != nil {
+				log.Error("Failed to apply database update", "seq", update.Seq, "err", err)
+				db.markStale()
+				return nil
+			}
+			db.seq = update.Seq
+			if !update.State {
+				db.checkHead()
+			}
+		case err := <-sub.Err():
+			return err
+		case <-db.quit:
+			return nil
+		}
+	}
+}
+
+// markStale stops serving the lookups after missing updates of the primary,
+// flagging the local database to be reseeded.
+func (db *Replica) markStale() {
+	db.stale.Store(true)
+	replicaStaleGauge.Update(1)
+	rawdb.WriteReplicaStale(db.Database)
+	log.Error("Database replica is stale, reseed it from the primary")
+}
+
+// checkHead signals the subscribers if the head block marker was modified.
+func (db *Replica) checkHead() {
+	head := rawdb.ReadHeadBlockHash(db.Database)
+	if head == db.head || head == (common.Hash{}) {
+		return
+	}
+	db.head = head
+	select {
+	case db.headCh <- struct{}{}:
+	default:
+	}
+}
+
+// apply applies the modifications of the update to the local database.
+func (db *Replica) apply(update *Update) error {
+	var (
+		store = ethdb.Database(db.Database)
+		batch ethdb.Batch
+		keys  = make([][]byte, 0, len(update.Ops))
+		purge bool
+		err   error
+	)
+	if update.State && db.HasSeparateStateStore() {
+		store = db.GetStateStore()
+	}
+	defer func() {
+		if purge {
+			keys = nil
+		}
+		db.invalidate(keys)
+	}()
+	flush := func() error {
+		if batch == nil {
+			return nil
+		}
+		err := batch.Write()
+		batch = nil
+		return err
+	}
+	for i := 0; i < len(update.Ops); i++ {
+		op := update.Ops[i]
+		switch op.Kind {
+		case OpPut, OpDelete:
+			if batch == nil {
+				batch = store.NewBatch()
+			}
+			if op.Kind == OpPut {
+				err = batch.Put(op.Key, op.Value)
+			} else {
+				err = batch.Delete(op.Key)
+			}
+			keys = append(keys, op.Key)
+
+		case OpDeleteRange:
+			if err = flush(); err == nil {
+				err = store.DeleteRange(op.Key, op.End)
+			}
+			purge = true
+
+		case OpAppend:
+			// Appends are only produced by ModifyAncients, all of them
+			// belong to the same atomic modification.
+			if err = flush(); err == nil {
+				err = db.appendAncients(store, update.Ops[i:])
+			}
+			i = len(update.Ops)
+
+		case OpTruncateHead:
+			if err = flush(); err == nil {
+				_, err = store.TruncateHead(uint64(op.Number))
+			}
+		case OpTruncateTail:
+			if err = flush(); err == nil {
+				_, err = store.TruncateTail(uint64(op.Number))
+			}
+		case OpTruncateTableTail:
+			if err = flush(); err == nil {
+				_, err = store.TruncateTableTail(op.Table, uint64(op.Number))
+			}
+		default:
+			log.Warn("Unknown database update operation", "kind", op.Kind)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return flush()
+}
+
+// appendAncients appends the ancient items to the local freezer, as long as
+// they are contiguous with the local data. Otherwise they remain available
+// from the primary node.
+func (db *Replica) appendAncients(store ethdb.Database, ops []*Op) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	if head, err := store.Ancients(); err != nil || head != uint64(ops[0].Number) {
+		log.Debug("Skipped non-contiguous ancient items", "head", head, "number", ops[0].Number, "err", err)
+		return nil
+	}
+	_, err := store.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+		for _, item := range ops {
+			if item.Kind != OpAppend {
+				continue
+			}
+			if err := op.AppendRaw(item.Table, uint64(item.Number), item.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// replicaBatch is a batch which invalidates the cached keys once written.
+type replicaBatch struct {
+	ethdb.Batch
+	db *Replica
+}
+
+// Write implements ethdb.Batch.
+func (b *replicaBatch) Write() error {
+	var ops opRecorder
+	if err := b.Batch.Replay(&ops); err != nil {
+		return err
+	}
+	keys := make([][]byte, 0, len(ops))
+	for _, op := range ops {
+		keys = append(keys, op.Key)
+	}
+	defer b.db.invalidate(keys)
+	return b.Batch.Write()
+}
+
+// replicaAncientReader is an ancient reader falling back to the primary node
+// if the item is missing locally.
+type replicaAncientReader struct {
+	ethdb.AncientReaderOp
+	db *Replica
+}
+
+func (r *replicaAncientReader) HasAncient(kind string, number uint64) (bool, error) {
+	return r.db.hasAncient(r.AncientReaderOp, kind, number)
+}
+
+func (r *replicaAncientReader) Ancient(kind string, number uint64) ([]byte, error) {
+	return r.db.ancient(r.AncientReaderOp, kind, number)
+}
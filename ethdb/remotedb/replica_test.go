@@ -0,0 +1,309 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package remotedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testPrimaryAPI is a minimal debug API of a primary node.
+type testPrimaryAPI struct {
+	db      *Publisher
+	failing atomic.Bool // Whether the lookups fail regardless of the data
+}
+
+func (api *testPrimaryAPI) DbGet(key hexutil.Bytes) (hexutil.Bytes, error) {
+	if api.failing.Load() {
+		return nil, errors.New("lookup failed")
+	}
+	blob, err := api.db.Get(key)
+	if err != nil {
+		return nil, &NotFoundError{Message: err.Error()}
+	}
+	return blob, nil
+}
+
+func (api *testPrimaryAPI) DbAncient(kind string, number uint64) (hexutil.Bytes, error) {
+	if api.failing.Load() {
+		return nil, errors.New("lookup failed")
+	}
+	blob, err := api.db.Ancient(kind, number)
+	if err != nil {
+		return nil, &NotFoundError{Message: err.Error()}
+	}
+	return blob, nil
+}
+
+func (api *testPrimaryAPI) DbUpdates(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	var (
+		rpcSub     = notifier.CreateSubscription()
+		updates    = make(chan *Update, 128)
+		updatesSub = api.db.SubscribeUpdates(updates)
+	)
+	go func() {
+		defer updatesSub.Unsubscribe()
+		for {
+			select {
+			case update := <-updates:
+				notifier.Notify(rpcSub.ID, update)
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+func newTestDatabase(t *testing.T) ethdb.Database {
+	db, err := rawdb.NewDatabaseWithFreezer(memorydb.New(), "", "", false, true, false)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	return db
+}
+
+// waitFor polls the condition until it holds or the test times out.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	for deadline := time.Now().Add(30 * time.Second); time.Now().Before(deadline); {
+		if cond() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for %s", what)
+}
+
+func TestReplica(t *testing.T) {
+	primary := NewPublisher(newTestDatabase(t))
+	defer primary.Close()
+
+	api := &testPrimaryAPI{db: primary}
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("debug", api); err != nil {
+		t.Fatal(err)
+	}
+	// Write a key before the replica follows the primary, it must be resolved
+	// from the primary without being replicated.
+	primary.Put([]byte("early"), []byte("value"))
+
+	local := newTestDatabase(t)
+	replica := NewReplica(rpc.DialInProc(server), local, 16)
+	defer replica.Close()
+
+	waitFor(t, "subscription", primary.feed.active)
+
+	if blob, err := replica.Get([]byte("early")); err != nil || !bytes.Equal(blob, []byte("value")) {
+		t.Fatalf("Unexpected remote value: %x, %v", blob, err)
+	}
+	if ok, _ := local.Has([]byte("early")); ok {
+		t.Fatal("Remote value stored locally")
+	}
+	// Modify the primary, the updates must be replicated and the stale cache
+	// entries dropped.
+	primary.Put([]byte("early"), []byte("updated"))
+
+	batch := primary.NewBatch()
+	batch.Put([]byte("a"), []byte{0x1})
+	batch.Put([]byte("b"), []byte{0x2})
+	batch.Write()
+	primary.Delete([]byte("b"))
+
+	blocks := []*types.Block{types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)})}
+	if _, err := rawdb.WriteAncientBlocks(primary, blocks, []types.Receipts{nil}, big.NewInt(1)); err != nil {
+		t.Fatalf("Failed to write ancient blocks: %v", err)
+	}
+	waitFor(t, "replication", func() bool {
+		frozen, _ := local.Ancients()
+		return frozen == 1
+	})
+	if blob, err := replica.Get([]byte("early")); err != nil || !bytes.Equal(blob, []byte("updated")) {
+		t.Fatalf("Unexpected replicated value: %x, %v", blob, err)
+	}
+	if blob, err := local.Get([]byte("a")); err != nil || !bytes.Equal(blob, []byte{0x1}) {
+		t.Fatalf("Unexpected replicated batch value: %x, %v", blob, err)
+	}
+	if ok, _ := local.Has([]byte("b")); ok {
+		t.Fatal("Deleted key replicated")
+	}
+	if hash := rawdb.ReadCanonicalHash(replica, 0); hash != blocks[0].Hash() {
+		t.Fatalf("Unexpected replicated ancient hash: %x", hash)
+	}
+	// Local writes are permitted and invalidate the cache
+	replica.Put([]byte("a"), []byte{0x3})
+	if blob, _ := replica.Get([]byte("a")); !bytes.Equal(blob, []byte{0x3}) {
+		t.Fatalf("Unexpected local value: %x", blob)
+	}
+	// The failed lookups must be propagated without being cached as missing
+	api.failing.Store(true)
+	if _, err := replica.Has(common.Hash{}.Bytes()); err == nil {
+		t.Fatal("Failed lookup reported as missing")
+	}
+	if _, err := replica.Ancient(rawdb.ChainFreezerHashTable, 1); err == nil || errors.Is(err, errReplicaNotFound) {
+		t.Fatalf("Failed ancient lookup reported as missing: %v", err)
+	}
+	api.failing.Store(false)
+
+	if ok, err := replica.Has(common.Hash{}.Bytes()); ok || err != nil {
+		t.Fatalf("Missing key reported: %v", err)
+	}
+	if _, err := replica.Ancient(rawdb.ChainFreezerHashTable, 1); !errors.Is(err, errReplicaNotFound) {
+		t.Fatalf("Unexpected error of missing ancient item: %v", err)
+	}
+	// The head block marker changes must be signalled
+	rawdb.WriteHeadBlockHash(primary, blocks[0].Hash())
+	select {
+	case <-replica.Heads():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Head change not signalled")
+	}
+	// The missing key is cached until it's replicated
+	primary.Put(common.Hash{}.Bytes(), []byte{0x4})
+	waitFor(t, "replication", func() bool {
+		ok, _ := replica.Has(common.Hash{}.Bytes())
+		return ok
+	})
+}
+
+func TestReplicaGap(t *testing.T) {
+	primary := NewPublisher(newTestDatabase(t))
+	defer primary.Close()
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("debug", &testPrimaryAPI{db: primary}); err != nil {
+		t.Fatal(err)
+	}
+	local := newTestDatabase(t)
+	replica := NewReplica(rpc.DialInProc(server), local, 16)
+	defer replica.Close()
+
+	waitFor(t, "subscription", primary.feed.active)
+
+	primary.Put([]byte("a"), []byte{0x1})
+	waitFor(t, "replication", func() bool {
+		ok, _ := local.Has([]byte("a"))
+		return ok
+	})
+	if blob, err := replica.Get([]byte("a")); err != nil || !bytes.Equal(blob, []byte{0x1}) {
+		t.Fatalf("Unexpected replicated value: %x, %v", blob, err)
+	}
+	// Skip an update, the replica must stop serving the lookups
+	primary.feed.lock.Lock()
+	primary.feed.seq++
+	primary.feed.lock.Unlock()
+
+	primary.Put([]byte("b"), []byte{0x2})
+	waitFor(t, "stale replica", replica.stale.Load)
+
+	if _, err := replica.Get([]byte("a")); !errors.Is(err, ErrReplicaStale) {
+		t.Fatalf("Unexpected error of stale lookup: %v", err)
+	}
+	if _, err := replica.Has([]byte("b")); !errors.Is(err, ErrReplicaStale) {
+		t.Fatalf("Unexpected error of stale existence check: %v", err)
+	}
+	if _, err := replica.Ancient(rawdb.ChainFreezerHashTable, 0); !errors.Is(err, ErrReplicaStale) {
+		t.Fatalf("Unexpected error of stale ancient lookup: %v", err)
+	}
+	if !rawdb.ReadReplicaStale(local) {
+		t.Fatal("Stale replica not flagged")
+	}
+	if ok, _ := local.Has([]byte("b")); ok {
+		t.Fatal("Update applied after the gap")
+	}
+}
+
+func TestReplicaLargeUpdate(t *testing.T) {
+	primary := NewPublisher(newTestDatabase(t))
+	defer primary.Close()
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("debug", &testPrimaryAPI{db: primary}); err != nil {
+		t.Fatal(err)
+	}
+	httpsrv := httptest.NewServer(server.WebsocketHandler([]string{"*"}, 0))
+	defer httpsrv.Close()
+
+	local := newTestDatabase(t)
+	replica, err := DialReplica("ws:"+strings.TrimPrefix(httpsrv.URL, "http:"), local, 16)
+	if err != nil {
+		t.Fatalf("Failed to dial primary: %v", err)
+	}
+	defer replica.Close()
+
+	waitFor(t, "subscription", primary.feed.active)
+
+	// Write a batch exceeding the default websocket message limit once hex encoded
+	var (
+		batch = primary.NewBatch()
+		value = bytes.Repeat([]byte{0xff}, 1024*1024)
+	)
+	for i := byte(0); i < 17; i++ {
+		batch.Put([]byte{i}, value)
+	}
+	batch.Write()
+
+	waitFor(t, "replication", func() bool {
+		ok, _ := local.Has([]byte{16})
+		return ok
+	})
+	if replica.stale.Load() {
+		t.Fatal("Replica stale after a large update")
+	}
+}
+
+func TestPublisherSequence(t *testing.T) {
+	primary := NewPublisher(newTestDatabase(t))
+	defer primary.Close()
+
+	// The writes without subscribers advance the sequence numbers too
+	primary.Put([]byte("a"), []byte{0x1})
+
+	updates := make(chan *Update, 1)
+	sub := primary.SubscribeUpdates(updates)
+	defer sub.Unsubscribe()
+
+	// The updates must be dropped for a subscriber falling behind, without
+	// blocking the writes
+	primary.Put([]byte("b"), []byte{0x2})
+	primary.Delete([]byte("a"))
+	if update := <-updates; update.Seq != 2 || update.Ops[0].Kind != OpPut {
+		t.Fatalf("Unexpected update: seq %d, kind %s", update.Seq, update.Ops[0].Kind)
+	}
+	primary.Delete([]byte("b"))
+	if update := <-updates; update.Seq != 4 || update.Ops[0].Kind != OpDelete {
+		t.Fatalf("Unexpected update: seq %d, kind %s", update.Seq, update.Ops[0].Kind)
+	}
+}
@@ -42,17 +42,23 @@ import (
 // The structure can be summarized through this definition:
 //
 //	era1 := Version | block-tuple* | other-entries* | Accumulator | BlockIndex
-//	block-tuple :=  CompressedHeader | CompressedBody | CompressedReceipts | TotalDifficulty
+//	block-tuple :=  CompressedHeader | CompressedBody | CompressedReceipts | TotalDifficulty | CompressedBlobSidecars?
 //
 // Each basic element is its own entry:
 //
-//	Version            = { type: [0x65, 0x32], data: nil }
-//	CompressedHeader   = { type: [0x03, 0x00], data: snappyFramed(rlp(header)) }
-//	CompressedBody     = { type: [0x04, 0x00], data: snappyFramed(rlp(body)) }
-//	CompressedReceipts = { type: [0x05, 0x00], data: snappyFramed(rlp(receipts)) }
-//	TotalDifficulty    = { type: [0x06, 0x00], data: uint256(header.total_difficulty) }
-//	AccumulatorRoot    = { type: [0x07, 0x00], data: accumulator-root }
-//	BlockIndex         = { type: [0x32, 0x66], data: block-index }
+//	Version                = { type: [0x65, 0x32], data: nil }
+//	CompressedHeader       = { type: [0x03, 0x00], data: snappyFramed(rlp(header)) }
+//	CompressedBody         = { type: [0x04, 0x00], data: snappyFramed(rlp(body)) }
+//	CompressedReceipts     = { type: [0x05, 0x00], data: snappyFramed(rlp(receipts)) }
+//	TotalDifficulty        = { type: [0x06, 0x00], data: uint256(header.total_difficulty) }
+//	AccumulatorRoot        = { type: [0x07, 0x00], data: accumulator-root }
+//	CompressedBlobSidecars = { type: [0x08, 0x00], data: snappyFramed(rlp(sidecars)) }
+//	BlockIndex             = { type: [0x32, 0x66], data: block-index }
+//
+// The blob sidecars entry is only present for the blocks carrying sidecars,
+// i.e. the blocks after Cancun whose blobs are still within the retention
+// window at the time of the export. It follows the total difficulty so that
+// the fixed layout of the rest of the block tuple is retained.
 //
 // Accumulator is computed by constructing an SSZ list of header-records of length at most
 // 8192 and then calculating the hash_tree_root of that list.
@@ -94,8 +100,9 @@ func NewBuilder(w io.Writer) *Builder {
 	}
 }
 
-// Add writes a compressed block entry and compressed receipts entry to the
-// underlying e2store file.
+// Add writes a compressed block entry, compressed receipts entry and compressed
+// blob sidecars entry, if the block carries sidecars, to the underlying e2store
+// file.
 func (b *Builder) Add(block *types.Block, receipts types.Receipts, td *big.Int) error {
 	eh, err := rlp.EncodeToBytes(block.Header())
 	if err != nil {
@@ -109,12 +116,19 @@ func (b *Builder) Add(block *types.Block, receipts types.Receipts, td *big.Int)
 	if err != nil {
 		return err
 	}
-	return b.AddRLP(eh, eb, er, block.NumberU64(), block.Hash(), td, block.Difficulty())
+	var es []byte
+	if sidecars := block.Sidecars(); sidecars != nil {
+		if es, err = rlp.EncodeToBytes(sidecars); err != nil {
+			return err
+		}
+	}
+	return b.AddRLP(eh, eb, er, es, block.NumberU64(), block.Hash(), td, block.Difficulty())
 }
 
-// AddRLP writes a compressed block entry and compressed receipts entry to the
-// underlying e2store file.
-func (b *Builder) AddRLP(header, body, receipts []byte, number uint64, hash common.Hash, td, difficulty *big.Int) error {
+// AddRLP writes a compressed block entry, compressed receipts entry and
+// compressed blob sidecars entry, if sidecars is non-nil, to the underlying
+// e2store file.
+func (b *Builder) AddRLP(header, body, receipts, sidecars []byte, number uint64, hash common.Hash, td, difficulty *big.Int) error {
 	// Write Era1 version entry before first block.
 	if b.startNum == nil {
 		n, err := b.w.Write(TypeVersion, nil)
@@ -153,6 +167,12 @@ func (b *Builder) AddRLP(header, body, receipts []byte, number uint64, hash comm
 		return err
 	}
 
+	// Write the blob sidecars at last, if any.
+	if sidecars != nil {
+		if err := b.snappyWrite(TypeCompressedBlobSidecars, sidecars); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
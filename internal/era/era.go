@@ -36,13 +36,14 @@ import (
 )
 
 var (
-	TypeVersion            uint16 = 0x3265
-	TypeCompressedHeader   uint16 = 0x03
-	TypeCompressedBody     uint16 = 0x04
-	TypeCompressedReceipts uint16 = 0x05
-	TypeTotalDifficulty    uint16 = 0x06
-	TypeAccumulator        uint16 = 0x07
-	TypeBlockIndex         uint16 = 0x3266
+	TypeVersion                uint16 = 0x3265
+	TypeCompressedHeader       uint16 = 0x03
+	TypeCompressedBody         uint16 = 0x04
+	TypeCompressedReceipts     uint16 = 0x05
+	TypeTotalDifficulty        uint16 = 0x06
+	TypeAccumulator            uint16 = 0x07
+	TypeCompressedBlobSidecars uint16 = 0x08
+	TypeBlockIndex             uint16 = 0x3266
 
 	MaxEra1Size = 8192
 )
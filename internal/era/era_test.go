@@ -30,6 +30,7 @@ type testchain struct {
 	headers  [][]byte
 	bodies   [][]byte
 	receipts [][]byte
+	sidecars [][]byte
 	tds      []*big.Int
 }
 
@@ -51,6 +52,11 @@ func TestEra1Builder(t *testing.T) {
 		chain.headers = append(chain.headers, []byte{byte('h'), byte(i)})
 		chain.bodies = append(chain.bodies, []byte{byte('b'), byte(i)})
 		chain.receipts = append(chain.receipts, []byte{byte('r'), byte(i)})
+		if i%2 == 0 {
+			chain.sidecars = append(chain.sidecars, []byte{byte('s'), byte(i)})
+		} else {
+			chain.sidecars = append(chain.sidecars, nil)
+		}
 		chain.tds = append(chain.tds, big.NewInt(int64(i)))
 	}
 
@@ -60,10 +66,11 @@ func TestEra1Builder(t *testing.T) {
 			header   = chain.headers[i]
 			body     = chain.bodies[i]
 			receipts = chain.receipts[i]
+			sidecars = chain.sidecars[i]
 			hash     = common.Hash{byte(i)}
 			td       = chain.tds[i]
 		)
-		if err = builder.AddRLP(header, body, receipts, uint64(i), hash, td, big.NewInt(1)); err != nil {
+		if err = builder.AddRLP(header, body, receipts, sidecars, uint64(i), hash, td, big.NewInt(1)); err != nil {
 			t.Fatalf("error adding entry: %v", err)
 		}
 	}
@@ -123,6 +130,24 @@ func TestEra1Builder(t *testing.T) {
 		if td.Cmp(chain.tds[i]) != 0 {
 			t.Fatalf("mismatched tds: want %s, got %s", chain.tds[i], td)
 		}
+
+		// Check blob sidecars.
+		if chain.sidecars[i] == nil {
+			if it.BlobSidecars != nil {
+				t.Fatalf("unexpected sidecars in block %d", i)
+			}
+			continue
+		}
+		if it.BlobSidecars == nil {
+			t.Fatalf("missing sidecars in block %d", i)
+		}
+		sidecars, err := io.ReadAll(it.BlobSidecars)
+		if err != nil {
+			t.Fatalf("error reading sidecars: %v", err)
+		}
+		if !bytes.Equal(sidecars, chain.sidecars[i]) {
+			t.Fatalf("mismatched sidecars: want %s, got %s", chain.sidecars[i], sidecars)
+		}
 	}
 }
 
@@ -86,6 +86,19 @@ func (it *Iterator) Receipts() (types.Receipts, error) {
 	return receipts, err
 }
 
+// BlobSidecars returns the blob sidecars for the iterator's current position,
+// nil if the block was archived without sidecars.
+func (it *Iterator) BlobSidecars() (types.BlobSidecars, error) {
+	if it.inner.BlobSidecars == nil {
+		return nil, nil
+	}
+	sidecars := make(types.BlobSidecars, 0)
+	if err := rlp.Decode(it.inner.BlobSidecars, &sidecars); err != nil {
+		return nil, err
+	}
+	return sidecars, nil
+}
+
 // BlockAndReceipts returns the block and receipts for the iterator's current
 // position.
 func (it *Iterator) BlockAndReceipts() (*types.Block, types.Receipts, error) {
@@ -120,6 +133,7 @@ type RawIterator struct {
 	Body            io.Reader
 	Receipts        io.Reader
 	TotalDifficulty io.Reader
+	BlobSidecars    io.Reader // Nil if the block has no blob sidecars entry
 }
 
 // NewRawIterator returns a new RawIterator instance. Next must be immediately
@@ -133,8 +147,9 @@ func NewRawIterator(e *Era) (*RawIterator, error) {
 
 // Next moves the iterator to the next block entry. It returns false when all
 // items have been read or an error has halted its progress. Header, Body,
-// Receipts, TotalDifficulty will be set to nil in the case returning false or
-// finding an error and should therefore no longer be read from.
+// Receipts, TotalDifficulty and BlobSidecars will be set to nil in the case
+// returning false or finding an error and should therefore no longer be read
+// from.
 func (it *RawIterator) Next() bool {
 	// Clear old errors.
 	it.err = nil
@@ -166,10 +181,28 @@ func (it *RawIterator) Next() bool {
 		return true
 	}
 	off += n
-	if it.TotalDifficulty, _, it.err = it.e.s.ReaderAt(TypeTotalDifficulty, off); it.err != nil {
+	var m int
+	if it.TotalDifficulty, m, it.err = it.e.s.ReaderAt(TypeTotalDifficulty, off); it.err != nil {
+		it.clear()
+		return true
+	}
+	off += int64(m)
+
+	// The blob sidecars entry is optional, peek the type of the next entry.
+	// There's always a next one as the block index is the last entry.
+	typ, _, err := it.e.s.ReadMetadataAt(off)
+	if err != nil {
 		it.clear()
+		it.err = err
 		return true
 	}
+	it.BlobSidecars = nil
+	if typ == TypeCompressedBlobSidecars {
+		if it.BlobSidecars, _, it.err = newSnappyReader(it.e.s, TypeCompressedBlobSidecars, off); it.err != nil {
+			it.clear()
+			return true
+		}
+	}
 	it.next += 1
 	return true
 }
@@ -194,4 +227,5 @@ func (it *RawIterator) clear() {
 	it.Body = nil
 	it.Receipts = nil
 	it.TotalDifficulty = nil
+	it.BlobSidecars = nil
 }
@@ -2048,6 +2048,45 @@ func (api *TransactionAPI) SendRawTransactionConditional(ctx context.Context, in
 	if err := TxOptsCheck(opts, header.Number.Uint64(), header.Time, state); err != nil {
 		return common.Hash{}, err
 	}
+	// Keep the conditions with the transaction for the pool and the miner to
+	// enforce them until its inclusion.
+	tx.SetOptions(&opts)
+	return SubmitTransaction(ctx, api.b, tx)
+}
+
+// defaultPrivateTxBlocks is the number of blocks a private transaction stays
+// private when the sender sets no expiry.
+const defaultPrivateTxBlocks = 25
+
+// PrivateTransactionArgs are the options of a private transaction.
+type PrivateTransactionArgs struct {
+	MaxBlockNumber *hexutil.Uint64 `json:"maxBlockNumber"` // Last block which may include it privately
+	Fallback       bool            `json:"fallback"`       // Whether to broadcast it once expired, rather than dropping it
+}
+
+// SendPrivateRawTransaction will add the signed transaction to the transaction
+// pool without announcing it to the peers. It is included by the local miner or
+// forwarded to the trusted endpoints only, until its expiry.
+func (api *TransactionAPI) SendPrivateRawTransaction(ctx context.Context, input hexutil.Bytes, args *PrivateTransactionArgs) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	if tx.Type() == types.BlobTxType {
+		return common.Hash{}, errors.New("private blob transactions are not supported")
+	}
+	head := api.b.CurrentHeader().Number.Uint64()
+	opts := &types.PrivateOpts{MaxBlockNumber: head + defaultPrivateTxBlocks}
+	if args != nil {
+		if args.MaxBlockNumber != nil {
+			opts.MaxBlockNumber = uint64(*args.MaxBlockNumber)
+		}
+		opts.Fallback = args.Fallback
+	}
+	if opts.MaxBlockNumber <= head {
+		return common.Hash{}, fmt.Errorf("max block number %d already reached, head is %d", opts.MaxBlockNumber, head)
+	}
+	tx.SetPrivate(opts)
 	return SubmitTransaction(ctx, api.b, tx)
 }
 
@@ -2329,6 +2368,12 @@ func (api *DebugAPI) ChaindbCompact() error {
 	return nil
 }
 
+// GetDoubleSignEvidence returns the conflicting header pairs detected by the
+// double sign monitor at the given height.
+func (api *DebugAPI) GetDoubleSignEvidence(number hexutil.Uint64) []*types.DoubleSignEvidence {
+	return rawdb.ReadDoubleSignEvidences(api.b.ChainDb(), uint64(number), uint64(number))
+}
+
 // SetHead rewinds the head of the blockchain to a previous block.
 func (api *DebugAPI) SetHead(number hexutil.Uint64) {
 	api.b.SetHead(uint64(number))
@@ -95,6 +95,12 @@ func (m *MevAPI) HasBuilder(builder common.Address) bool {
 	return m.b.HasBuilder(builder)
 }
 
+// GetBuilderStats returns the scores of the builders, derived from their bids.
+// The builders scoring below the threshold are quarantined for some blocks.
+func (m *MevAPI) GetBuilderStats() []*types.BuilderStats {
+	return m.b.BuilderStats()
+}
+
 // Running returns true if mev is running
 func (m *MevAPI) Running() bool {
 	return m.b.MevRunning()
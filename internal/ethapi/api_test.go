@@ -50,6 +50,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -632,6 +633,9 @@ func (b testBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transactio
 func (b testBackend) SubscribeNewTxsEvent(events chan<- core.NewTxsEvent) event.Subscription {
 	panic("implement me")
 }
+func (b testBackend) SubscribeTxPoolEvents(events chan<- []*txpool.TxEvent) event.Subscription {
+	panic("implement me")
+}
 func (b testBackend) ChainConfig() *params.ChainConfig             { return b.chain.Config() }
 func (b testBackend) Engine() consensus.Engine                     { return b.chain.Engine() }
 func (b testBackend) CurrentValidators() ([]common.Address, error) { return []common.Address{}, nil }
@@ -654,6 +658,7 @@ func (b testBackend) ServiceFilter(ctx context.Context, session *bloombits.Match
 
 func (b *testBackend) MevRunning() bool                       { return false }
 func (b *testBackend) HasBuilder(builder common.Address) bool { return false }
+func (b *testBackend) BuilderStats() []*types.BuilderStats    { return nil }
 func (b *testBackend) MevParams() *types.MevParams {
 	return &types.MevParams{}
 }
@@ -17,23 +17,59 @@
 package ethapi
 
 import (
+	"context"
+	"errors"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/gopool"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethdb/remotedb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
-// DbGet returns the raw value of a key stored in the database.
+// dbUpdatesBuffer is the number of database updates buffered for a subscriber.
+// The updates are dropped for a subscriber falling behind further, which detects
+// the gap in the sequence numbers.
+const dbUpdatesBuffer = 4096
+
+// dbUpdatePublisher is implemented by databases publishing their modifications.
+type dbUpdatePublisher interface {
+	SubscribeUpdates(ch chan<- *remotedb.Update) event.Subscription
+}
+
+// DbGet returns the raw value of a key stored in the database. A missing key is
+// reported with a remotedb.NotFoundError, for the read replicas to tell it apart
+// from the other failures.
 func (api *DebugAPI) DbGet(key string) (hexutil.Bytes, error) {
 	blob, err := common.ParseHexOrString(key)
 	if err != nil {
 		return nil, err
 	}
-	return api.b.ChainDb().Get(blob)
+	db := api.b.ChainDb()
+	value, err := db.Get(blob)
+	if err != nil {
+		if has, herr := db.Has(blob); herr == nil && !has {
+			return nil, &remotedb.NotFoundError{Message: err.Error()}
+		}
+		return nil, err
+	}
+	return value, nil
 }
 
 // DbAncient retrieves an ancient binary blob from the append-only immutable files.
-// It is a mapping to the `AncientReaderOp.Ancient` method
+// It is a mapping to the `AncientReaderOp.Ancient` method. A missing item is
+// reported with a remotedb.NotFoundError, like in DbGet.
 func (api *DebugAPI) DbAncient(kind string, number uint64) (hexutil.Bytes, error) {
-	return api.b.ChainDb().Ancient(kind, number)
+	db := api.b.ChainDb()
+	blob, err := db.Ancient(kind, number)
+	if err != nil {
+		if has, herr := db.HasAncient(kind, number); herr == nil && !has {
+			return nil, &remotedb.NotFoundError{Message: err.Error()}
+		}
+		return nil, err
+	}
+	return blob, nil
 }
 
 // DbAncients returns the ancient item numbers in the ancient store.
@@ -41,3 +77,35 @@ func (api *DebugAPI) DbAncient(kind string, number uint64) (hexutil.Bytes, error
 func (api *DebugAPI) DbAncients() (uint64, error) {
 	return api.b.ChainDb().Ancients()
 }
+
+// DbUpdates creates a subscription that is fired with the database modifications
+// committed on the node, used to keep read replicas in sync. It's only available
+// if the node is configured to publish its database updates.
+func (api *DebugAPI) DbUpdates(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	publisher, ok := api.b.ChainDb().(dbUpdatePublisher)
+	if !ok {
+		return &rpc.Subscription{}, errors.New("database updates are not published")
+	}
+	var (
+		rpcSub     = notifier.CreateSubscription()
+		updates    = make(chan *remotedb.Update, dbUpdatesBuffer)
+		updatesSub = publisher.SubscribeUpdates(updates)
+	)
+	gopool.Submit(func() {
+		defer updatesSub.Unsubscribe()
+
+		for {
+			select {
+			case update := <-updates:
+				notifier.Notify(rpcSub.ID, update)
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	})
+	return rpcSub, nil
+}
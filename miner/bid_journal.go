@@ -0,0 +1,341 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// Kinds of the bid journal entries.
+const (
+	BidJournalReceived  = "received"  // A bid arrived, accepted for simulation or not
+	BidJournalSimulated = "simulated" // The simulation of a bid ended
+	BidJournalWinner    = "winner"    // The block sealed on a parent was chosen
+)
+
+// bidJournalQueue is the number of entries buffered for writing, the entries
+// recorded beyond are dropped rather than delaying the bids.
+const bidJournalQueue = 1024
+
+// bidJournalRotated is the suffix of the previous journal, moved aside once the
+// current one reaches its maximum size.
+const bidJournalRotated = ".old"
+
+var (
+	bidJournalWrittenMeter = metrics.NewRegisteredMeter("bid/journal/written", nil)
+	bidJournalDroppedMeter = metrics.NewRegisteredMeter("bid/journal/dropped", nil)
+)
+
+// BidJournalEntry is a record of the bid journal, a JSON object per line.
+type BidJournalEntry struct {
+	Kind        string         `json:"kind"`
+	Time        int64          `json:"time"` // Unix milliseconds of the arrival, end of simulation or choice
+	BlockNumber uint64         `json:"blockNumber"`
+	ParentHash  common.Hash    `json:"parentHash"`
+	BidHash     common.Hash    `json:"bidHash"` // Zero for the winner entries of the local blocks
+	Builder     common.Address `json:"builder"`
+
+	// Fields of the received bids
+	Args *types.BidArgs `json:"args,omitempty"`
+
+	// Fields of the simulated bids
+	Header     *types.Header `json:"header,omitempty"` // Header of the block before executing the bid
+	Commission uint64        `json:"commission,omitempty"`
+	Txs        int           `json:"txs,omitempty"` // Transactions of the block, merged ones included
+	GasUsed    uint64        `json:"gasUsed,omitempty"`
+	Elapsed    time.Duration `json:"elapsed,omitempty"` // Duration of the simulation
+
+	// Fields of the simulated bids and winners
+	BlockReward     *big.Int `json:"blockReward,omitempty"`
+	ValidatorReward *big.Int `json:"validatorReward,omitempty"`
+	LocalReward     *big.Int `json:"localReward,omitempty"` // Reward of the best local block
+
+	Error string `json:"error,omitempty"`
+}
+
+// bidJournal appends the entries to a file in the background. A nil journal
+// discards the entries.
+//
+// Once the file reaches the maximum size, it's moved aside, replacing the
+// previous one, and a new file is started. The journal thus takes at most
+// twice the maximum size on disk.
+type bidJournal struct {
+	path    string
+	maxSize uint64 // Maximum size of the file before rotation, 0 for unlimited
+	file    *os.File
+	writer  *bufio.Writer
+	size    uint64 // Size of the file, written or buffered
+
+	entries chan *BidJournalEntry
+	dropped atomic.Uint64 // Entries dropped since the last report
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// newBidJournal opens the journal at the given path for appending, rotating it
+// past the given size.
+func newBidJournal(path string, maxSize uint64) (*bidJournal, error) {
+	journal := &bidJournal{
+		path:    path,
+		maxSize: maxSize,
+		entries: make(chan *BidJournalEntry, bidJournalQueue),
+		quit:    make(chan struct{}),
+	}
+	if err := journal.open(); err != nil {
+		return nil, err
+	}
+	journal.wg.Add(1)
+	go journal.loop()
+	return journal, nil
+}
+
+// open opens the file of the journal for appending.
+func (journal *bidJournal) open() error {
+	file, err := os.OpenFile(journal.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	journal.file, journal.writer, journal.size = file, bufio.NewWriter(file), uint64(stat.Size())
+	return nil
+}
+
+// rotate moves the full journal aside and starts a new one.
+func (journal *bidJournal) rotate() error {
+	if err := journal.writer.Flush(); err != nil {
+		return err
+	}
+	if err := journal.file.Close(); err != nil {
+		return err
+	}
+	journal.file, journal.writer = nil, nil
+	if err := os.Rename(journal.path, journal.path+bidJournalRotated); err != nil {
+		return err
+	}
+	return journal.open()
+}
+
+func (journal *bidJournal) loop() {
+	defer journal.wg.Done()
+
+	write := func(entry *BidJournalEntry) {
+		if journal.file == nil {
+			return // Failed rotation, journaling disabled
+		}
+		blob, err := json.Marshal(entry)
+		if err != nil {
+			log.Warn("Failed to encode bid journal entry", "err", err)
+			return
+		}
+		n, err := journal.writer.Write(append(blob, '\n'))
+		journal.size += uint64(n)
+		if err != nil {
+			log.Warn("Failed to write bid journal", "err", err)
+			return
+		}
+		bidJournalWrittenMeter.Mark(1)
+
+		if journal.maxSize > 0 && journal.size >= journal.maxSize {
+			if err := journal.rotate(); err != nil {
+				log.Error("Failed to rotate bid journal, journaling disabled", "path", journal.path, "err", err)
+			}
+		}
+	}
+	flush := func() {
+		if journal.file != nil {
+			journal.writer.Flush()
+		}
+		if dropped := journal.dropped.Swap(0); dropped > 0 {
+			log.Warn("Bid journal queue full, entries dropped", "dropped", dropped)
+		}
+	}
+	for {
+		select {
+		case entry := <-journal.entries:
+			write(entry)
+			// Flush once the burst of entries is written
+			if len(journal.entries) == 0 {
+				flush()
+			}
+		case <-journal.quit:
+			for len(journal.entries) > 0 {
+				write(<-journal.entries)
+			}
+			flush()
+			if journal.file != nil {
+				journal.file.Close()
+			}
+			return
+		}
+	}
+}
+
+// record queues the entry for writing.
+func (journal *bidJournal) record(entry *BidJournalEntry) {
+	if journal == nil {
+		return
+	}
+	select {
+	case journal.entries <- entry:
+	default:
+		// Reported by the writer, to avoid flooding the logs during a burst
+		journal.dropped.Add(1)
+		bidJournalDroppedMeter.Mark(1)
+	}
+}
+
+// close writes the queued entries and closes the journal.
+func (journal *bidJournal) close() {
+	if journal == nil {
+		return
+	}
+	close(journal.quit)
+	journal.wg.Wait()
+}
+
+// ReadBidJournal returns the entries of the journal at the given path recorded
+// for the block of the given number, the rotated journal included.
+func ReadBidJournal(path string, number uint64) ([]*BidJournalEntry, error) {
+	rotated, err := readBidJournal(path+bidJournalRotated, number)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	entries, err := readBidJournal(path, number)
+	if err != nil {
+		return nil, err
+	}
+	return append(rotated, entries...), nil
+}
+
+func readBidJournal(path string, number uint64) ([]*BidJournalEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var (
+		entries []*BidJournalEntry
+		decoder = json.NewDecoder(file)
+		index   int
+	)
+	for ; decoder.More(); index++ {
+		entry := new(BidJournalEntry)
+		if err := decoder.Decode(entry); err != nil {
+			return nil, fmt.Errorf("invalid journal entry %d of %s: %v", index, path, err)
+		}
+		if entry.BlockNumber == number {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// BidReplay is the outcome of the replay of a journaled bid.
+type BidReplay struct {
+	BidHash         common.Hash    `json:"bidHash"`
+	Builder         common.Address `json:"builder"`
+	Txs             int            `json:"txs"`
+	GasUsed         uint64         `json:"gasUsed"`
+	BlockReward     *big.Int       `json:"blockReward"`
+	ValidatorReward *big.Int       `json:"validatorReward"`
+	Error           string         `json:"error,omitempty"`
+}
+
+// ReplayBid executes the bid again on the header it was simulated with, on top
+// of the state of its parent, the way the simulation does. The steps depending
+// on the live node are skipped: the time left is not checked, the gas price of
+// the bid is not checked against the pool, which is gone, and no pending
+// transactions are merged.
+//
+// The returned error reports the failure to replay, the failure of the bid is
+// part of the replay.
+func ReplayBid(chain *core.BlockChain, args *types.BidArgs, header *types.Header, commission uint64) (*BidReplay, error) {
+	if args.RawBid == nil {
+		return nil, errors.New("missing raw bid")
+	}
+	config := chain.Config()
+	builder, err := args.EcrecoverSender()
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %v", err)
+	}
+	bid, err := args.ToBid(builder, types.MakeSigner(config, header.Number, header.Time))
+	if err != nil {
+		return nil, err
+	}
+	parent := chain.GetHeaderByHash(bid.ParentHash)
+	if parent == nil {
+		return nil, fmt.Errorf("missing parent %x", bid.ParentHash)
+	}
+	statedb, err := chain.StateAt(parent.Root)
+	if err != nil {
+		return nil, err
+	}
+	// Reset the header to its state before the simulation
+	header = types.CopyHeader(header)
+	header.GasUsed = 0
+	if header.BlobGasUsed != nil {
+		header.BlobGasUsed = new(uint64)
+	}
+	env := &environment{
+		signer:   types.MakeSigner(config, header.Number, header.Time),
+		state:    statedb,
+		coinbase: header.Coinbase,
+		header:   header,
+		evm:      vm.NewEVM(core.NewEVMBlockContext(header, chain, &header.Coinbase), statedb, config, vm.Config{}),
+	}
+	preExecute(config, parent, env)
+	env.gasPool = newBidGasPool(chain, chain.Engine(), header)
+
+	replay := &BidReplay{BidHash: bid.Hash(), Builder: builder}
+	bidRuntime, err := newBidRuntime(bid, commission)
+	if err == nil {
+		if len(bid.Txs) == 0 {
+			err = errors.New("empty bid")
+		} else {
+			bidRuntime.env = env
+			err = bidRuntime.execute(chain, config, commission, new(bidExecution))
+		}
+	}
+	if err != nil {
+		replay.Error = err.Error()
+	}
+	replay.Txs, replay.GasUsed = env.tcount, header.GasUsed
+	if bidRuntime != nil {
+		replay.BlockReward, replay.ValidatorReward = bidRuntime.packedBlockReward, bidRuntime.packedValidatorReward
+	}
+	return replay, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestBidJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bids.journal")
+	journal, err := newBidJournal(path, 0)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	rawBid := &types.RawBid{
+		BlockNumber: 2,
+		ParentHash:  common.HexToHash("0x01"),
+		Txs:         []hexutil.Bytes{{0x01, 0x02}},
+		GasUsed:     21000,
+		GasFee:      big.NewInt(100),
+		BuilderFee:  big.NewInt(1),
+	}
+	key, _ := crypto.GenerateKey()
+	signature, _ := crypto.Sign(rawBid.Hash().Bytes(), key)
+	args := &types.BidArgs{RawBid: rawBid, Signature: signature}
+
+	journal.record(&BidJournalEntry{Kind: BidJournalReceived, BlockNumber: 1})
+	journal.record(&BidJournalEntry{Kind: BidJournalReceived, BlockNumber: 2, BidHash: rawBid.Hash(), Args: args})
+	journal.record(&BidJournalEntry{
+		Kind:        BidJournalSimulated,
+		BlockNumber: 2,
+		BidHash:     rawBid.Hash(),
+		Header:      &types.Header{Number: big.NewInt(2), Difficulty: big.NewInt(2)},
+		BlockReward: big.NewInt(10),
+		Error:       "failed",
+	})
+	journal.close()
+
+	entries, err := ReadBidJournal(path, 2)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("entry count mismatch: have %d, want 2", len(entries))
+	}
+	received, simulated := entries[0], entries[1]
+	if received.Args == nil || received.Args.RawBid.Hash() != rawBid.Hash() {
+		t.Errorf("raw bid mismatch: have %v", received.Args)
+	}
+	if builder, err := received.Args.EcrecoverSender(); err != nil || builder != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Errorf("builder mismatch: have %x, %v", builder, err)
+	}
+	if simulated.Header == nil || simulated.Header.Number.Uint64() != 2 {
+		t.Errorf("header mismatch: have %v", simulated.Header)
+	}
+	if simulated.BlockReward.Cmp(big.NewInt(10)) != 0 || simulated.Error != "failed" {
+		t.Errorf("simulation mismatch: have reward %v, error %q", simulated.BlockReward, simulated.Error)
+	}
+
+	// A nil journal discards the entries
+	var disabled *bidJournal
+	disabled.record(&BidJournalEntry{})
+	disabled.close()
+}
+
+func TestBidJournalRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bids.journal")
+
+	// Each entry is larger than the maximum size, rotating the journal after
+	// every write. Only the last entry is kept, in the rotated journal.
+	journal, err := newBidJournal(path, 1)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		journal.record(&BidJournalEntry{Kind: BidJournalReceived, BlockNumber: 1, Time: int64(i)})
+	}
+	journal.close()
+
+	entries, err := ReadBidJournal(path, 1)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Time != 3 {
+		t.Fatalf("entries mismatch: have %d", len(entries))
+	}
+	// The entries written to the current journal and the rotated one are read
+	journal, err = newBidJournal(path, 1024)
+	if err != nil {
+		t.Fatalf("failed to reopen journal: %v", err)
+	}
+	journal.record(&BidJournalEntry{Kind: BidJournalReceived, BlockNumber: 1, Time: 4})
+	journal.close()
+
+	entries, err = ReadBidJournal(path, 1)
+	if err != nil {
+		t.Fatalf("failed to read journal: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Time != 3 || entries[1].Time != 4 {
+		t.Fatalf("entries mismatch: have %d", len(entries))
+	}
+}
@@ -29,10 +29,17 @@ import (
 	"github.com/ethereum/go-ethereum/miner/minerconfig"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
 )
 
 const prefetchTxNumber = 100
 
+const (
+	// builderStatsSaveInterval is the number of blocks between the saves of the
+	// builder scores.
+	builderStatsSaveInterval = 100
+)
+
 var (
 	bidPreCheckTimer     = metrics.NewRegisteredTimer("bid/preCheck", nil)
 	bidTryInterruptTimer = metrics.NewRegisteredTimer("bid/sim/tryInterrupt", nil)
@@ -63,8 +70,11 @@ var (
 		Timeout:   5 * time.Second,
 		Transport: transport,
 	}
-	errBetterBid  = errors.New("simulation abort due to better bid arrived")
-	errNoTimeLeft = errors.New("bid discarded due to lack of simulation time")
+	errBetterBid        = errors.New("simulation abort due to better bid arrived")
+	errNoTimeLeft       = errors.New("bid discarded due to lack of simulation time")
+	errOverstatedReward = errors.New("reward does not achieve the expectation")
+	errMinerExit        = errors.New("miner exit")
+	errPrepareWork      = errors.New("failed to prepare the block of the bid")
 )
 
 type bidWorker interface {
@@ -129,6 +139,9 @@ type bidSimulator struct {
 	bidsToSim     map[uint64][]*BidRuntime    // blockNumber -->  bidRuntime list, used to discard envs
 
 	maxBidsPerBuilder uint32 // Maximum number of bids allowed per builder per block
+
+	journal    *bidJournal        // Journal recording the bids, nil if disabled
+	reputation *builderReputation // Scores of the builders, quarantining the misbehaving ones
 }
 
 func newBidSimulator(
@@ -166,6 +179,31 @@ func newBidSimulator(
 		b.maxBidsPerBuilder = *config.MaxBidsPerBuilder
 	}
 
+	var (
+		threshold  = *minerconfig.DefaultMevConfig.BuilderScoreThreshold
+		quarantine uint64
+	)
+	if config.BuilderScoreThreshold != nil {
+		threshold = *config.BuilderScoreThreshold
+	}
+	if config.BuilderQuarantineBlocks != nil {
+		quarantine = *config.BuilderQuarantineBlocks
+	}
+	b.reputation = newBuilderReputation(config.BuilderStats, threshold, quarantine)
+
+	if config.Journal != "" {
+		maxSize := *minerconfig.DefaultMevConfig.JournalMaxSize
+		if config.JournalMaxSize != nil {
+			maxSize = *config.JournalMaxSize
+		}
+		journal, err := newBidJournal(config.Journal, maxSize)
+		if err != nil {
+			log.Error("BidSimulator: failed to open bid journal", "path", config.Journal, "err", err)
+		} else {
+			b.journal = journal
+		}
+	}
+
 	b.chainHeadSub = b.chain.SubscribeChainHeadEvent(b.chainHeadCh)
 
 	if config.Enabled != nil && *config.Enabled {
@@ -213,6 +251,8 @@ func (b *bidSimulator) stop() {
 func (b *bidSimulator) close() {
 	b.running.Store(false)
 	close(b.exitCh)
+	b.journal.close()
+	b.reputation.save()
 }
 
 func (b *bidSimulator) isRunning() bool {
@@ -275,6 +315,24 @@ func (b *bidSimulator) ExistBuilder(builder common.Address) bool {
 	return ok
 }
 
+// CheckQuarantine returns an error if the builder is quarantined at the given block.
+func (b *bidSimulator) CheckQuarantine(builder common.Address, blockNumber uint64) error {
+	if until, ok := b.reputation.quarantined(builder, blockNumber); ok {
+		return fmt.Errorf("builder is quarantined until block %d", until)
+	}
+	return nil
+}
+
+// RecordLateBid penalizes the builder for a bid arriving after its deadline.
+func (b *bidSimulator) RecordLateBid(builder common.Address, blockNumber uint64) {
+	b.reputation.recordLate(builder, blockNumber)
+}
+
+// BuilderStats returns the scores of the builders.
+func (b *bidSimulator) BuilderStats() []*types.BuilderStats {
+	return b.reputation.list()
+}
+
 // best bid here is based on packedBlockReward after the bid is simulated
 func (b *bidSimulator) SetBestBid(prevBlockHash common.Hash, bid *BidRuntime) {
 	b.bestBidMu.Lock()
@@ -571,6 +629,9 @@ func (b *bidSimulator) clearLoop() {
 	}
 
 	for head := range b.chainHeadCh {
+		if head.Header.Number.Uint64()%builderStatsSaveInterval == 0 {
+			b.reputation.save()
+		}
 		if !b.isRunning() {
 			continue
 		}
@@ -655,12 +716,11 @@ func (b *bidSimulator) simBid(interruptCh chan int32, bidRuntime *BidRuntime) {
 		parentHash  = bidRuntime.bid.ParentHash
 		builder     = bidRuntime.bid.Builder
 
-		bidTxs   = bidRuntime.bid.Txs
-		bidTxLen = len(bidTxs)
-		payBidTx = bidTxs[bidTxLen-1]
+		bidTxLen = len(bidRuntime.bid.Txs)
 
 		err     error
 		success bool
+		header  *types.Header // header before the execution of the bid, for the journal
 	)
 
 	// ensure simulation exited then start next simulation
@@ -684,10 +744,22 @@ func (b *bidSimulator) simBid(interruptCh chan int32, bidRuntime *BidRuntime) {
 			logCtx = append(logCtx, "err", err)
 			log.Info("BidSimulator: simulation failed", logCtx...)
 			if !errors.Is(errBetterBid, err) && !errors.Is(errNoTimeLeft, err) {
+				// The builder is only penalized for the faults of the bid,
+				// not for the failures of the local node.
+				switch {
+				case errors.Is(err, errOverstatedReward):
+					b.reputation.recordOverstated(builder, blockNumber)
+				case !errors.Is(err, errMinerExit) && !errors.Is(err, errPrepareWork):
+					b.reputation.recordFailed(builder, blockNumber)
+				}
 				go b.reportIssue(bidRuntime, err)
 			}
+		} else {
+			b.reputation.recordSimulated(builder)
 		}
 
+		b.journalSimulation(bidRuntime, header, time.Since(simStart), err)
+
 		b.RemoveSimulatingBid(parentHash)
 		close(bidRuntime.finished)
 
@@ -718,8 +790,10 @@ func (b *bidSimulator) simBid(interruptCh chan int32, bidRuntime *BidRuntime) {
 		parentHash: bidRuntime.bid.ParentHash,
 		coinbase:   b.bidWorker.etherbase(),
 	}, false); err != nil {
+		err = fmt.Errorf("%w: %v", errPrepareWork, err)
 		return
 	}
+	header = types.CopyHeader(bidRuntime.env.header)
 	b.AddBidToSim(bidRuntime)
 
 	// if the left time is not enough to do simulation, return
@@ -731,132 +805,57 @@ func (b *bidSimulator) simBid(interruptCh chan int32, bidRuntime *BidRuntime) {
 
 	gasLimit := bidRuntime.env.header.GasLimit
 	if bidRuntime.env.gasPool == nil {
-		bidRuntime.env.gasPool = new(core.GasPool).AddGas(gasLimit)
-		if p, ok := b.engine.(*parlia.Parlia); ok {
-			bidRuntime.env.gasPool.SubGas(p.EstimateGasReservedForSystemTxs(b.chain, bidRuntime.env.header))
-		}
-		bidRuntime.env.gasPool.SubGas(params.PayBidTxGasLimit)
-	}
-
-	// error log:
-	// 	simulation failed blockNumber=47630147 parentHash=0x2476bcc93db4c924a2c8079c6d5d783441a72d6ff70c5850b1afd778102e175e builder=0x48a5Ed9abC1a8FBe86ceC4900483f43a7f2dBB48
-	// 	gasUsed=136807406 gasLimit=137816878 err="gas used exceeds gas limit"
-	// error tracing:
-	// 	left: b.RawBid.GasUsed + b.PayBidTxGasUsed => (136782406 + 25000 = 136807406)
-	// 	right: headerGasLimit - b.PayBidTxGasLimit - systemGasReserved => (137816878 - 25000 - 1000000 = 136791878)
-	// 	cause: 136807406 > 136791878 => true
-	// error reason:
-	//	left should not be added with PayBidTxGasUsed, Or right should be not be subtracted with PayBidTxGasLimit
-	// error fix:
-	//	136782406 > 136791878 => false, Or 136807406 > 136816878 => false
-	if bidRuntime.bid.GasUsed > bidRuntime.env.gasPool.Gas() {
-		err = errors.New("gas used exceeds gas limit")
-		return
-	}
-
-	if len(b.bidsToSim[bidRuntime.bid.BlockNumber]) == 1 {
-		bidSim1stBidTimer.UpdateSince(time.UnixMilli(int64(b.chain.GetHeaderByHash(bidRuntime.bid.ParentHash).MilliTimestamp())))
-	}
-
-	if len(bidRuntime.bid.Txs) > prefetchTxNumber {
-		interruptPrefetchCh := make(chan struct{})
-		defer close(interruptPrefetchCh)
-		throwaway := bidRuntime.env.state.CopyDoPrefetch()
-		// Disable tracing for prefetcher executions.
-		vmCfg := *b.chain.GetVMConfig()
-		vmCfg.Tracer = nil
-		go b.bidWorker.getPrefetcher().Prefetch(bidRuntime.bid.Txs, bidRuntime.env.header, gasLimit, throwaway, &vmCfg, interruptPrefetchCh)
-	}
-
-	// commit transactions in bid
-	for _, tx := range bidRuntime.bid.Txs {
-		select {
-		case <-interruptCh:
-			err = errBetterBid
-			return
-
-		case <-b.exitCh:
-			err = errors.New("miner exit")
-			return
-
-		default:
-		}
-
-		if bidRuntime.env.tcount == bidTxLen-1 {
-			break
-		}
-
-		err = bidRuntime.commitTransaction(b.chain, b.chainConfig, tx, bidRuntime.bid.UnRevertible.Contains(tx.Hash()))
-		if err != nil {
-			log.Error("BidSimulator: failed to commit tx", "bidHash", bidRuntime.bid.Hash(), "tx", tx.Hash(), "err", err)
-			err = fmt.Errorf("invalid tx in bid, %v", err)
-			return
-		}
-	}
-
-	// check whether time `NoInterruptLeftOver-delayLeftOver` is enough for simulating
-	delay = b.engine.Delay(b.chain, bidRuntime.env.header, &b.delayLeftOver)
-	if delay != nil && *delay < 0 {
-		bidSimTimeoutCounter.Inc(1)
-		err = errNoTimeLeft
-		return
-	}
-
-	// check if bid reward is valid
-	{
-		bidRuntime.packReward(*b.config.ValidatorCommission)
-		if !bidRuntime.validReward() {
-			err = errors.New("reward does not achieve the expectation")
-			return
-		}
+		bidRuntime.env.gasPool = newBidGasPool(b.chain, b.engine, bidRuntime.env.header)
 	}
 
-	// check if bid gas price is lower than min gas price
-	{
-		bidGasUsed := uint64(0)
-		bidGasFee := big.NewInt(0)
-
-		for i, receipt := range bidRuntime.env.receipts {
-			tx := bidRuntime.env.txs[i]
-			if !b.txpool.Has(tx.Hash()) {
-				bidGasUsed += receipt.GasUsed
-				effectiveTip, er := tx.EffectiveGasTip(bidRuntime.env.header.BaseFee)
-				if er != nil {
-					err = errors.New("failed to calculate effective tip")
-					return
-				}
+	interruptPrefetchCh := make(chan struct{})
+	defer close(interruptPrefetchCh)
 
-				if bidRuntime.env.header.BaseFee != nil {
-					effectiveTip.Add(effectiveTip, bidRuntime.env.header.BaseFee)
-				}
-
-				gasFee := new(big.Int).Mul(effectiveTip, new(big.Int).SetUint64(receipt.GasUsed))
-				bidGasFee.Add(bidGasFee, gasFee)
-
-				if tx.Type() == types.BlobTxType {
-					blobFee := new(big.Int).Mul(receipt.BlobGasPrice, new(big.Int).SetUint64(receipt.BlobGasUsed))
-					bidGasFee.Add(bidGasFee, blobFee)
-				}
+	greedyMergeElapsed := time.Duration(0)
+	exec := &bidExecution{
+		start: func() {
+			if len(b.bidsToSim[bidRuntime.bid.BlockNumber]) == 1 {
+				bidSim1stBidTimer.UpdateSince(time.UnixMilli(int64(b.chain.GetHeaderByHash(bidRuntime.bid.ParentHash).MilliTimestamp())))
 			}
-		}
-
-		// if bid txs are all from mempool, do not check gas price
-		if bidGasUsed != 0 {
-			bidGasPrice := new(big.Int).Div(bidGasFee, new(big.Int).SetUint64(bidGasUsed))
-			if bidGasPrice.Cmp(b.minGasPrice) < 0 {
-				err = fmt.Errorf("bid gas price is lower than min gas price, bid:%v, min:%v", bidGasPrice, b.minGasPrice)
-				return
+			if len(bidRuntime.bid.Txs) > prefetchTxNumber {
+				throwaway := bidRuntime.env.state.CopyDoPrefetch()
+				// Disable tracing for prefetcher executions.
+				vmCfg := *b.chain.GetVMConfig()
+				vmCfg.Tracer = nil
+				go b.bidWorker.getPrefetcher().Prefetch(bidRuntime.bid.Txs, bidRuntime.env.header, gasLimit, throwaway, &vmCfg, interruptPrefetchCh)
 			}
-		}
+		},
+		interrupt: func() error {
+			select {
+			case <-interruptCh:
+				return errBetterBid
+			case <-b.exitCh:
+				return errMinerExit
+			default:
+				return nil
+			}
+		},
+		timeLeft: func() error {
+			// check whether time `NoInterruptLeftOver-delayLeftOver` is enough for simulating
+			delay := b.engine.Delay(b.chain, bidRuntime.env.header, &b.delayLeftOver)
+			if delay != nil && *delay < 0 {
+				bidSimTimeoutCounter.Inc(1)
+				return errNoTimeLeft
+			}
+			return nil
+		},
+		inPool:      b.txpool.Has,
+		minGasPrice: b.minGasPrice,
 	}
-
 	// if enable greedy merge, fill bid env with transactions from mempool
-	greedyMergeElapsed := time.Duration(0)
 	if *b.config.GreedyMergeTx {
-		endingBidsExtra := 20 * time.Millisecond // Add a buffer to ensure ending bids before `delayLeftOver`
-		minTimeLeftForEndingBids := b.delayLeftOver + endingBidsExtra
-		delay := b.engine.Delay(b.chain, bidRuntime.env.header, &minTimeLeftForEndingBids)
-		if delay != nil && *delay > 0 {
+		exec.merge = func() {
+			endingBidsExtra := 20 * time.Millisecond // Add a buffer to ensure ending bids before `delayLeftOver`
+			minTimeLeftForEndingBids := b.delayLeftOver + endingBidsExtra
+			delay := b.engine.Delay(b.chain, bidRuntime.env.header, &minTimeLeftForEndingBids)
+			if delay == nil || *delay <= 0 {
+				return
+			}
 			greedyMergeStartTs := time.Now()
 			bidTxsSet := mapset.NewThreadUnsafeSetWithSize[common.Hash](len(bidRuntime.bid.Txs))
 			for _, tx := range bidRuntime.bid.Txs {
@@ -874,14 +873,7 @@ func (b *bidSimulator) simBid(interruptCh chan int32, bidRuntime *BidRuntime) {
 				"builder", bidRuntime.bid.Builder, "tx count", bidRuntime.env.tcount-bidTxLen+1, "err", fillErr, "greedyMergeElapsed", greedyMergeElapsed)
 		}
 	}
-
-	// commit payBidTx at the end of the block
-	bidRuntime.env.gasPool.AddGas(params.PayBidTxGasLimit)
-	err = bidRuntime.commitTransaction(b.chain, b.chainConfig, payBidTx, true)
-	if err != nil {
-		log.Error("BidSimulator: failed to commit tx", "builder", bidRuntime.bid.Builder,
-			"bidHash", bidRuntime.bid.Hash(), "tx", payBidTx.Hash(), "err", err)
-		err = fmt.Errorf("invalid tx in bid, %v", err)
+	if err = bidRuntime.execute(b.chain, b.chainConfig, *b.config.ValidatorCommission, exec); err != nil {
 		return
 	}
 
@@ -916,6 +908,9 @@ func (b *bidSimulator) simBid(interruptCh chan int32, bidRuntime *BidRuntime) {
 
 	// this is the simplest strategy: best for all the delegators.
 	if bestBid == nil || bidRuntime.packedBlockReward.Cmp(bestBid.packedBlockReward) > 0 {
+		// Snapshot the block for the readers of the best bid, the environment
+		// being modified further by the sealing.
+		bidRuntime.gasUsed, bidRuntime.txHashes = bidRuntime.env.header.GasUsed, txHashes(bidRuntime.env.txs)
 		b.SetBestBid(bidRuntime.bid.ParentHash, bidRuntime)
 		bidRuntime.duration = time.Since(startTS)
 		bidSimTimer.UpdateSince(startTS)
@@ -923,6 +918,74 @@ func (b *bidSimulator) simBid(interruptCh chan int32, bidRuntime *BidRuntime) {
 	}
 }
 
+// journalBid records the arrival of a bid, and the error rejecting it if any.
+func (b *bidSimulator) journalBid(args *types.BidArgs, builder common.Address, receiveTime time.Time, err error) {
+	if b.journal == nil || args.RawBid == nil {
+		return
+	}
+	entry := &BidJournalEntry{
+		Kind:        BidJournalReceived,
+		Time:        receiveTime.UnixMilli(),
+		BlockNumber: args.RawBid.BlockNumber,
+		ParentHash:  args.RawBid.ParentHash,
+		BidHash:     args.RawBid.Hash(),
+		Builder:     builder,
+		Args:        args,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	b.journal.record(entry)
+}
+
+// journalSimulation records the outcome of the simulation of a bid, which took
+// the given time.
+func (b *bidSimulator) journalSimulation(bidRuntime *BidRuntime, header *types.Header, elapsed time.Duration, err error) {
+	if b.journal == nil {
+		return
+	}
+	entry := &BidJournalEntry{
+		Kind:            BidJournalSimulated,
+		Time:            time.Now().UnixMilli(),
+		BlockNumber:     bidRuntime.bid.BlockNumber,
+		ParentHash:      bidRuntime.bid.ParentHash,
+		BidHash:         bidRuntime.bid.Hash(),
+		Builder:         bidRuntime.bid.Builder,
+		Header:          header,
+		Commission:      *b.config.ValidatorCommission,
+		Elapsed:         elapsed,
+		BlockReward:     bidRuntime.packedBlockReward,
+		ValidatorReward: bidRuntime.packedValidatorReward,
+	}
+	if bidRuntime.env != nil {
+		entry.Txs, entry.GasUsed = bidRuntime.env.tcount, bidRuntime.env.header.GasUsed
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	b.journal.record(entry)
+}
+
+// journalWinner records the block chosen for sealing on the parent, built from
+// the winning bid, or locally if nil.
+func (b *bidSimulator) journalWinner(number uint64, parentHash common.Hash, winner *BidRuntime, localReward *big.Int) {
+	if b.journal == nil {
+		return
+	}
+	entry := &BidJournalEntry{
+		Kind:        BidJournalWinner,
+		Time:        time.Now().UnixMilli(),
+		BlockNumber: number,
+		ParentHash:  parentHash,
+		LocalReward: localReward,
+	}
+	if winner != nil {
+		entry.BidHash, entry.Builder = winner.bid.Hash(), winner.bid.Builder
+		entry.BlockReward, entry.ValidatorReward = winner.packedBlockReward, winner.packedValidatorReward
+	}
+	b.journal.record(entry)
+}
+
 // reportIssue reports the issue to the mev-sentry
 func (b *bidSimulator) reportIssue(bidRuntime *BidRuntime, err error) {
 	metrics.GetOrRegisterCounter(fmt.Sprintf("bid/err/%v", bidRuntime.bid.Builder), nil).Inc(1)
@@ -953,6 +1016,9 @@ type BidRuntime struct {
 	packedBlockReward     *big.Int
 	packedValidatorReward *big.Int
 
+	gasUsed  uint64        // Gas used by the simulated block, set once the bid is the best one
+	txHashes []common.Hash // Transactions of the simulated block, set once the bid is the best one
+
 	finished chan struct{}
 	duration time.Duration
 }
@@ -993,6 +1059,13 @@ func (r *BidRuntime) isExpectedBetterThan(other *BidRuntime) bool {
 		r.expectedValidatorReward.Cmp(other.expectedValidatorReward) >= 0
 }
 
+// isBetterThanLocal returns whether the packed bid beats the local block of the
+// given reward, both for the delegators and for the validator.
+func (r *BidRuntime) isBetterThanLocal(localBlockReward *uint256.Int, validatorCommission uint64) bool {
+	return localBlockReward.CmpBig(r.packedBlockReward) < 0 &&
+		validatorShare(localBlockReward, validatorCommission).CmpBig(r.packedValidatorReward) < 0
+}
+
 // packReward calculates packedBlockReward and packedValidatorReward
 func (r *BidRuntime) packReward(validatorCommission uint64) {
 	r.packedBlockReward = r.env.state.GetBalance(consensus.SystemAddress).ToBig()
@@ -1001,6 +1074,137 @@ func (r *BidRuntime) packReward(validatorCommission uint64) {
 	r.packedValidatorReward.Sub(r.packedValidatorReward, r.bid.BuilderFee)
 }
 
+// commitBidTxs commits the transactions of the bid but the payment one at its
+// end, checking for an abort before each of them.
+func (r *BidRuntime) commitBidTxs(chain *core.BlockChain, chainConfig *params.ChainConfig, abort func() error) error {
+	bidTxLen := len(r.bid.Txs)
+	for _, tx := range r.bid.Txs {
+		if err := abort(); err != nil {
+			return err
+		}
+
+		if r.env.tcount == bidTxLen-1 {
+			break
+		}
+
+		err := r.commitTransaction(chain, chainConfig, tx, r.bid.UnRevertible.Contains(tx.Hash()))
+		if err != nil {
+			log.Error("BidSimulator: failed to commit tx", "bidHash", r.bid.Hash(), "tx", tx.Hash(), "err", err)
+			return fmt.Errorf("invalid tx in bid, %v", err)
+		}
+	}
+	return nil
+}
+
+// bidExecution holds the steps of the execution of a bid depending on the live
+// node. They are all skipped by the offline replay of a bid, which leaves them
+// nil.
+type bidExecution struct {
+	start       func()                 // Called once the bid is known to fit in the block
+	interrupt   func() error           // Checked before each transaction of the bid
+	timeLeft    func() error           // Checked once the transactions of the bid are executed
+	inPool      func(common.Hash) bool // Reports the transactions known to the pool, exempt from the gas price check
+	minGasPrice *big.Int               // Minimum gas price of the transactions of the bid
+	merge       func()                 // Fills the block with the transactions of the pool
+}
+
+// execute executes the bid on its environment and checks its outcome, the
+// payment to the builder being committed last.
+func (r *BidRuntime) execute(chain *core.BlockChain, chainConfig *params.ChainConfig, commission uint64, exec *bidExecution) error {
+	// error log:
+	// 	simulation failed blockNumber=47630147 parentHash=0x2476bcc93db4c924a2c8079c6d5d783441a72d6ff70c5850b1afd778102e175e builder=0x48a5Ed9abC1a8FBe86ceC4900483f43a7f2dBB48
+	// 	gasUsed=136807406 gasLimit=137816878 err="gas used exceeds gas limit"
+	// error tracing:
+	// 	left: b.RawBid.GasUsed + b.PayBidTxGasUsed => (136782406 + 25000 = 136807406)
+	// 	right: headerGasLimit - b.PayBidTxGasLimit - systemGasReserved => (137816878 - 25000 - 1000000 = 136791878)
+	// 	cause: 136807406 > 136791878 => true
+	// error reason:
+	//	left should not be added with PayBidTxGasUsed, Or right should be not be subtracted with PayBidTxGasLimit
+	// error fix:
+	//	136782406 > 136791878 => false, Or 136807406 > 136816878 => false
+	if r.bid.GasUsed > r.env.gasPool.Gas() {
+		return errors.New("gas used exceeds gas limit")
+	}
+	if exec.start != nil {
+		exec.start()
+	}
+	// commit transactions in bid
+	interrupt := exec.interrupt
+	if interrupt == nil {
+		interrupt = func() error { return nil }
+	}
+	if err := r.commitBidTxs(chain, chainConfig, interrupt); err != nil {
+		return err
+	}
+	if exec.timeLeft != nil {
+		if err := exec.timeLeft(); err != nil {
+			return err
+		}
+	}
+	// check if bid reward is valid
+	r.packReward(commission)
+	if !r.validReward() {
+		return errOverstatedReward
+	}
+	// check if bid gas price is lower than min gas price
+	if exec.inPool != nil {
+		if err := r.checkGasPrice(exec.inPool, exec.minGasPrice); err != nil {
+			return err
+		}
+	}
+	if exec.merge != nil {
+		exec.merge()
+	}
+	// commit payBidTx at the end of the block
+	payBidTx := r.bid.Txs[len(r.bid.Txs)-1]
+	r.env.gasPool.AddGas(params.PayBidTxGasLimit)
+	if err := r.commitTransaction(chain, chainConfig, payBidTx, true); err != nil {
+		log.Error("BidSimulator: failed to commit tx", "builder", r.bid.Builder,
+			"bidHash", r.bid.Hash(), "tx", payBidTx.Hash(), "err", err)
+		return fmt.Errorf("invalid tx in bid, %v", err)
+	}
+	return nil
+}
+
+// checkGasPrice checks the average gas price of the transactions of the bid
+// unknown to the pool against the minimum gas price.
+func (r *BidRuntime) checkGasPrice(inPool func(common.Hash) bool, minGasPrice *big.Int) error {
+	bidGasUsed := uint64(0)
+	bidGasFee := big.NewInt(0)
+
+	for i, receipt := range r.env.receipts {
+		tx := r.env.txs[i]
+		if !inPool(tx.Hash()) {
+			bidGasUsed += receipt.GasUsed
+			effectiveTip, err := tx.EffectiveGasTip(r.env.header.BaseFee)
+			if err != nil {
+				return errors.New("failed to calculate effective tip")
+			}
+
+			if r.env.header.BaseFee != nil {
+				effectiveTip.Add(effectiveTip, r.env.header.BaseFee)
+			}
+
+			gasFee := new(big.Int).Mul(effectiveTip, new(big.Int).SetUint64(receipt.GasUsed))
+			bidGasFee.Add(bidGasFee, gasFee)
+
+			if tx.Type() == types.BlobTxType {
+				blobFee := new(big.Int).Mul(receipt.BlobGasPrice, new(big.Int).SetUint64(receipt.BlobGasUsed))
+				bidGasFee.Add(bidGasFee, blobFee)
+			}
+		}
+	}
+
+	// if bid txs are all from mempool, do not check gas price
+	if bidGasUsed != 0 {
+		bidGasPrice := new(big.Int).Div(bidGasFee, new(big.Int).SetUint64(bidGasUsed))
+		if bidGasPrice.Cmp(minGasPrice) < 0 {
+			return fmt.Errorf("bid gas price is lower than min gas price, bid:%v, min:%v", bidGasPrice, minGasPrice)
+		}
+	}
+	return nil
+}
+
 func (r *BidRuntime) commitTransaction(chain *core.BlockChain, chainConfig *params.ChainConfig, tx *types.Transaction, unRevertible bool) error {
 	var (
 		env = r.env
@@ -1049,6 +1253,17 @@ func (r *BidRuntime) commitTransaction(chain *core.BlockChain, chainConfig *para
 	return nil
 }
 
+// newBidGasPool creates the gas pool of a bid, with the gas of the system
+// transactions and of the payment to the builder reserved.
+func newBidGasPool(chain *core.BlockChain, engine consensus.Engine, header *types.Header) *core.GasPool {
+	gasPool := new(core.GasPool).AddGas(header.GasLimit)
+	if p, ok := engine.(*parlia.Parlia); ok {
+		gasPool.SubGas(p.EstimateGasReservedForSystemTxs(chain, header))
+	}
+	gasPool.SubGas(params.PayBidTxGasLimit)
+	return gasPool
+}
+
 func weiToEtherStringF6(wei *big.Int) string {
 	f, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(params.Ether)).Float64()
 	return strconv.FormatFloat(f, 'f', 6, 64)
@@ -0,0 +1,303 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/parlia"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/miner/builderclient"
+	"github.com/ethereum/go-ethereum/miner/minerconfig"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// bidderBuildTimeout bounds the filling of the block of a bid, leaving the
+	// validator time to simulate the bid before sealing.
+	bidderBuildTimeout = 500 * time.Millisecond
+
+	// bidderRequestTimeout bounds the requests to the validators.
+	bidderRequestTimeout = 2 * time.Second
+)
+
+var (
+	errEmptyBid        = errors.New("no fees to bid")
+	errBidUnderPriced  = errors.New("average gas price below the minimum of the validator")
+	bidderSentMeter    = metrics.NewRegisteredMeter("bidder/sent", nil)
+	bidderFailedMeter  = metrics.NewRegisteredMeter("bidder/failed", nil)
+	bidderIssuedMeter  = metrics.NewRegisteredMeter("bidder/issued", nil)
+	bidderBuildTimer   = metrics.NewRegisteredTimer("bidder/build", nil)
+	bidderGasFeeGauge  = metrics.NewRegisteredGauge("bidder/gasFee", nil) // gwei
+	bidderGasUsedGauge = metrics.NewRegisteredGauge("bidder/gasUsed", nil)
+)
+
+// bidder is the built-in builder. On each new head, it builds a block with the
+// worker and sends it as a bid to the next in-turn validator, if configured.
+type bidder struct {
+	config   *minerconfig.BidderConfig
+	worker   *worker
+	account  accounts.Account
+	signFn   parlia.SignerFn   // Signs the raw bids
+	signTxFn parlia.SignerTxFn // Signs the payment transactions
+
+	validators map[common.Address]*builderclient.Client
+	issues     *builderclient.IssueServer // Nil if not listening for issues
+
+	chainHeadCh  chan core.ChainHeadEvent
+	chainHeadSub event.Subscription
+	exitCh       chan struct{}
+	wg           sync.WaitGroup
+}
+
+// newBidder dials the validators, starts listening for the issues of the bids
+// and starts bidding on the new heads.
+func newBidder(config *minerconfig.BidderConfig, worker *worker, signFn parlia.SignerFn, signTxFn parlia.SignerTxFn) (*bidder, error) {
+	b := &bidder{
+		config:      config,
+		worker:      worker,
+		account:     accounts.Account{Address: config.Account},
+		signFn:      signFn,
+		signTxFn:    signTxFn,
+		validators:  make(map[common.Address]*builderclient.Client),
+		chainHeadCh: make(chan core.ChainHeadEvent, chainHeadChanSize),
+		exitCh:      make(chan struct{}),
+	}
+	for _, validator := range config.Validators {
+		cli, err := builderclient.DialOptions(context.Background(), validator.URL, rpc.WithHTTPClient(client))
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial validator %v: %v", validator.Address, err)
+		}
+		b.validators[validator.Address] = cli
+	}
+	if config.IssueListenAddr != "" {
+		issues, err := builderclient.NewIssueServer(config.IssueListenAddr, b.handleIssue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen for bid issues: %v", err)
+		}
+		b.issues = issues
+	}
+	b.chainHeadSub = worker.chain.SubscribeChainHeadEvent(b.chainHeadCh)
+
+	b.wg.Add(1)
+	go b.loop()
+
+	log.Info("Bidder started", "builder", config.Account, "validators", len(b.validators))
+	return b, nil
+}
+
+func (b *bidder) loop() {
+	defer b.wg.Done()
+	defer b.chainHeadSub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-b.chainHeadCh:
+			b.bid(ev.Header)
+		case <-b.chainHeadSub.Err():
+			return
+		case <-b.exitCh:
+			return
+		}
+	}
+}
+
+// close stops bidding and listening for issues.
+func (b *bidder) close() {
+	close(b.exitCh)
+	b.wg.Wait()
+	if b.issues != nil {
+		b.issues.Close()
+	}
+}
+
+// bid builds a block on the given parent and sends it to the validator in turn
+// for the next block, if it is one of the configured validators.
+func (b *bidder) bid(parent *types.Header) {
+	// Skip the stale heads and the heads imported while syncing
+	if b.worker.syncing.Load() || parent.Hash() != b.worker.chain.CurrentBlock().Hash() {
+		return
+	}
+	validator, err := b.worker.engine.NextInTurnValidator(b.worker.chain, parent)
+	if err != nil {
+		log.Debug("Bidder: failed to get next in-turn validator", "parent", parent.Hash(), "err", err)
+		return
+	}
+	cli := b.validators[validator]
+	if cli == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), bidderRequestTimeout)
+	defer cancel()
+
+	mevParams, err := cli.MevParams(ctx)
+	if err != nil {
+		log.Warn("Bidder: failed to get mev params", "validator", validator, "err", err)
+		return
+	}
+	args, err := b.buildBid(parent, validator, mevParams)
+	if err != nil {
+		log.Debug("Bidder: no bid built", "block", parent.Number.Uint64()+1, "validator", validator, "err", err)
+		return
+	}
+	hash, err := cli.SendBid(ctx, *args)
+	if err != nil {
+		bidderFailedMeter.Mark(1)
+		log.Warn("Bidder: failed to send bid", "block", args.RawBid.BlockNumber, "validator", validator, "err", err)
+		return
+	}
+	bidderSentMeter.Mark(1)
+	log.Info("Bidder: bid sent", "block", args.RawBid.BlockNumber, "validator", validator, "bid", hash.TerminalString(),
+		"txs", len(args.RawBid.Txs), "gasUsed", args.RawBid.GasUsed,
+		"gasFee", weiToEtherStringF6(args.RawBid.GasFee), "builderFee", weiToEtherStringF6(args.RawBid.BuilderFee))
+}
+
+// buildBid fills a block on the given parent from the transaction pool, executed
+// as the validator will simulate it, and signs it as a bid. The block is built
+// silently, apart from the sealing work of the worker.
+func (b *bidder) buildBid(parent *types.Header, validator common.Address, mevParams *types.MevParams) (*types.BidArgs, error) {
+	start := time.Now()
+	w := b.worker
+	work, err := w.prepareWork(&generateParams{
+		timestamp:  uint64(time.Now().Unix()),
+		parentHash: parent.Hash(),
+		coinbase:   validator,
+		silent:     true,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+	defer work.discard()
+
+	// Execute the transactions with the coinbase of the validator, keeping the
+	// gas of the system transactions and of the payment free.
+	work.header.Coinbase = validator
+	work.evm.Context.Coinbase = validator
+	work.gasPool = newBidGasPool(w.chain, w.engine, work.header)
+
+	stopTimer := time.NewTimer(bidderBuildTimeout)
+	defer stopTimer.Stop()
+
+	err = w.fillTransactions(nil, work, stopTimer, nil)
+	if err != nil && !errors.Is(err, errBlockInterruptedByTimeout) && !errors.Is(err, errBlockInterruptedByOutOfGas) {
+		return nil, err
+	}
+	bidderBuildTimer.UpdateSince(start)
+
+	gasFee := work.state.GetBalance(consensus.SystemAddress).ToBig()
+	if len(work.txs) == 0 || gasFee.Sign() == 0 {
+		return nil, errEmptyBid
+	}
+	if mevParams.GasPrice != nil && gasFee.Cmp(new(big.Int).Mul(mevParams.GasPrice, new(big.Int).SetUint64(work.header.GasUsed))) < 0 {
+		return nil, errBidUnderPriced
+	}
+	bidderGasFeeGauge.Update(new(big.Int).Div(gasFee, big.NewInt(params.GWei)).Int64())
+	bidderGasUsedGauge.Update(int64(work.header.GasUsed))
+
+	// The blob transactions lost their sidecars once executed, attach them back
+	txs := make([]*types.Transaction, len(work.txs))
+	copy(txs, work.txs)
+	for _, sidecar := range work.sidecars {
+		txs[sidecar.TxIndex] = txs[sidecar.TxIndex].WithBlobTxSidecar(&sidecar.BlobTxSidecar)
+	}
+	rawBid := &types.RawBid{
+		BlockNumber: work.header.Number.Uint64(),
+		ParentHash:  parent.Hash(),
+		Txs:         make([]hexutil.Bytes, len(txs)),
+		GasUsed:     work.header.GasUsed,
+		GasFee:      gasFee,
+		BuilderFee:  b.builderFee(gasFee, mevParams),
+	}
+	for i, tx := range txs {
+		if rawBid.Txs[i], err = tx.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+	payBidTx, err := b.payBidTx(work, validator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign payment: %v", err)
+	}
+	blob, err := rlp.EncodeToBytes(rawBid)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := b.signFn(b.account, accounts.MimetypeBid, blob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign bid: %v", err)
+	}
+	return &types.BidArgs{
+		RawBid:          rawBid,
+		Signature:       signature,
+		PayBidTx:        payBidTx,
+		PayBidTxGasUsed: params.TxGas,
+	}, nil
+}
+
+// builderFee returns the fee claimed for a bid of the given gas fee: the
+// configured one, capped by the ceiling of the validator and by its reward.
+func (b *bidder) builderFee(gasFee *big.Int, mevParams *types.MevParams) *big.Int {
+	fee := new(big.Int)
+	if b.config.Fee != nil {
+		fee.Set(b.config.Fee)
+	}
+	if mevParams.BuilderFeeCeil != nil && fee.Cmp(mevParams.BuilderFeeCeil) > 0 {
+		fee.Set(mevParams.BuilderFeeCeil)
+	}
+	validatorReward := new(big.Int).Mul(gasFee, new(big.Int).SetUint64(mevParams.ValidatorCommission))
+	validatorReward.Div(validatorReward, big.NewInt(10000))
+	if fee.Cmp(validatorReward) > 0 {
+		fee.Set(validatorReward)
+	}
+	return fee
+}
+
+// payBidTx signs the payment transaction closing the bid. The fees of the block
+// already go to the validator, so the payment is an empty transfer from the
+// builder to the validator, at the base fee.
+func (b *bidder) payBidTx(work *environment, validator common.Address) (hexutil.Bytes, error) {
+	gasPrice := new(big.Int)
+	if work.header.BaseFee != nil {
+		gasPrice.Set(work.header.BaseFee)
+	}
+	tx := types.NewTransaction(work.state.GetNonce(b.account.Address), validator, common.Big0, params.TxGas, gasPrice, nil)
+	signed, err := b.signTxFn(b.account, tx, b.worker.chainConfig.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
+// handleIssue records an issue reported by a validator with a bid.
+func (b *bidder) handleIssue(issue *types.BidIssue) {
+	bidderIssuedMeter.Mark(1)
+	log.Warn("Bidder: bid issue reported", "validator", issue.Validator, "bid", issue.BidHash, "message", issue.Message)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/miner/minerconfig"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestBidderBuilderFee(t *testing.T) {
+	tests := []struct {
+		fee        *big.Int
+		ceil       *big.Int
+		commission uint64
+		gasFee     int64
+		want       int64
+	}{
+		{nil, nil, 100, 10000, 0},                            // no fee configured
+		{big.NewInt(50), nil, 100, 10000, 50},                // fee within the reward of the validator
+		{big.NewInt(50), big.NewInt(20), 100, 10000, 20},     // capped by the ceiling of the validator
+		{big.NewInt(500), big.NewInt(1000), 100, 10000, 100}, // capped by the reward of the validator
+	}
+	for i, tt := range tests {
+		b := &bidder{config: &minerconfig.BidderConfig{Fee: tt.fee}}
+		params := &types.MevParams{ValidatorCommission: tt.commission, BuilderFeeCeil: tt.ceil}
+		if have := b.builderFee(big.NewInt(tt.gasFee), params); have.Int64() != tt.want {
+			t.Errorf("test %d: fee mismatch, have %v, want %d", i, have, tt.want)
+		}
+	}
+}
+
+// Tests that the bidder signs the data the validators recover the builder from.
+func TestBidderSignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	builder := crypto.PubkeyToAddress(key.PublicKey)
+
+	rawBid := &types.RawBid{
+		BlockNumber: 1,
+		Txs:         []hexutil.Bytes{{0x01}},
+		GasUsed:     params.TxGas,
+		GasFee:      big.NewInt(100),
+		BuilderFee:  big.NewInt(1),
+	}
+	blob, err := rlp.EncodeToBytes(rawBid)
+	if err != nil {
+		t.Fatalf("failed to encode bid: %v", err)
+	}
+	// Sign as the keystore wallets do, hashing the data
+	signature, err := crypto.Sign(crypto.Keccak256(blob), key)
+	if err != nil {
+		t.Fatalf("failed to sign bid: %v", err)
+	}
+	args := &types.BidArgs{RawBid: rawBid, Signature: signature}
+	if have, err := args.EcrecoverSender(); err != nil || have != builder {
+		t.Errorf("builder mismatch: have %v, want %v (err %v)", have, builder, err)
+	}
+}
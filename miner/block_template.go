@@ -0,0 +1,124 @@
+package miner
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+// BlockTemplate is a preview of the block the worker would build on a parent,
+// along with the best bid received for the same parent.
+type BlockTemplate struct {
+	ParentHash      common.Hash    `json:"parentHash"`
+	Number          hexutil.Uint64 `json:"number"`
+	Timestamp       hexutil.Uint64 `json:"timestamp"`
+	Coinbase        common.Address `json:"miner"`
+	GasLimit        hexutil.Uint64 `json:"gasLimit"`
+	GasUsed         hexutil.Uint64 `json:"gasUsed"`
+	Transactions    []common.Hash  `json:"transactions"`
+	BlockReward     *hexutil.Big   `json:"blockReward"`     // Fees collected by the block
+	ValidatorReward *hexutil.Big   `json:"validatorReward"` // Share of the fees claimed by the validator
+	BestBid         *BidTemplate   `json:"bestBid,omitempty"`
+}
+
+// BidTemplate is the outcome of the simulation of the best bid of a parent.
+type BidTemplate struct {
+	BidHash      common.Hash    `json:"bidHash"`
+	Builder      common.Address `json:"builder"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Transactions []common.Hash  `json:"transactions"` // Transactions of the bid and merged ones
+
+	// Rewards announced by the builder, ranking the bids before their simulation
+	ExpectedBlockReward     *hexutil.Big `json:"expectedBlockReward"`
+	ExpectedValidatorReward *hexutil.Big `json:"expectedValidatorReward"`
+
+	// Rewards packed by the simulation, compared with the local block
+	BlockReward     *hexutil.Big `json:"blockReward"`
+	ValidatorReward *hexutil.Big `json:"validatorReward"`
+
+	BetterThanLocal bool `json:"betterThanLocal"` // Whether the bid would be sealed rather than the local block
+}
+
+// validatorShare returns the share of the block reward claimed by the validator
+// with the given commission.
+func validatorShare(blockReward *uint256.Int, commission uint64) *uint256.Int {
+	share := new(uint256.Int).Mul(blockReward, uint256.NewInt(commission))
+	return share.Div(share, uint256.NewInt(10000))
+}
+
+// blockTemplate builds a block on the given parent in a dry run, filling it from
+// the transaction pool the way a sealing block is. The block is neither finalized
+// nor sealed.
+//
+// The dry run happens on the goroutine of the caller, not to delay the sealing
+// work, and is built silently: none of its logs are reported as pending.
+func (w *worker) blockTemplate(parentHash common.Hash) (*BlockTemplate, error) {
+	if w.chain.GetHeaderByHash(parentHash) == nil {
+		return nil, errors.New("missing parent")
+	}
+	work, err := w.prepareWork(&generateParams{
+		timestamp:  uint64(time.Now().Unix()),
+		parentHash: parentHash,
+		coinbase:   w.etherbase(),
+		silent:     true,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+	defer work.discard()
+
+	// Allow the dry run as much time as a recommit of the sealing block
+	stopTimer := time.NewTimer(*w.config.Recommit)
+	defer stopTimer.Stop()
+
+	err = w.fillTransactions(nil, work, stopTimer, nil)
+	if err != nil && !errors.Is(err, errBlockInterruptedByTimeout) && !errors.Is(err, errBlockInterruptedByOutOfGas) {
+		return nil, err
+	}
+	var (
+		commission  = *w.config.Mev.ValidatorCommission
+		blockReward = work.state.GetBalance(consensus.SystemAddress)
+		template    = &BlockTemplate{
+			ParentHash:      parentHash,
+			Number:          hexutil.Uint64(work.header.Number.Uint64()),
+			Timestamp:       hexutil.Uint64(work.header.Time),
+			Coinbase:        work.header.Coinbase,
+			GasLimit:        hexutil.Uint64(work.header.GasLimit),
+			GasUsed:         hexutil.Uint64(work.header.GasUsed),
+			Transactions:    txHashes(work.txs),
+			BlockReward:     (*hexutil.Big)(blockReward.ToBig()),
+			ValidatorReward: (*hexutil.Big)(validatorShare(blockReward, commission).ToBig()),
+		}
+	)
+	if w.bidFetcher == nil {
+		return template, nil
+	}
+	if bid := w.bidFetcher.GetBestBid(parentHash); bid != nil {
+		template.BestBid = &BidTemplate{
+			BidHash:                 bid.bid.Hash(),
+			Builder:                 bid.bid.Builder,
+			GasUsed:                 hexutil.Uint64(bid.gasUsed),
+			Transactions:            bid.txHashes,
+			ExpectedBlockReward:     (*hexutil.Big)(bid.expectedBlockReward),
+			ExpectedValidatorReward: (*hexutil.Big)(bid.expectedValidatorReward),
+			BlockReward:             (*hexutil.Big)(bid.packedBlockReward),
+			ValidatorReward:         (*hexutil.Big)(bid.packedValidatorReward),
+			BetterThanLocal:         bid.isBetterThanLocal(blockReward, commission),
+		}
+	}
+	return template, nil
+}
+
+// txHashes returns the hashes of the transactions.
+func txHashes(txs []*types.Transaction) []common.Hash {
+	hashes := make([]common.Hash, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash()
+	}
+	return hashes
+}
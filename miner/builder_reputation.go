@@ -0,0 +1,197 @@
+package miner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// The score of a builder starts at the maximum, decreases by a penalty for each
+// misbehaving bid and increases by a reward for each bid simulated successfully.
+const (
+	maxBuilderScore = 100
+
+	builderFailedPenalty     = 10 // Bid failing the simulation
+	builderOverstatedPenalty = 5  // Bid paying less than its announced gas fee
+	builderLatePenalty       = 2  // Bid arriving after the deadline of its block
+	builderSimulatedReward   = 1  // Bid simulated successfully
+)
+
+var builderQuarantinedMeter = metrics.NewRegisteredMeter("bid/builder/quarantined", nil)
+
+// builderReputation tracks the score of the builders, and quarantines the ones
+// falling below the threshold for a number of blocks. Once quarantined, the
+// score of a builder is restored, the builder starting over after the release.
+type builderReputation struct {
+	path       string // File persisting the scores, empty if kept in memory only
+	threshold  int64  // Score below which a builder is quarantined
+	quarantine uint64 // Number of blocks a builder is quarantined for, 0 to disable
+
+	stats map[common.Address]*types.BuilderStats
+	dirty bool // Whether the scores changed since the last save
+	mu    sync.Mutex
+}
+
+// newBuilderReputation creates the reputation tracker, loading the scores saved
+// at the given path if any.
+func newBuilderReputation(path string, threshold int64, quarantine uint64) *builderReputation {
+	r := &builderReputation{
+		path:       path,
+		threshold:  threshold,
+		quarantine: quarantine,
+		stats:      make(map[common.Address]*types.BuilderStats),
+	}
+	if path == "" {
+		return r
+	}
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Warn("BidSimulator: failed to load builder stats", "path", path, "err", err)
+		}
+		return r
+	}
+	var stats []*types.BuilderStats
+	if err := json.Unmarshal(blob, &stats); err != nil {
+		log.Warn("BidSimulator: failed to decode builder stats", "path", path, "err", err)
+		return r
+	}
+	for _, stat := range stats {
+		r.stats[stat.Builder] = stat
+	}
+	log.Info("BidSimulator: loaded builder stats", "builders", len(stats))
+	return r
+}
+
+// stat returns the stats of the builder, created if missing. The lock must be
+// held by the caller.
+func (r *builderReputation) stat(builder common.Address) *types.BuilderStats {
+	stat, ok := r.stats[builder]
+	if !ok {
+		stat = &types.BuilderStats{Builder: builder, Score: maxBuilderScore}
+		r.stats[builder] = stat
+	}
+	return stat
+}
+
+// recordSimulated rewards a bid simulated successfully.
+func (r *builderReputation) recordSimulated(builder common.Address) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stat := r.stat(builder)
+	stat.Simulated++
+	stat.Score = min(stat.Score+builderSimulatedReward, maxBuilderScore)
+	r.dirty = true
+}
+
+// recordFailed penalizes a bid of the given block failing the simulation.
+func (r *builderReputation) recordFailed(builder common.Address, number uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stat := r.stat(builder)
+	stat.Failed++
+	r.penalize(stat, builderFailedPenalty, number)
+}
+
+// recordOverstated penalizes a bid of the given block paying less than its gas fee.
+func (r *builderReputation) recordOverstated(builder common.Address, number uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stat := r.stat(builder)
+	stat.Overstated++
+	r.penalize(stat, builderOverstatedPenalty, number)
+}
+
+// recordLate penalizes a bid of the given block arriving after its deadline.
+func (r *builderReputation) recordLate(builder common.Address, number uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stat := r.stat(builder)
+	stat.Late++
+	r.penalize(stat, builderLatePenalty, number)
+}
+
+// penalize lowers the score of the builder, quarantining it from the given block
+// on if it falls below the threshold. The lock must be held by the caller.
+func (r *builderReputation) penalize(stat *types.BuilderStats, penalty int64, number uint64) {
+	r.dirty = true
+	stat.Score -= penalty
+	if stat.Score >= r.threshold || r.quarantine == 0 {
+		return
+	}
+	stat.Score = maxBuilderScore
+	stat.Quarantines++
+	stat.QuarantinedUntil = number + r.quarantine - 1
+	builderQuarantinedMeter.Mark(1)
+	log.Warn("BidSimulator: builder quarantined", "builder", stat.Builder, "until", stat.QuarantinedUntil, "quarantines", stat.Quarantines)
+}
+
+// quarantined reports whether the builder may not bid for the given block, and
+// the last block of its quarantine.
+func (r *builderReputation) quarantined(builder common.Address, number uint64) (uint64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stat, ok := r.stats[builder]
+	if !ok || stat.Quarantines == 0 {
+		return 0, false
+	}
+	return stat.QuarantinedUntil, number <= stat.QuarantinedUntil
+}
+
+// list returns a copy of the stats of all the builders, sorted by address.
+func (r *builderReputation) list() []*types.BuilderStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make([]*types.BuilderStats, 0, len(r.stats))
+	for _, stat := range r.stats {
+		cpy := *stat
+		stats = append(stats, &cpy)
+	}
+	slices.SortFunc(stats, func(a, b *types.BuilderStats) int {
+		return bytes.Compare(a.Builder[:], b.Builder[:])
+	})
+	return stats
+}
+
+// save writes the scores to disk if they changed since the last save.
+func (r *builderReputation) save() {
+	if r.path == "" {
+		return
+	}
+	r.mu.Lock()
+	dirty := r.dirty
+	r.dirty = false
+	r.mu.Unlock()
+
+	if !dirty {
+		return
+	}
+	blob, err := json.MarshalIndent(r.list(), "", "  ")
+	if err != nil {
+		log.Warn("BidSimulator: failed to encode builder stats", "err", err)
+		return
+	}
+	// Write to a temporary file first, not to lose the scores on a crash
+	if err := os.WriteFile(r.path+".tmp", blob, 0644); err != nil {
+		log.Warn("BidSimulator: failed to save builder stats", "path", r.path, "err", err)
+		return
+	}
+	if err := os.Rename(r.path+".tmp", r.path); err != nil {
+		log.Warn("BidSimulator: failed to save builder stats", "path", r.path, "err", err)
+	}
+}
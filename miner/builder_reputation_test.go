@@ -0,0 +1,51 @@
+package miner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBuilderReputation(t *testing.T) {
+	var (
+		path    = filepath.Join(t.TempDir(), "builders.json")
+		r       = newBuilderReputation(path, 80, 10)
+		builder = common.HexToAddress("0x01")
+		honest  = common.HexToAddress("0x02")
+	)
+	r.recordSimulated(honest)
+	r.recordLate(builder, 100)
+	r.recordOverstated(builder, 100)
+	if _, ok := r.quarantined(builder, 101); ok {
+		t.Fatalf("builder quarantined above the threshold")
+	}
+	// The score falls to 100-2-5-10-10 = 73, below the threshold
+	r.recordFailed(builder, 101)
+	r.recordFailed(builder, 102)
+
+	if until, ok := r.quarantined(builder, 103); !ok || until != 111 {
+		t.Fatalf("quarantine mismatch: have %d %v, want 111 true", until, ok)
+	}
+	if _, ok := r.quarantined(builder, 112); ok {
+		t.Fatalf("builder quarantined after its release")
+	}
+	if _, ok := r.quarantined(honest, 103); ok {
+		t.Fatalf("honest builder quarantined")
+	}
+	r.save()
+
+	// The scores survive a restart
+	stats := newBuilderReputation(path, 80, 10).list()
+	if len(stats) != 2 {
+		t.Fatalf("builder count mismatch: have %d, want 2", len(stats))
+	}
+	stat := stats[0]
+	if stat.Builder != builder || stat.Score != maxBuilderScore || stat.Failed != 2 || stat.Late != 1 ||
+		stat.Overstated != 1 || stat.Quarantines != 1 || stat.QuarantinedUntil != 111 {
+		t.Errorf("builder stats mismatch: have %+v", stat)
+	}
+	if stat := stats[1]; stat.Builder != honest || stat.Simulated != 1 || stat.Score != maxBuilderScore {
+		t.Errorf("honest builder stats mismatch: have %+v", stat)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package builderclient
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// IssueServer serves mev_reportIssue, receiving the issues the validators found
+// with the bids of the builder.
+type IssueServer struct {
+	listener net.Listener
+	rpc      *rpc.Server
+	http     *http.Server
+}
+
+// issueAPI is the API of the issue server, in the mev namespace.
+type issueAPI struct {
+	handler func(*types.BidIssue)
+}
+
+// ReportIssue receives an issue with a bid.
+func (api *issueAPI) ReportIssue(issue *types.BidIssue) error {
+	if issue == nil {
+		return errors.New("missing issue")
+	}
+	api.handler(issue)
+	return nil
+}
+
+// NewIssueServer starts serving mev_reportIssue over HTTP at the given address,
+// passing the reported issues to the handler.
+func NewIssueServer(addr string, handler func(*types.BidIssue)) (*IssueServer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	server := &IssueServer{
+		listener: listener,
+		rpc:      rpc.NewServer(),
+	}
+	if err := server.rpc.RegisterName("mev", &issueAPI{handler: handler}); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	server.http = &http.Server{Handler: server.rpc, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		if err := server.http.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Warn("Bid issue server failed", "err", err)
+		}
+	}()
+	log.Info("Bid issue server started", "addr", listener.Addr())
+	return server, nil
+}
+
+// Addr returns the address the server listens on.
+func (s *IssueServer) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Close stops the server.
+func (s *IssueServer) Close() {
+	s.http.Close()
+	s.rpc.Stop()
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package builderclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestIssueServer(t *testing.T) {
+	issues := make(chan *types.BidIssue, 1)
+	server, err := NewIssueServer("127.0.0.1:0", func(issue *types.BidIssue) {
+		issues <- issue
+	})
+	if err != nil {
+		t.Fatalf("failed to start issue server: %v", err)
+	}
+	defer server.Close()
+
+	client, err := DialOptions(context.Background(), "http://"+server.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to dial issue server: %v", err)
+	}
+	issue := &types.BidIssue{
+		Validator: common.HexToAddress("0x01"),
+		Builder:   common.HexToAddress("0x02"),
+		BidHash:   common.HexToHash("0x03"),
+		Message:   "invalid bid",
+	}
+	if err := client.ReportIssue(context.Background(), issue); err != nil {
+		t.Fatalf("failed to report issue: %v", err)
+	}
+	if have := <-issues; *have != *issue {
+		t.Errorf("issue mismatch: have %+v, want %+v", have, issue)
+	}
+}
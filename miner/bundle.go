@@ -0,0 +1,257 @@
+package miner
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool/bundlepool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// maxBundleSimulations is the maximum number of bundles simulated alone per block
+// built, bounding the work on the block building path. The outcomes are cached
+// per parent block, the remaining bundles are simulated by the next rounds.
+const maxBundleSimulations = 128
+
+var (
+	bundleSimulatedMeter = metrics.NewRegisteredMeter("miner/bundle/simulated", nil)
+	bundleFailedMeter    = metrics.NewRegisteredMeter("miner/bundle/failed", nil)
+	bundleCommittedMeter = metrics.NewRegisteredMeter("miner/bundle/committed", nil)
+)
+
+// SimulatedBundle is the outcome of the execution of a bundle.
+type SimulatedBundle struct {
+	Bundle   *types.Bundle
+	Receipts types.Receipts
+	GasUsed  uint64
+	Reward   *big.Int // Fees and direct payments to the coinbase
+	GasPrice *big.Int // Reward per gas used
+}
+
+// SimulateBundle executes the bundle on top of the state, as part of the block
+// of the given header. The state and the gas used by the header are modified.
+//
+// The bundle fails if any of its transactions is invalid, or reverts without
+// being allowed to.
+func SimulateBundle(config *params.ChainConfig, chain core.ChainContext, statedb *state.StateDB, header *types.Header, bundle *types.Bundle) (*SimulatedBundle, error) {
+	gasPool := new(core.GasPool).AddGas(header.GasLimit - header.GasUsed)
+	return simulateBundle(config, chain, statedb, header, header.Coinbase, gasPool, 0, bundle)
+}
+
+func simulateBundle(config *params.ChainConfig, chain core.ChainContext, statedb *state.StateDB, header *types.Header,
+	coinbase common.Address, gasPool *core.GasPool, txIndex int, bundle *types.Bundle) (*SimulatedBundle, error) {
+	var (
+		evm    = vm.NewEVM(core.NewEVMBlockContext(header, chain, &coinbase), statedb, config, vm.Config{})
+		before = bundleReward(statedb, coinbase)
+		sim    = &SimulatedBundle{Bundle: bundle}
+	)
+	for i, tx := range bundle.Txs {
+		statedb.SetTxContext(tx.Hash(), txIndex+i)
+		receipt, err := core.ApplyTransaction(evm, gasPool, statedb, header, tx, &header.GasUsed)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %s invalid: %w", tx.Hash(), err)
+		}
+		if receipt.Status == types.ReceiptStatusFailed && !bundle.MayRevert(tx.Hash()) {
+			return nil, fmt.Errorf("transaction %s reverted", tx.Hash())
+		}
+		sim.Receipts = append(sim.Receipts, receipt)
+		sim.GasUsed += receipt.GasUsed
+	}
+	sim.Reward = new(big.Int).Sub(bundleReward(statedb, coinbase), before)
+	sim.GasPrice = new(big.Int)
+	if sim.GasUsed > 0 {
+		sim.GasPrice.Div(sim.Reward, new(big.Int).SetUint64(sim.GasUsed))
+	}
+	return sim, nil
+}
+
+// bundleReward returns the sum of the fees collected by the block so far and
+// the balance of the coinbase, which searchers may pay directly.
+func bundleReward(statedb *state.StateDB, coinbase common.Address) *big.Int {
+	reward := statedb.GetBalance(consensus.SystemAddress).ToBig()
+	return reward.Add(reward, statedb.GetBalance(coinbase).ToBig())
+}
+
+// bundleSimCache caches the outcomes of the bundles simulated alone on top of a
+// parent block, shared by the blocks built on it. A nil outcome marks a failed
+// bundle.
+type bundleSimCache struct {
+	parent common.Hash
+	sims   map[common.Hash]*SimulatedBundle
+	lock   sync.Mutex
+}
+
+// get returns the cached outcome of the bundle simulated on the parent block.
+func (c *bundleSimCache) get(parent common.Hash, bundle common.Hash) (*SimulatedBundle, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.parent != parent {
+		return nil, false
+	}
+	sim, ok := c.sims[bundle]
+	return sim, ok
+}
+
+// add caches the outcome of the bundle simulated on the parent block, dropping
+// the outcomes cached for any other parent.
+func (c *bundleSimCache) add(parent common.Hash, bundle common.Hash, sim *SimulatedBundle) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.parent != parent || c.sims == nil {
+		c.parent = parent
+		c.sims = make(map[common.Hash]*SimulatedBundle)
+	}
+	c.sims[bundle] = sim
+}
+
+// setBundlePool sets the pool of the bundles merged into the blocks.
+func (w *worker) setBundlePool(pool *bundlepool.BundlePool) {
+	w.confMu.Lock()
+	defer w.confMu.Unlock()
+	w.bundlePool = pool
+}
+
+// simulateBundle executes the bundle on a copy of the environment.
+func (w *worker) simulateBundle(env *environment, bundle *types.Bundle) (*SimulatedBundle, error) {
+	gasPool := new(core.GasPool).AddGas(env.gasPool.Gas())
+	return simulateBundle(w.chainConfig, w.chain, env.state.Copy(), types.CopyHeader(env.header), env.coinbase, gasPool, env.tcount, bundle)
+}
+
+// commitBundles merges the pending bundles into the block ahead of the regular
+// transactions. The bundles are simulated alone first and ranked by the reward
+// they pay per gas, then simulated again on top of the bundles already merged.
+// Only the bundles paying at least the minimum tip per gas are kept, as the gas
+// they use would otherwise be better spent on regular transactions.
+//
+// At most maxBundleSimulations bundles not yet simulated on the parent block
+// are simulated alone per call.
+func (w *worker) commitBundles(env *environment, interruptCh chan int32) error {
+	w.confMu.RLock()
+	pool, tip := w.bundlePool, w.tip
+	w.confMu.RUnlock()
+
+	if pool == nil || env.witness != nil {
+		return nil
+	}
+	bundles := pool.Pending(env.header.Number.Uint64())
+	if len(bundles) == 0 {
+		return nil
+	}
+	w.initGasPool(env)
+
+	minPrice := new(big.Int)
+	if tip != nil {
+		minPrice = tip.ToBig()
+	}
+	var (
+		parent    = env.header.ParentHash
+		simulated = make([]*SimulatedBundle, 0, len(bundles))
+		budget    = maxBundleSimulations
+	)
+	for _, bundle := range bundles {
+		sim, ok := w.bundleSims.get(parent, bundle.Hash())
+		if !ok {
+			if budget == 0 {
+				continue
+			}
+			budget--
+
+			var err error
+			sim, err = w.simulateBundle(env, bundle)
+			bundleSimulatedMeter.Mark(1)
+			if err != nil {
+				log.Trace("Bundle simulation failed", "hash", bundle.Hash(), "err", err)
+				bundleFailedMeter.Mark(1)
+			} else {
+				sim.Receipts = nil // Only the outcome is needed for the ranking
+			}
+			w.bundleSims.add(parent, bundle.Hash(), sim)
+		}
+		if sim == nil {
+			continue
+		}
+		if sim.Reward.Sign() <= 0 || sim.GasPrice.Cmp(minPrice) < 0 {
+			log.Trace("Bundle underpriced", "hash", bundle.Hash(), "price", sim.GasPrice, "min", minPrice)
+			continue
+		}
+		simulated = append(simulated, sim)
+	}
+	sort.SliceStable(simulated, func(i, j int) bool {
+		return simulated[i].GasPrice.Cmp(simulated[j].GasPrice) > 0
+	})
+
+	for _, sim := range simulated {
+		if interruptCh != nil {
+			select {
+			case signal := <-interruptCh:
+				return signalToErr(signal)
+			default:
+			}
+		}
+		if env.gasPool.Gas() < sim.GasUsed {
+			continue
+		}
+		// The bundles merged before may have changed the outcome of this one.
+		merged, err := w.simulateBundle(env, sim.Bundle)
+		if err != nil || merged.GasPrice.Cmp(minPrice) < 0 || merged.Reward.Sign() <= 0 {
+			log.Trace("Bundle dropped after merging", "hash", sim.Bundle.Hash(), "err", err)
+			continue
+		}
+		// The execution on the environment is expected to match the simulation
+		// on its copy. Should it not, the bundle is rolled back whole.
+		if err := w.commitBundle(env, sim.Bundle); err != nil {
+			log.Error("Bundle failed after simulation", "hash", sim.Bundle.Hash(), "err", err)
+			bundleFailedMeter.Mark(1)
+			continue
+		}
+		bundleCommittedMeter.Mark(1)
+		log.Debug("Committed bundle", "hash", sim.Bundle.Hash(), "txs", len(sim.Bundle.Txs), "gas", merged.GasUsed, "reward", merged.Reward)
+	}
+	return nil
+}
+
+// commitBundle commits the transactions of the bundle to the environment. If any
+// of them fails, the environment is reverted to its state before the bundle.
+func (w *worker) commitBundle(env *environment, bundle *types.Bundle) error {
+	var (
+		snap     = env.state.Snapshot()
+		tcount   = env.tcount
+		txs      = len(env.txs)
+		receipts = len(env.receipts)
+		gas      = env.gasPool.Gas()
+		gasUsed  = env.header.GasUsed
+	)
+	revert := func() {
+		env.state.RevertToSnapshot(snap)
+		env.tcount = tcount
+		env.txs = env.txs[:txs]
+		env.receipts = env.receipts[:receipts]
+		env.gasPool.SetGas(gas)
+		env.header.GasUsed = gasUsed
+	}
+	for _, tx := range bundle.Txs {
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+		if _, err := w.commitTransaction(env, tx); err != nil {
+			revert()
+			return fmt.Errorf("transaction %s invalid: %w", tx.Hash(), err)
+		}
+		env.tcount++
+		if env.receipts[len(env.receipts)-1].Status == types.ReceiptStatusFailed && !bundle.MayRevert(tx.Hash()) {
+			revert()
+			return fmt.Errorf("transaction %s reverted", tx.Hash())
+		}
+	}
+	return nil
+}
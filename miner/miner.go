@@ -21,13 +21,16 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/parlia"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/bundlepool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/event"
@@ -55,6 +58,7 @@ type Miner struct {
 	worker  *worker
 
 	bidSimulator *bidSimulator
+	bidder       atomic.Pointer[bidder] // Built-in builder, nil if not bidding
 
 	wg sync.WaitGroup
 }
@@ -146,6 +150,9 @@ func (miner *Miner) update() {
 			miner.worker.stop()
 			miner.bidSimulator.stop()
 		case <-miner.exitCh:
+			if bidder := miner.bidder.Load(); bidder != nil {
+				bidder.close()
+			}
 			miner.worker.close()
 			miner.bidSimulator.close()
 			return
@@ -234,6 +241,25 @@ func (miner *Miner) SetGasCeil(ceil uint64) {
 	miner.worker.setGasCeil(ceil)
 }
 
+// SetBundlePool sets the pool of the bundles merged ahead of the transactions
+// into the blocks built locally.
+func (miner *Miner) SetBundlePool(pool *bundlepool.BundlePool) {
+	miner.worker.setBundlePool(pool)
+}
+
+// StartBidder starts the built-in builder, building blocks on the new heads and
+// sending them as bids signed by the builder account to the validators in turn.
+func (miner *Miner) StartBidder(signFn parlia.SignerFn, signTxFn parlia.SignerTxFn) error {
+	bidder, err := newBidder(&miner.worker.config.Bidder, miner.worker, signFn, signTxFn)
+	if err != nil {
+		return err
+	}
+	if old := miner.bidder.Swap(bidder); old != nil {
+		old.close()
+	}
+	return nil
+}
+
 // SubscribePendingLogs starts delivering logs from pending transactions
 // to the given channel.
 func (miner *Miner) SubscribePendingLogs(ch chan<- []*types.Log) event.Subscription {
@@ -42,8 +42,16 @@ func (miner *Miner) HasBuilder(builder common.Address) bool {
 	return miner.bidSimulator.ExistBuilder(builder)
 }
 
-func (miner *Miner) SendBid(ctx context.Context, bidArgs *types.BidArgs) (common.Hash, error) {
-	builder, err := bidArgs.EcrecoverSender()
+func (miner *Miner) SendBid(ctx context.Context, bidArgs *types.BidArgs) (_ common.Hash, err error) {
+	var (
+		receiveTime = time.Now()
+		builder     common.Address
+	)
+	defer func() {
+		miner.bidSimulator.journalBid(bidArgs, builder, receiveTime, err)
+	}()
+
+	builder, err = bidArgs.EcrecoverSender()
 	if err != nil {
 		return common.Hash{}, types.NewInvalidBidError(fmt.Sprintf("invalid signature:%v", err))
 	}
@@ -52,6 +60,10 @@ func (miner *Miner) SendBid(ctx context.Context, bidArgs *types.BidArgs) (common
 		return common.Hash{}, types.NewInvalidBidError("builder is not registered")
 	}
 
+	if err := miner.bidSimulator.CheckQuarantine(builder, bidArgs.RawBid.BlockNumber); err != nil {
+		return common.Hash{}, types.NewInvalidBidError(err.Error())
+	}
+
 	err = miner.bidSimulator.CheckPending(bidArgs.RawBid.BlockNumber, builder, bidArgs.RawBid.Hash())
 	if err != nil {
 		return common.Hash{}, err
@@ -67,6 +79,7 @@ func (miner *Miner) SendBid(ctx context.Context, bidArgs *types.BidArgs) (common
 	timeout := time.Until(bidBetterBefore)
 
 	if timeout <= 0 {
+		miner.bidSimulator.RecordLateBid(builder, bidArgs.RawBid.BlockNumber)
 		return common.Hash{}, fmt.Errorf("too late, expected before %s, appeared %s later", bidBetterBefore,
 			common.PrettyDuration(timeout))
 	}
@@ -80,6 +93,17 @@ func (miner *Miner) SendBid(ctx context.Context, bidArgs *types.BidArgs) (common
 	return bid.Hash(), nil
 }
 
+// BuilderStats returns the scores of the builders, derived from their bids.
+func (miner *Miner) BuilderStats() []*types.BuilderStats {
+	return miner.bidSimulator.BuilderStats()
+}
+
+// BlockTemplate builds a block on the given parent in a dry run, returning it
+// along with the best bid received for the parent.
+func (miner *Miner) BlockTemplate(parentHash common.Hash) (*BlockTemplate, error) {
+	return miner.worker.blockTemplate(parentHash)
+}
+
 func (miner *Miner) MevParams() *types.MevParams {
 	builderFeeCeil, ok := big.NewInt(0).SetString(*miner.worker.config.Mev.BuilderFeeCeil, 10)
 	if !ok {
@@ -18,6 +18,7 @@
 package minerconfig
 
 import (
+	"fmt"
 	"math/big"
 	"time"
 
@@ -46,6 +47,11 @@ var (
 	defaultBuilderFeeCeil      = "0"
 	defaultValidatorCommission = uint64(100)
 	defaultMaxBidsPerBuilder   = uint32(2) // Simple strategy: send one bid early, another near deadline
+
+	defaultBuilderScoreThreshold   = int64(50)
+	defaultBuilderQuarantineBlocks = uint64(1200)
+	defaultBuilderStats            = "mev_builders.json"
+	defaultJournalMaxSize          = uint64(256 * 1024 * 1024)
 )
 
 // Config is the configuration parameters of mining.
@@ -61,7 +67,10 @@ type Config struct {
 	MaxWaitProposalInSecs  *uint64        `toml:",omitempty"` // The maximum time to wait for the proposal to be done, it's aimed to prevent validator being slashed when restarting
 	DisableVoteAttestation bool           // Whether to skip assembling vote attestation
 
-	Mev MevConfig // Mev configuration
+	Ordering OrderingPolicy // Order of the transactions of the pool in the mined blocks
+
+	Mev    MevConfig    // Mev configuration
+	Bidder BidderConfig // Built-in builder configuration
 }
 
 // DefaultConfig contains default settings for miner.
@@ -83,6 +92,32 @@ var DefaultConfig = Config{
 	Mev: DefaultMevConfig,
 }
 
+// Sorts of the transactions of different accounts. The transactions of an account
+// are always included in nonce order.
+const (
+	OrderingPriceAndTime = "price" // Highest effective tip first, first seen first on equal tips
+	OrderingFIFO         = "fifo"  // First seen first, regardless of the tip
+)
+
+// OrderingPolicy is the order in which the miner includes the transactions of
+// the pool in a block.
+type OrderingPolicy struct {
+	Sort              string           `toml:",omitempty"` // Sort of the transactions, "price" if empty
+	SenderGasCap      uint64           `toml:",omitempty"` // Maximum gas limit of the transactions of a sender per block, 0 to disable
+	ReservedContracts []common.Address `toml:",omitempty"` // Contracts whose transactions are included first, up to ReservedGas
+	ReservedGas       uint64           `toml:",omitempty"` // Block space reserved for the transactions calling ReservedContracts
+}
+
+// Validate checks the policy is a known one.
+func (p *OrderingPolicy) Validate() error {
+	switch p.Sort {
+	case "", OrderingPriceAndTime, OrderingFIFO:
+		return nil
+	default:
+		return fmt.Errorf("unknown transaction sort %q", p.Sort)
+	}
+}
+
 type BuilderConfig struct {
 	Address common.Address
 	URL     string
@@ -98,6 +133,28 @@ type MevConfig struct {
 	BidSimulationLeftOver *time.Duration  `toml:",omitempty"`
 	NoInterruptLeftOver   *time.Duration  `toml:",omitempty"`
 	MaxBidsPerBuilder     *uint32         `toml:",omitempty"` // Maximum number of bids allowed per builder per block
+	Journal               string          `toml:",omitempty"` // Path of the journal recording the bids, empty to disable
+	JournalMaxSize        *uint64         `toml:",omitempty"` // Size in bytes past which the bid journal is rotated, 0 for unlimited
+
+	BuilderScoreThreshold   *int64  `toml:",omitempty"` // Score below which a builder is quarantined
+	BuilderQuarantineBlocks *uint64 `toml:",omitempty"` // Number of blocks a builder is quarantined for, 0 to disable
+	BuilderStats            string  `toml:",omitempty"` // Path of the file persisting the builder scores, empty to disable
+}
+
+// ValidatorConfig is a validator the built-in builder sends its bids to.
+type ValidatorConfig struct {
+	Address common.Address // Consensus address of the validator
+	URL     string         // Endpoint of the validator serving mev_sendBid
+}
+
+// BidderConfig is the configuration of the built-in builder, building blocks on
+// top of the chain head and sending them as bids to the validators in turn.
+type BidderConfig struct {
+	Enabled         bool              // Whether to build and send bids
+	Account         common.Address    // Builder account signing the bids, unlocked in the keystore
+	Fee             *big.Int          `toml:",omitempty"` // Builder fee claimed per bid, capped by the validator
+	Validators      []ValidatorConfig // Validators to send the bids to
+	IssueListenAddr string            `toml:",omitempty"` // Local endpoint receiving the issues of the bids, empty to disable
 }
 
 var DefaultMevConfig = MevConfig{
@@ -110,6 +167,11 @@ var DefaultMevConfig = MevConfig{
 	BidSimulationLeftOver: &defaultBidSimulationLeftOver,
 	NoInterruptLeftOver:   &defaultNoInterruptLeftOver,
 	MaxBidsPerBuilder:     &defaultMaxBidsPerBuilder,
+	JournalMaxSize:        &defaultJournalMaxSize,
+
+	BuilderScoreThreshold:   &defaultBuilderScoreThreshold,
+	BuilderQuarantineBlocks: &defaultBuilderQuarantineBlocks,
+	BuilderStats:            defaultBuilderStats,
 }
 
 func ApplyDefaultMinerConfig(cfg *Config) {
@@ -161,4 +223,16 @@ func ApplyDefaultMinerConfig(cfg *Config) {
 		cfg.Mev.MaxBidsPerBuilder = &defaultMaxBidsPerBuilder
 		log.Info("ApplyDefaultMinerConfig", "Mev.MaxBidsPerBuilder", *cfg.Mev.MaxBidsPerBuilder)
 	}
+	if cfg.Mev.JournalMaxSize == nil {
+		cfg.Mev.JournalMaxSize = &defaultJournalMaxSize
+		log.Info("ApplyDefaultMinerConfig", "Mev.JournalMaxSize", *cfg.Mev.JournalMaxSize)
+	}
+	if cfg.Mev.BuilderScoreThreshold == nil {
+		cfg.Mev.BuilderScoreThreshold = &defaultBuilderScoreThreshold
+		log.Info("ApplyDefaultMinerConfig", "Mev.BuilderScoreThreshold", *cfg.Mev.BuilderScoreThreshold)
+	}
+	if cfg.Mev.BuilderQuarantineBlocks == nil {
+		cfg.Mev.BuilderQuarantineBlocks = &defaultBuilderQuarantineBlocks
+		log.Info("ApplyDefaultMinerConfig", "Mev.BuilderQuarantineBlocks", *cfg.Mev.BuilderQuarantineBlocks)
+	}
 }
@@ -18,19 +18,128 @@ package miner
 
 import (
 	"container/heap"
+	"maps"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/miner/minerconfig"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
 )
 
+// orderedTransactions is a set of transactions retrieved one at a time in the
+// order of the ordering policy of the miner, the transactions of an account
+// always being retrieved in nonce order.
+type orderedTransactions interface {
+	// Peek returns the next transaction and its effective miner tip.
+	Peek() (*txpool.LazyTransaction, *uint256.Int)
+
+	// PeekWithUnwrap returns the next transaction resolved, nil if none.
+	PeekWithUnwrap() *types.Transaction
+
+	// Shift replaces the next transaction with the following one of the same account.
+	Shift()
+
+	// Pop removes the next transaction, and all the following ones of the same
+	// account.
+	Pop()
+
+	// Empty returns whether there are no transactions left.
+	Empty() bool
+
+	// Clear removes all the transactions.
+	Clear()
+
+	// CurrentSize returns the number of accounts with transactions left.
+	CurrentSize() int
+
+	// Forward moves the set to the transaction following the given one.
+	Forward(tx *types.Transaction)
+
+	// Copy returns an independent copy of the set.
+	Copy() orderedTransactions
+
+	// peekSender returns the sender of the next transaction.
+	peekSender() common.Address
+}
+
+// newOrderedTransactions creates a transaction set retrieving the transactions
+// in the order of the given policy, in a nonce-honouring way. The limits of the
+// policy are enforced against the budget of the block, shared by all its sets.
+//
+// Note, the input map is reowned so the caller should not interact any more with
+// if after providing it to the constructor.
+func newOrderedTransactions(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, policy *minerconfig.OrderingPolicy, budget *orderingBudget) orderedTransactions {
+	sorted := newSortedTransactions(signer, txs, baseFee, policy, budget)
+	if policy.SenderGasCap == 0 {
+		return sorted
+	}
+	return newSenderCappedTransactions(sorted, policy.SenderGasCap, budget)
+}
+
+// orderingBudget tracks the block space used against the limits of the ordering
+// policy. The limits apply per block, so the budget is kept by the environment
+// and shared by all the transaction sets committed to it.
+type orderingBudget struct {
+	reserved     map[common.Address]struct{} // Contracts with reserved block space, nil if none
+	reservedLeft uint64                      // Gas left to the transactions calling the reserved contracts
+	senderUsed   map[common.Address]uint64   // Gas of the included transactions of each sender
+}
+
+// newOrderingBudget creates the budget of a block for the given policy.
+func newOrderingBudget(policy *minerconfig.OrderingPolicy) *orderingBudget {
+	budget := &orderingBudget{senderUsed: make(map[common.Address]uint64)}
+	if len(policy.ReservedContracts) > 0 && policy.ReservedGas >= params.TxGas {
+		budget.reserved = make(map[common.Address]struct{}, len(policy.ReservedContracts))
+		for _, contract := range policy.ReservedContracts {
+			budget.reserved[contract] = struct{}{}
+		}
+		budget.reservedLeft = policy.ReservedGas
+	}
+	return budget
+}
+
+// reserving returns whether the transactions calling the reserved contracts
+// still have reserved space left.
+func (b *orderingBudget) reserving() bool {
+	return b.reserved != nil && b.reservedLeft >= params.TxGas
+}
+
+// isReserved returns whether the transaction calls a reserved contract.
+func (b *orderingBudget) isReserved(tx *types.Transaction) bool {
+	if b.reserved == nil || tx.To() == nil {
+		return false
+	}
+	_, ok := b.reserved[*tx.To()]
+	return ok
+}
+
+// charge charges the gas limit of a transaction included in the block to its
+// sender, and to the reserved space if it calls a reserved contract.
+func (b *orderingBudget) charge(from common.Address, tx *types.Transaction) {
+	b.senderUsed[from] += tx.Gas()
+	if b.isReserved(tx) {
+		b.reservedLeft -= min(tx.Gas(), b.reservedLeft)
+	}
+}
+
+// copy returns an independent copy of the budget.
+func (b *orderingBudget) copy() *orderingBudget {
+	return &orderingBudget{
+		reserved:     b.reserved,
+		reservedLeft: b.reservedLeft,
+		senderUsed:   maps.Clone(b.senderUsed),
+	}
+}
+
 // txWithMinerFee wraps a transaction with its gas price or effective miner gasTipCap
 type txWithMinerFee struct {
-	tx   *txpool.LazyTransaction
-	from common.Address
-	fees *uint256.Int
+	tx       *txpool.LazyTransaction
+	from     common.Address
+	fees     *uint256.Int
+	reserved bool // Whether the transaction calls a contract with reserved block space
 }
 
 // newTxWithMinerFee creates a wrapped transaction, calculating the effective
@@ -54,82 +163,136 @@ func newTxWithMinerFee(tx *txpool.LazyTransaction, from common.Address, baseFee
 	}, nil
 }
 
-// txByPriceAndTime implements both the sort and the heap interface, making it useful
-// for all at once sorting as well as individually adding and removing elements.
-type txByPriceAndTime []*txWithMinerFee
-
-func (s txByPriceAndTime) Len() int { return len(s) }
-func (s txByPriceAndTime) Less(i, j int) bool {
-	// If the prices are equal, use the time the transaction was first seen for
-	// deterministic sorting
-	cmp := s[i].fees.Cmp(s[j].fees)
+// byPriceAndTime orders the transactions by decreasing price. If the prices are
+// equal, the time the transaction was first seen is used for deterministic sorting.
+func byPriceAndTime(a, b *txWithMinerFee) bool {
+	cmp := a.fees.Cmp(b.fees)
 	if cmp == 0 {
-		return s[i].tx.Time.Before(s[j].tx.Time)
+		return a.tx.Time.Before(b.tx.Time)
 	}
 	return cmp > 0
 }
-func (s txByPriceAndTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 
-func (s *txByPriceAndTime) Push(x interface{}) {
-	*s = append(*s, x.(*txWithMinerFee))
+// byTimeAndPrice orders the transactions by the time they were first seen. If the
+// times are equal, the price is used for deterministic sorting.
+func byTimeAndPrice(a, b *txWithMinerFee) bool {
+	if a.tx.Time.Equal(b.tx.Time) {
+		return a.fees.Cmp(b.fees) > 0
+	}
+	return a.tx.Time.Before(b.tx.Time)
+}
+
+// txHeads implements the heap interface over the next transaction of each account,
+// making it useful for all at once sorting as well as individually adding and
+// removing elements.
+type txHeads struct {
+	txs       []*txWithMinerFee
+	fifo      bool // Whether to order by first-seen time rather than by price
+	reserving bool // Whether the transactions calling the reserved contracts come first
+}
+
+func (h *txHeads) Len() int { return len(h.txs) }
+func (h *txHeads) Less(i, j int) bool {
+	a, b := h.txs[i], h.txs[j]
+	if h.reserving && a.reserved != b.reserved {
+		return a.reserved
+	}
+	if h.fifo {
+		return byTimeAndPrice(a, b)
+	}
+	return byPriceAndTime(a, b)
+}
+func (h *txHeads) Swap(i, j int) { h.txs[i], h.txs[j] = h.txs[j], h.txs[i] }
+
+func (h *txHeads) Push(x interface{}) {
+	h.txs = append(h.txs, x.(*txWithMinerFee))
 }
 
-func (s *txByPriceAndTime) Pop() interface{} {
-	old := *s
+func (h *txHeads) Pop() interface{} {
+	old := h.txs
 	n := len(old)
 	x := old[n-1]
 	old[n-1] = nil
-	*s = old[0 : n-1]
+	h.txs = old[0 : n-1]
 	return x
 }
 
-// transactionsByPriceAndNonce represents a set of transactions that can return
-// transactions in a profit-maximizing sorted order, while supporting removing
-// entire batches of transactions for non-executable accounts.
-type transactionsByPriceAndNonce struct {
+// sortedTransactions represents a set of transactions that can return
+// transactions in a profit-maximizing or first-seen sorted order, while supporting
+// removing entire batches of transactions for non-executable accounts.
+type sortedTransactions struct {
 	txs     map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
-	heads   txByPriceAndTime                             // Next transaction for each unique account (price heap)
+	heads   txHeads                                      // Next transaction for each unique account (heap)
 	signer  types.Signer                                 // Signer for the set of transactions
 	baseFee *uint256.Int                                 // Current base fee
+
+	budget *orderingBudget // Block space left to the limits of the ordering policy
 }
 
 // newTransactionsByPriceAndNonce creates a transaction set that can retrieve
 // price sorted transactions in a nonce-honouring way.
-//
-// Note, the input map is reowned so the caller should not interact any more with
-// if after providing it to the constructor.
-func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) *transactionsByPriceAndNonce {
+func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) *sortedTransactions {
+	policy := new(minerconfig.OrderingPolicy)
+	return newSortedTransactions(signer, txs, baseFee, policy, newOrderingBudget(policy))
+}
+
+// newSortedTransactions creates a transaction set sorted by the policy, the
+// sender gas cap left aside.
+func newSortedTransactions(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, policy *minerconfig.OrderingPolicy, budget *orderingBudget) *sortedTransactions {
 	// Convert the basefee from header format to uint256 format
 	var baseFeeUint *uint256.Int
 	if baseFee != nil {
 		baseFeeUint = uint256.MustFromBig(baseFee)
 	}
-	// Initialize a price and received time based heap with the head transactions
-	heads := make(txByPriceAndTime, 0, len(txs))
+	t := &sortedTransactions{
+		txs:     txs,
+		signer:  signer,
+		baseFee: baseFeeUint,
+		heads: txHeads{
+			txs:       make([]*txWithMinerFee, 0, len(txs)),
+			fifo:      policy.Sort == minerconfig.OrderingFIFO,
+			reserving: budget.reserving(),
+		},
+		budget: budget,
+	}
+	// Initialize the heap with the head transactions
 	for from, accTxs := range txs {
-		wrapped, err := newTxWithMinerFee(accTxs[0], from, baseFeeUint)
+		wrapped, err := t.wrap(accTxs[0], from)
 		if err != nil {
 			delete(txs, from)
 			continue
 		}
-		heads = append(heads, wrapped)
+		t.heads.txs = append(t.heads.txs, wrapped)
 		txs[from] = accTxs[1:]
 	}
-	heap.Init(&heads)
+	heap.Init(&t.heads)
+	return t
+}
 
-	// Assemble and return the transaction set
-	return &transactionsByPriceAndNonce{
-		txs:     txs,
-		heads:   heads,
-		signer:  signer,
-		baseFee: baseFeeUint,
+// wrap creates a wrapped transaction, flagging the ones calling the reserved
+// contracts.
+func (t *sortedTransactions) wrap(tx *txpool.LazyTransaction, from common.Address) (*txWithMinerFee, error) {
+	wrapped, err := newTxWithMinerFee(tx, from, t.baseFee)
+	if err != nil {
+		return nil, err
+	}
+	if t.heads.reserving {
+		if resolved := tx.Resolve(); resolved != nil {
+			wrapped.reserved = t.budget.isReserved(resolved)
+		}
 	}
+	return wrapped, nil
 }
 
-// Copy copies a new TransactionsPriceAndNonce with the same *transaction
-func (t *transactionsByPriceAndNonce) Copy() *transactionsByPriceAndNonce {
-	heads := make([]*txWithMinerFee, len(t.heads))
-	copy(heads, t.heads)
+// Copy copies a new sortedTransactions with the same *transaction
+func (t *sortedTransactions) Copy() orderedTransactions {
+	return t.copyWithBudget(t.budget.copy())
+}
+
+// copyWithBudget copies the set, charging the copy to the given budget.
+func (t *sortedTransactions) copyWithBudget(budget *orderingBudget) *sortedTransactions {
+	heads := make([]*txWithMinerFee, len(t.heads.txs))
+	copy(heads, t.heads.txs)
 	txs := make(map[common.Address][]*txpool.LazyTransaction, len(t.txs))
 	for acc, txsTmp := range t.txs {
 		txs[acc] = txsTmp
@@ -138,36 +301,51 @@ func (t *transactionsByPriceAndNonce) Copy() *transactionsByPriceAndNonce {
 	if t.baseFee != nil {
 		baseFee = *t.baseFee
 	}
-	return &transactionsByPriceAndNonce{
-		heads:   heads,
+	return &sortedTransactions{
+		heads: txHeads{
+			txs:       heads,
+			fifo:      t.heads.fifo,
+			reserving: t.heads.reserving,
+		},
 		txs:     txs,
 		signer:  t.signer,
 		baseFee: &baseFee,
+		budget:  budget,
 	}
 }
 
 // Peek returns the next transaction by price.
-func (t *transactionsByPriceAndNonce) Peek() (*txpool.LazyTransaction, *uint256.Int) {
-	if len(t.heads) == 0 {
+func (t *sortedTransactions) Peek() (*txpool.LazyTransaction, *uint256.Int) {
+	if len(t.heads.txs) == 0 {
 		return nil, nil
 	}
-	return t.heads[0].tx, t.heads[0].fees
+	return t.heads.txs[0].tx, t.heads.txs[0].fees
 }
 
 // Peek returns the next transaction by price.
-func (t *transactionsByPriceAndNonce) PeekWithUnwrap() *types.Transaction {
-	if len(t.heads) > 0 && t.heads[0].tx != nil && t.heads[0].tx.Resolve() != nil {
-		return t.heads[0].tx.Tx
+func (t *sortedTransactions) PeekWithUnwrap() *types.Transaction {
+	if len(t.heads.txs) > 0 && t.heads.txs[0].tx != nil && t.heads.txs[0].tx.Resolve() != nil {
+		return t.heads.txs[0].tx.Tx
 	}
 	return nil
 }
 
+func (t *sortedTransactions) peekSender() common.Address {
+	return t.heads.txs[0].from
+}
+
 // Shift replaces the current best head with the next one from the same account.
-func (t *transactionsByPriceAndNonce) Shift() {
-	acc := t.heads[0].from
+func (t *sortedTransactions) Shift() {
+	if t.heads.reserving && !t.budget.reserving() {
+		// The reserved space is used up, order the remaining transactions by the
+		// policy alone
+		t.heads.reserving = false
+		defer heap.Init(&t.heads)
+	}
+	acc := t.heads.txs[0].from
 	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
-		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
-			t.heads[0], t.txs[acc] = wrapped, txs[1:]
+		if wrapped, err := t.wrap(txs[0], acc); err == nil {
+			t.heads.txs[0], t.txs[acc] = wrapped, txs[1:]
 			heap.Fix(&t.heads, 0)
 			return
 		}
@@ -178,35 +356,35 @@ func (t *transactionsByPriceAndNonce) Shift() {
 // Pop removes the best transaction, *not* replacing it with the next one from
 // the same account. This should be used when a transaction cannot be executed
 // and hence all subsequent ones should be discarded from the same account.
-func (t *transactionsByPriceAndNonce) Pop() {
+func (t *sortedTransactions) Pop() {
 	heap.Pop(&t.heads)
 }
 
 // Empty returns if the price heap is empty. It can be used to check it simpler
 // than calling peek and checking for nil return.
-func (t *transactionsByPriceAndNonce) Empty() bool {
-	return len(t.heads) == 0
+func (t *sortedTransactions) Empty() bool {
+	return len(t.heads.txs) == 0
 }
 
 // Clear removes the entire content of the heap.
-func (t *transactionsByPriceAndNonce) Clear() {
-	t.heads, t.txs = nil, nil
+func (t *sortedTransactions) Clear() {
+	t.heads.txs, t.txs = nil, nil
 }
 
-func (t *transactionsByPriceAndNonce) CurrentSize() int {
-	return len(t.heads)
+func (t *sortedTransactions) CurrentSize() int {
+	return len(t.heads.txs)
 }
 
 // Forward moves current transaction to be the one which is one index after tx
-func (t *transactionsByPriceAndNonce) Forward(tx *types.Transaction) {
+func (t *sortedTransactions) Forward(tx *types.Transaction) {
 	if tx == nil {
-		if len(t.heads) > 0 {
-			t.heads = t.heads[0:0]
+		if len(t.heads.txs) > 0 {
+			t.heads.txs = t.heads.txs[0:0]
 		}
 		return
 	}
 	//check whether target tx exists in t.heads
-	for _, head := range t.heads {
+	for _, head := range t.heads.txs {
 		if head.tx != nil && head.tx.Resolve() != nil {
 			if tx == head.tx.Tx {
 				//shift t to the position one after tx
@@ -240,3 +418,77 @@ func (t *transactionsByPriceAndNonce) Forward(tx *types.Transaction) {
 		}
 	}
 }
+
+// senderCappedTransactions caps the gas limits of the transactions included per
+// sender, discarding the account once its next transaction exceeds the cap.
+type senderCappedTransactions struct {
+	orderedTransactions
+
+	cap    uint64          // Maximum gas of the transactions of a sender
+	budget *orderingBudget // Budget of the block, holding the gas used by each sender
+}
+
+// newSenderCappedTransactions caps the gas of the senders of the set.
+func newSenderCappedTransactions(txs orderedTransactions, cap uint64, budget *orderingBudget) *senderCappedTransactions {
+	t := &senderCappedTransactions{
+		orderedTransactions: txs,
+		cap:                 cap,
+		budget:              budget,
+	}
+	t.skip()
+	return t
+}
+
+// Shift replaces the best transaction with the next one from the same account,
+// if within the cap. The included transactions are charged to the budget by the
+// caller, the skipped ones not being charged.
+func (t *senderCappedTransactions) Shift() {
+	t.orderedTransactions.Shift()
+	t.skip()
+}
+
+// Pop removes the best transaction and the following ones of the same account.
+func (t *senderCappedTransactions) Pop() {
+	t.orderedTransactions.Pop()
+	t.skip()
+}
+
+// Forward moves current transaction to be the one which is one index after tx.
+// The transactions shifted over are not charged to their senders, Forward being
+// only used to keep the prefetching in line with the block being built.
+func (t *senderCappedTransactions) Forward(tx *types.Transaction) {
+	t.orderedTransactions.Forward(tx)
+	t.skip()
+}
+
+// Copy copies a new senderCappedTransactions with the same *transaction, the
+// copy sharing a single copy of the budget with the copied inner set.
+func (t *senderCappedTransactions) Copy() orderedTransactions {
+	var (
+		budget = t.budget.copy()
+		inner  orderedTransactions
+	)
+	if sorted, ok := t.orderedTransactions.(*sortedTransactions); ok {
+		inner = sorted.copyWithBudget(budget)
+	} else {
+		inner = t.orderedTransactions.Copy()
+	}
+	return &senderCappedTransactions{
+		orderedTransactions: inner,
+		cap:                 t.cap,
+		budget:              budget,
+	}
+}
+
+// skip discards the accounts whose next transaction exceeds the cap of its
+// sender, until the best transaction is within the cap. Discarding the whole
+// account keeps the nonce order of its transactions.
+func (t *senderCappedTransactions) skip() {
+	for {
+		ltx, _ := t.Peek()
+		if ltx == nil || t.budget.senderUsed[t.peekSender()]+ltx.Gas <= t.cap {
+			return
+		}
+		t.orderedTransactions.Pop()
+	}
+}
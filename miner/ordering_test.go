@@ -27,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/miner/minerconfig"
 	"github.com/holiman/uint256"
 )
 
@@ -194,3 +195,209 @@ func TestTransactionTimeSort(t *testing.T) {
 		}
 	}
 }
+
+// newOrderingTestTx creates a signed legacy transaction of the given key, wrapped
+// as a pending transaction of the pool.
+func newOrderingTestTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, to common.Address, gas uint64, price int64, seen time.Time) *txpool.LazyTransaction {
+	tx, err := types.SignTx(types.NewTransaction(nonce, to, big.NewInt(100), gas, big.NewInt(price), nil), types.HomesteadSigner{}, key)
+	if err != nil {
+		t.Fatalf("failed to sign tx: %v", err)
+	}
+	tx.SetTime(seen)
+	return &txpool.LazyTransaction{
+		Hash:      tx.Hash(),
+		Tx:        tx,
+		Time:      tx.Time(),
+		GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+		GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+		Gas:       tx.Gas(),
+	}
+}
+
+// drainOrderedTransactions retrieves all the transactions of the set, charging
+// them to the budget and shifting after each one as if they were all included.
+func drainOrderedTransactions(txset orderedTransactions, budget *orderingBudget) []*types.Transaction {
+	var txs []*types.Transaction
+	for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
+		txs = append(txs, tx.Tx)
+		budget.charge(txset.peekSender(), tx.Tx)
+		txset.Shift()
+	}
+	return txs
+}
+
+// Tests that the FIFO policy retrieves the transactions in the order they were
+// first seen, regardless of their price, while keeping the nonce order.
+func TestTransactionFIFOSort(t *testing.T) {
+	t.Parallel()
+
+	groups := map[common.Address][]*txpool.LazyTransaction{}
+	for i := 0; i < 5; i++ {
+		key, _ := crypto.GenerateKey()
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+
+		// The later accounts pay more, the first nonce of each account is seen
+		// after the second one.
+		groups[addr] = []*txpool.LazyTransaction{
+			newOrderingTestTx(t, key, 0, common.Address{}, 21000, int64(i+1), time.Unix(int64(10+i), 0)),
+			newOrderingTestTx(t, key, 1, common.Address{}, 21000, int64(i+1), time.Unix(int64(i), 0)),
+		}
+	}
+	policy := &minerconfig.OrderingPolicy{Sort: minerconfig.OrderingFIFO}
+	budget := newOrderingBudget(policy)
+	txs := drainOrderedTransactions(newOrderedTransactions(types.HomesteadSigner{}, groups, nil, policy, budget), budget)
+	if len(txs) != 10 {
+		t.Fatalf("expected 10 transactions, found %d", len(txs))
+	}
+	// The first nonces are retrieved by first-seen time, each followed by the
+	// second nonce of the account which was seen before.
+	for i, tx := range txs {
+		if want := uint64(i % 2); tx.Nonce() != want {
+			t.Errorf("tx #%d: nonce mismatch, have %d, want %d", i, tx.Nonce(), want)
+		}
+		if want := big.NewInt(int64(i/2 + 1)); tx.GasPrice().Cmp(want) != 0 {
+			t.Errorf("tx #%d: price mismatch, have %v, want %v", i, tx.GasPrice(), want)
+		}
+	}
+}
+
+// Tests that the sender gas cap discards the transactions of an account once the
+// next one would exceed the cap, keeping the included ones gapless.
+func TestTransactionSenderGasCap(t *testing.T) {
+	t.Parallel()
+
+	var (
+		keys   = make([]*ecdsa.PrivateKey, 3)
+		addrs  = make([]common.Address, 3)
+		groups = map[common.Address][]*txpool.LazyTransaction{}
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		addrs[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+	}
+	// The first account fits two transactions, the second one a single one and
+	// the third one none.
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		groups[addrs[0]] = append(groups[addrs[0]], newOrderingTestTx(t, keys[0], nonce, common.Address{}, 30000, 1, time.Unix(0, 0)))
+	}
+	groups[addrs[1]] = []*txpool.LazyTransaction{
+		newOrderingTestTx(t, keys[1], 0, common.Address{}, 60000, 2, time.Unix(0, 0)),
+		newOrderingTestTx(t, keys[1], 1, common.Address{}, 21000, 2, time.Unix(0, 0)),
+	}
+	groups[addrs[2]] = []*txpool.LazyTransaction{
+		newOrderingTestTx(t, keys[2], 0, common.Address{}, 100000, 3, time.Unix(0, 0)),
+	}
+	policy := &minerconfig.OrderingPolicy{SenderGasCap: 70000}
+	budget := newOrderingBudget(policy)
+	txs := drainOrderedTransactions(newOrderedTransactions(types.HomesteadSigner{}, groups, nil, policy, budget), budget)
+
+	included := make(map[common.Address][]uint64)
+	for _, tx := range txs {
+		from, _ := types.Sender(types.HomesteadSigner{}, tx)
+		included[from] = append(included[from], tx.Nonce())
+	}
+	if have := included[addrs[0]]; len(have) != 2 || have[0] != 0 || have[1] != 1 {
+		t.Errorf("first account: included nonces mismatch, have %v, want [0 1]", have)
+	}
+	if have := included[addrs[1]]; len(have) != 1 || have[0] != 0 {
+		t.Errorf("second account: included nonces mismatch, have %v, want [0]", have)
+	}
+	if have := included[addrs[2]]; len(have) != 0 {
+		t.Errorf("third account: included nonces mismatch, have %v, want none", have)
+	}
+}
+
+// Tests that the transactions calling the reserved contracts are retrieved first
+// until the reserved space is used up, without breaking the nonce order.
+func TestTransactionReservedContracts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		reserved = common.HexToAddress("0xc0ffee")
+		other    = common.HexToAddress("0xdead")
+		groups   = map[common.Address][]*txpool.LazyTransaction{}
+		keys     = make([]*ecdsa.PrivateKey, 5)
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	// Three cheap accounts calling the reserved contract, of which only two fit
+	// in the reserved space.
+	for i := 0; i < 3; i++ {
+		groups[crypto.PubkeyToAddress(keys[i].PublicKey)] = []*txpool.LazyTransaction{
+			newOrderingTestTx(t, keys[i], 0, reserved, 50000, int64(i+1), time.Unix(0, 0)),
+		}
+	}
+	// An expensive account calling another contract.
+	groups[crypto.PubkeyToAddress(keys[3].PublicKey)] = []*txpool.LazyTransaction{
+		newOrderingTestTx(t, keys[3], 0, other, 50000, 100, time.Unix(0, 0)),
+	}
+	// A mid-priced account calling the reserved contract after another contract,
+	// which must not jump ahead of its first nonce.
+	groups[crypto.PubkeyToAddress(keys[4].PublicKey)] = []*txpool.LazyTransaction{
+		newOrderingTestTx(t, keys[4], 0, other, 50000, 50, time.Unix(0, 0)),
+		newOrderingTestTx(t, keys[4], 1, reserved, 50000, 50, time.Unix(0, 0)),
+	}
+	policy := &minerconfig.OrderingPolicy{
+		ReservedContracts: []common.Address{reserved},
+		ReservedGas:       100000,
+	}
+	budget := newOrderingBudget(policy)
+	txs := drainOrderedTransactions(newOrderedTransactions(types.HomesteadSigner{}, groups, nil, policy, budget), budget)
+
+	// The two most expensive reserved transactions come first, then the others by
+	// price with the used up reservation.
+	want := []int64{3, 2, 100, 50, 50, 1}
+	if len(txs) != len(want) {
+		t.Fatalf("expected %d transactions, found %d", len(want), len(txs))
+	}
+	for i, tx := range txs {
+		if tx.GasPrice().Int64() != want[i] {
+			t.Errorf("tx #%d: price mismatch, have %v, want %v", i, tx.GasPrice(), want[i])
+		}
+	}
+	if txs[3].Nonce() != 0 || txs[4].Nonce() != 1 {
+		t.Errorf("invalid nonce ordering: have %d, %d, want 0, 1", txs[3].Nonce(), txs[4].Nonce())
+	}
+}
+
+// Tests that the limits of the ordering policy apply to the block as a whole,
+// across the transaction sets sharing its budget, and only to the transactions
+// charged as included.
+func TestTransactionOrderingBudget(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	policy := &minerconfig.OrderingPolicy{SenderGasCap: 50000}
+	budget := newOrderingBudget(policy)
+
+	// The first transaction is skipped without being charged, as if failing with
+	// a nonce too low, the second one is included.
+	first := newOrderedTransactions(types.HomesteadSigner{}, map[common.Address][]*txpool.LazyTransaction{
+		addr: {
+			newOrderingTestTx(t, key, 0, common.Address{}, 30000, 1, time.Unix(0, 0)),
+			newOrderingTestTx(t, key, 1, common.Address{}, 30000, 1, time.Unix(0, 0)),
+		},
+	}, nil, policy, budget)
+	first.Shift()
+	if txs := drainOrderedTransactions(first, budget); len(txs) != 1 || txs[0].Nonce() != 1 {
+		t.Fatalf("first set: included transactions mismatch, have %d", len(txs))
+	}
+	// A later set of the same block is capped by the gas charged before
+	second := newOrderedTransactions(types.HomesteadSigner{}, map[common.Address][]*txpool.LazyTransaction{
+		addr: {newOrderingTestTx(t, key, 2, common.Address{}, 30000, 1, time.Unix(0, 0))},
+	}, nil, policy, budget)
+	if !second.Empty() {
+		t.Fatalf("second set: transaction exceeding the sender gas cap of the block retrieved")
+	}
+	// A copy is charged to a budget of its own, shared by all its layers
+	copied := second.Copy().(*senderCappedTransactions)
+	if copied.budget == budget {
+		t.Fatalf("copy charged to the original budget")
+	}
+	if inner := copied.orderedTransactions.(*sortedTransactions); inner.budget != copied.budget {
+		t.Fatalf("copy layers charged to distinct budgets")
+	}
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/stateless"
 	"github.com/ethereum/go-ethereum/core/systemcontracts"
 	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/bundlepool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/event"
@@ -104,6 +105,9 @@ type environment struct {
 	sidecars types.BlobSidecars
 	blobs    int
 
+	ordering *orderingBudget // Block space left to the limits of the ordering policy
+	silent   bool            // Whether the block is built apart from the sealing work, without side effects
+
 	witness *stateless.Witness
 }
 
@@ -116,6 +120,7 @@ func (env *environment) copy() *environment {
 		coinbase: env.coinbase,
 		header:   types.CopyHeader(env.header),
 		receipts: copyReceipts(env.receipts),
+		silent:   env.silent,
 	}
 	if env.gasPool != nil {
 		gasPool := *env.gasPool
@@ -129,6 +134,9 @@ func (env *environment) copy() *environment {
 		copy(cpy.sidecars, env.sidecars)
 		cpy.blobs = env.blobs
 	}
+	if env.ordering != nil {
+		cpy.ordering = env.ordering.copy()
+	}
 
 	return cpy
 }
@@ -189,6 +197,7 @@ type getWorkReq struct {
 type bidFetcher interface {
 	GetBestBid(parentHash common.Hash) *BidRuntime
 	GetSimulatingBid(prevBlockHash common.Hash) *BidRuntime
+	journalWinner(number uint64, parentHash common.Hash, winner *BidRuntime, localReward *big.Int)
 }
 
 // worker is the main object which takes care of submitting new work to consensus engine
@@ -229,6 +238,9 @@ type worker struct {
 	extra    []byte
 	tip      *uint256.Int // Minimum tip needed for non-local transaction to include them
 
+	bundlePool *bundlepool.BundlePool // Pool of the bundles merged ahead of the transactions
+	bundleSims bundleSimCache         // Outcomes of the bundles simulated alone on the current parent
+
 	pendingMu    sync.RWMutex
 	pendingTasks map[common.Hash]*task
 
@@ -798,17 +810,23 @@ func (w *worker) applyTransaction(env *environment, tx *types.Transaction, recei
 	return receipt, err
 }
 
-func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transactionsByPriceAndNonce,
-	interruptCh chan int32, stopTimer *time.Timer) error {
-	gasLimit := env.header.GasLimit
-	if env.gasPool == nil {
-		env.gasPool = new(core.GasPool).AddGas(gasLimit)
-		if p, ok := w.engine.(*parlia.Parlia); ok {
-			gasReserved := p.EstimateGasReservedForSystemTxs(w.chain, env.header)
-			env.gasPool.SubGas(gasReserved)
-			log.Debug("commitTransactions", "number", env.header.Number.Uint64(), "time", env.header.Time, "EstimateGasReservedForSystemTxs", gasReserved)
-		}
+// initGasPool creates the gas pool of the environment, if not done yet, with
+// the gas of the system transactions reserved.
+func (w *worker) initGasPool(env *environment) {
+	if env.gasPool != nil {
+		return
+	}
+	env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+	if p, ok := w.engine.(*parlia.Parlia); ok {
+		gasReserved := p.EstimateGasReservedForSystemTxs(w.chain, env.header)
+		env.gasPool.SubGas(gasReserved)
+		log.Debug("commitTransactions", "number", env.header.Number.Uint64(), "time", env.header.Time, "EstimateGasReservedForSystemTxs", gasReserved)
 	}
+}
+
+func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs orderedTransactions,
+	interruptCh chan int32, stopTimer *time.Timer) error {
+	w.initGasPool(env)
 
 	var coalescedLogs []*types.Log
 	// initialize bloom processors
@@ -876,7 +894,7 @@ LOOP:
 		// Retrieve the next transaction and abort if all done.
 		var (
 			ltx *txpool.LazyTransaction
-			txs *transactionsByPriceAndNonce
+			txs orderedTransactions
 		)
 		pltx, ptip := plainTxs.Peek()
 		bltx, btip := blobTxs.Peek()
@@ -934,6 +952,19 @@ LOOP:
 			txs.Pop()
 			continue
 		}
+		// Skip the conditional transactions whose conditions don't hold on the
+		// block being built, checking the accounts on the in-progress state.
+		if ltx.Opts != nil {
+			err := ltx.Opts.CheckBlock(env.header.Number.Uint64(), env.header.Time)
+			if err == nil {
+				err = env.state.ValidateKnownAccounts(ltx.Opts.KnownAccounts)
+			}
+			if err != nil {
+				log.Trace("Ignoring conditional transaction", "hash", ltx.Hash, "err", err)
+				txs.Pop()
+				continue
+			}
+		}
 		// Start executing the transaction
 		env.state.SetTxContext(tx.Hash(), env.tcount)
 
@@ -945,9 +976,13 @@ LOOP:
 			txs.Shift()
 
 		case errors.Is(err, nil):
-			// Everything ok, collect the logs and shift in the next transaction from the same account
+			// Everything ok, collect the logs, charge the ordering policy and shift
+			// in the next transaction from the same account
 			coalescedLogs = append(coalescedLogs, logs...)
 			env.tcount++
+			if env.ordering != nil {
+				env.ordering.charge(from, tx)
+			}
 			txs.Shift()
 
 		default:
@@ -957,7 +992,7 @@ LOOP:
 			txs.Pop()
 		}
 	}
-	if !w.isRunning() && len(coalescedLogs) > 0 {
+	if !w.isRunning() && !env.silent && len(coalescedLogs) > 0 {
 		// We don't push the pendingLogsEvent while we are sealing. The reason is that
 		// when we are sealing, the worker will regenerate a sealing block every 3 seconds.
 		// In order to avoid pushing the repeated pendingLog, we disable the pending log pushing.
@@ -986,6 +1021,7 @@ type generateParams struct {
 	prevWork    *environment
 	beaconRoot  *common.Hash // The beacon root (cancun field).
 	noTxs       bool         // Flag whether an empty block without any transaction is expected
+	silent      bool         // Flag whether the block is built apart from the sealing work, e.g. from an API call
 }
 
 // prepareWork constructs the sealing task according to the given parameters,
@@ -1072,17 +1108,26 @@ func (w *worker) prepareWork(genParams *generateParams, witness bool) (*environm
 		return nil, err
 	}
 
+	env.silent = genParams.silent
+
+	preExecute(w.chainConfig, parent, env)
+	return env, nil
+}
+
+// preExecute applies the state changes of the block preceding its transactions.
+func preExecute(config *params.ChainConfig, parent *types.Header, env *environment) {
+	header := env.header
+
 	// Handle upgrade built-in system contract code
-	systemcontracts.TryUpdateBuildInSystemContract(w.chainConfig, header.Number, parent.Time, header.Time, env.state, true)
+	systemcontracts.TryUpdateBuildInSystemContract(config, header.Number, parent.Time, header.Time, env.state, true)
 
 	if header.ParentBeaconRoot != nil {
 		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, env.evm)
 	}
 
-	if w.chainConfig.IsPrague(header.Number, header.Time) {
+	if config.IsPrague(header.Number, header.Time) {
 		core.ProcessParentBlockHash(header.ParentHash, env.evm)
 	}
-	return env, nil
 }
 
 // fillTransactions retrieves the pending transactions from the txpool and fills them
@@ -1094,6 +1139,14 @@ func (w *worker) fillTransactions(interruptCh chan int32, env *environment, stop
 	prio := w.prio
 	w.confMu.RUnlock()
 
+	// Merge the bundles ahead of the regular transactions, unless filling a block
+	// built around a bid.
+	if bidTxs == nil {
+		if err := w.commitBundles(env, interruptCh); err != nil {
+			return err
+		}
+	}
+
 	// Retrieve the pending transactions pre-filtered by the 1559/4844 dynamic fees
 	filter := txpool.PendingFilter{
 		MinTip: tip,
@@ -1145,18 +1198,22 @@ func (w *worker) fillTransactions(interruptCh chan int32, env *environment, stop
 		}
 	}
 
-	// Fill the block with all available pending transactions.
+	// Fill the block with all available pending transactions, the limits of the
+	// ordering policy applying to the block as a whole.
+	if env.ordering == nil {
+		env.ordering = newOrderingBudget(&w.config.Ordering)
+	}
 	if len(prioPlainTxs) > 0 || len(prioBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, prioPlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, prioBlobTxs, env.header.BaseFee)
+		plainTxs := newOrderedTransactions(env.signer, prioPlainTxs, env.header.BaseFee, &w.config.Ordering, env.ordering)
+		blobTxs := newOrderedTransactions(env.signer, prioBlobTxs, env.header.BaseFee, &w.config.Ordering, env.ordering)
 
 		if err := w.commitTransactions(env, plainTxs, blobTxs, interruptCh, stopTimer); err != nil {
 			return err
 		}
 	}
 	if len(normalPlainTxs) > 0 || len(normalBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, normalPlainTxs, env.header.BaseFee)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, normalBlobTxs, env.header.BaseFee)
+		plainTxs := newOrderedTransactions(env.signer, normalPlainTxs, env.header.BaseFee, &w.config.Ordering, env.ordering)
+		blobTxs := newOrderedTransactions(env.signer, normalBlobTxs, env.header.BaseFee, &w.config.Ordering, env.ordering)
 
 		if err := w.commitTransactions(env, plainTxs, blobTxs, interruptCh, stopTimer); err != nil {
 			return err
@@ -1416,6 +1473,7 @@ LOOP:
 		}
 
 		bestBid := w.bidFetcher.GetBestBid(bestWork.header.ParentHash)
+		var winner *BidRuntime
 
 		if bestBid != nil {
 			bidExistGauge.Inc(1)
@@ -1429,18 +1487,18 @@ LOOP:
 
 		if bestBid != nil && bestReward.CmpBig(bestBid.packedBlockReward) < 0 {
 			// localValidatorReward is the reward for the validator self by the local block.
-			localValidatorReward := new(uint256.Int).Mul(bestReward, uint256.NewInt(*w.config.Mev.ValidatorCommission))
-			localValidatorReward.Div(localValidatorReward, uint256.NewInt(10000))
+			localValidatorReward := validatorShare(bestReward, *w.config.Mev.ValidatorCommission)
 
 			log.Debug("BidSimulator: final compare", "block", bestWork.header.Number.Uint64(),
 				"localValidatorReward", localValidatorReward.String(),
 				"bidValidatorReward", bestBid.packedValidatorReward.String())
 
 			// blockReward(benefits delegators) and validatorReward(benefits the validator) are both optimal
-			if localValidatorReward.CmpBig(bestBid.packedValidatorReward) < 0 {
+			if bestBid.isBetterThanLocal(bestReward, *w.config.Mev.ValidatorCommission) {
 				bidWinGauge.Inc(1)
 
 				bestWork = bestBid.env
+				winner = bestBid
 
 				log.Info("[BUILDER BLOCK]",
 					"block", bestWork.header.Number.Uint64(),
@@ -1451,6 +1509,8 @@ LOOP:
 				)
 			}
 		}
+
+		w.bidFetcher.journalWinner(bestWork.header.Number.Uint64(), bestWork.header.ParentHash, winner, bestReward.ToBig())
 	}
 
 	w.commit(bestWork, w.fullTaskHook, true, start)
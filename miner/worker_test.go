@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/bundlepool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -412,3 +413,103 @@ func testGetSealingWork(t *testing.T, chainConfig *params.ChainConfig, engine co
 		}
 	}
 }
+
+func TestCommitBundles(t *testing.T) {
+	t.Parallel()
+	engine := ethash.NewFaker()
+	defer engine.Close()
+
+	w, b := newTestWorker(t, ethashChainConfig, engine, rawdb.NewMemoryDatabase(), 0)
+	defer w.close()
+
+	pool := bundlepool.New(bundlepool.DefaultConfig, b.chain)
+	defer pool.Close()
+	w.setBundlePool(pool)
+
+	signer := types.LatestSigner(ethashChainConfig)
+	transfer := func(nonce uint64) *types.Transaction {
+		return types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &testUserAddress,
+			Value:    big.NewInt(1),
+			Gas:      params.TxGas,
+			GasPrice: big.NewInt(2 * params.InitialBaseFee),
+		})
+	}
+	bundle := &types.Bundle{Txs: types.Transactions{transfer(0), transfer(1)}, MaxBlockNumber: 1}
+	invalid := &types.Bundle{Txs: types.Transactions{transfer(1), transfer(5)}, MaxBlockNumber: 1}
+	for _, bundle := range []*types.Bundle{bundle, invalid} {
+		if err := pool.Add(bundle); err != nil {
+			t.Fatalf("failed to add bundle: %v", err)
+		}
+	}
+	result := w.generateWork(&generateParams{
+		parentHash: b.chain.CurrentBlock().Hash(),
+		timestamp:  uint64(time.Now().Unix()),
+		coinbase:   common.HexToAddress("0xdeadbeef"),
+	}, false)
+	if result.err != nil {
+		t.Fatalf("failed to generate work: %v", result.err)
+	}
+	// The bundle supersedes the pending transaction of the same nonce.
+	txs := result.block.Transactions()
+	if len(txs) != len(bundle.Txs) {
+		t.Fatalf("transaction count mismatch: have %d, want %d", len(txs), len(bundle.Txs))
+	}
+	for i, tx := range bundle.Txs {
+		if txs[i].Hash() != tx.Hash() {
+			t.Errorf("transaction %d mismatch: have %x, want %x", i, txs[i].Hash(), tx.Hash())
+		}
+	}
+	// The outcomes are cached for the next blocks built on the same parent
+	parent := b.chain.CurrentBlock().Hash()
+	if sim, ok := w.bundleSims.get(parent, bundle.Hash()); !ok || sim == nil {
+		t.Error("bundle outcome not cached")
+	}
+	if sim, ok := w.bundleSims.get(parent, invalid.Hash()); !ok || sim != nil {
+		t.Error("invalid bundle failure not cached")
+	}
+}
+
+func TestBlockTemplate(t *testing.T) {
+	t.Parallel()
+	engine := ethash.NewFaker()
+	defer engine.Close()
+
+	config := *testConfig
+	config.Mev = minerconfig.DefaultMevConfig
+
+	b := newTestWorkerBackend(t, ethashChainConfig, engine, rawdb.NewMemoryDatabase(), 0)
+	b.txPool.Add(pendingTxs, true)
+	w := newWorker(&config, engine, b, new(event.TypeMux), false)
+	w.setEtherbase(testBankAddress)
+	defer w.close()
+
+	if _, err := w.blockTemplate(common.Hash{0x01}); err == nil {
+		t.Fatal("expected error for unknown parent")
+	}
+	parent := b.chain.CurrentBlock()
+	template, err := w.blockTemplate(parent.Hash())
+	if err != nil {
+		t.Fatalf("failed to build block template: %v", err)
+	}
+	if uint64(template.Number) != parent.Number.Uint64()+1 {
+		t.Errorf("number mismatch: have %d, want %d", template.Number, parent.Number.Uint64()+1)
+	}
+	if len(template.Transactions) != 1 || template.Transactions[0] != pendingTxs[0].Hash() {
+		t.Fatalf("transactions mismatch: have %v, want [%x]", template.Transactions, pendingTxs[0].Hash())
+	}
+	if uint64(template.GasUsed) != params.TxGas {
+		t.Errorf("gas used mismatch: have %d, want %d", template.GasUsed, params.TxGas)
+	}
+	if template.BlockReward == nil || template.ValidatorReward == nil {
+		t.Fatal("missing rewards")
+	}
+	if template.BestBid != nil {
+		t.Errorf("unexpected best bid: %v", template.BestBid)
+	}
+	// The dry run leaves the pool untouched.
+	if pending, _ := b.txPool.Stats(); pending != 1 {
+		t.Errorf("pending transactions mismatch: have %d, want 1", pending)
+	}
+}
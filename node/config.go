@@ -227,6 +227,10 @@ type Config struct {
 	// EnableMaliciousVoteMonitor is a flag that whether to enable the malicious vote checker
 	EnableMaliciousVoteMonitor bool `toml:",omitempty"`
 
+	// MaliciousVoteSubmitter is the account used to sign and submit the evidence found
+	// by the malicious vote checker to the slash indicator contract. Empty disables it.
+	MaliciousVoteSubmitter string `toml:",omitempty"`
+
 	// BLSPasswordFile is the file that contains BLS wallet password.
 	BLSPasswordFile string `toml:",omitempty"`
 
@@ -235,6 +239,25 @@ type Config struct {
 	// current directory.
 	BLSWalletDir string `toml:",omitempty"`
 
+	// BLSRemoteSigner is the URL of the remote signing service holding the BLS vote
+	// key. If set, the votes are signed remotely instead of by the local BLS wallet.
+	BLSRemoteSigner string `toml:",omitempty"`
+
+	// BLSRemoteSignerPubKey selects the BLS key of the remote signing service to
+	// vote with, the first key of the service is used if empty.
+	BLSRemoteSignerPubKey string `toml:",omitempty"`
+
+	// BLSRemoteSignerCert, BLSRemoteSignerKey and BLSRemoteSignerCA are the client
+	// certificate, client key and CA certificate files for the mutual TLS connection
+	// to the remote signing service.
+	BLSRemoteSignerCert string `toml:",omitempty"`
+	BLSRemoteSignerKey  string `toml:",omitempty"`
+	BLSRemoteSignerCA   string `toml:",omitempty"`
+
+	// BLSRemoteSignerInsecure permits connecting to the remote signing service over
+	// plain HTTP or without a client certificate.
+	BLSRemoteSignerInsecure bool `toml:",omitempty"`
+
 	// VoteJournalDir is the directory to store votes in the fast finality feature.
 	VoteJournalDir string `toml:",omitempty"`
 
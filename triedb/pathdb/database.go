@@ -125,6 +125,7 @@ type layer interface {
 type Config struct {
 	SyncFlush       bool   // Flag of trienodebuffer sync flush cache to disk
 	StateHistory    uint64 // Number of recent blocks to maintain state history for
+	StateIndexing   bool   // Flag whether to index the state histories for historic state reads
 	CleanCacheSize  int    // Maximum memory allowance (in bytes) for caching clean nodes
 	WriteBufferSize int    // Maximum memory allowance (in bytes) for write buffer
 	ReadOnly        bool   // Flag whether the database is opened in read only mode.
@@ -153,6 +154,9 @@ func (c *Config) fields() []interface{} {
 	list = append(list, "cache", common.StorageSize(c.CleanCacheSize))
 	list = append(list, "buffer", common.StorageSize(c.WriteBufferSize))
 	list = append(list, "history", c.StateHistory)
+	if c.StateIndexing {
+		list = append(list, "indexing", true)
+	}
 	return list
 }
 
@@ -214,6 +218,7 @@ type Database struct {
 	diskdb  ethdb.Database               // Persistent storage for matured trie nodes
 	tree    *layerTree                   // The group for all known layers
 	freezer ethdb.ResettableAncientStore // Freezer for storing trie histories, nil possible in tests
+	indexer *historyIndexer              // Indexer of the state histories, nil if indexing is disabled
 	lock    sync.RWMutex                 // Lock to prevent mutations from happening at the same time
 }
 
@@ -251,6 +256,15 @@ func New(diskdb ethdb.Database, config *Config, isVerkle bool) *Database {
 	if err := db.repairHistory(); err != nil {
 		log.Crit("Failed to repair state history", "err", err)
 	}
+	// Index the state histories in the background for serving historic states.
+	if config.StateIndexing && db.freezer != nil && !db.readOnly {
+		indexer, err := newHistoryIndexer(db.diskdb, db.freezer)
+		if err != nil {
+			log.Crit("Failed to initialize state history indexer", "err", err)
+		}
+		db.indexer = indexer
+		db.indexer.start()
+	}
 	// Disable database in case node is still in the initial state sync stage.
 	if rawdb.ReadSnapSyncStatusFlag(diskdb) == rawdb.StateSyncRunning && !db.readOnly {
 		if err := db.Disable(); err != nil {
@@ -307,6 +321,9 @@ func (db *Database) repairHistory() error {
 	}
 	// Truncate the extra state histories above in freezer in case it's not
 	// aligned with the disk layer. It might happen after a unclean shutdown.
+	if err := rewindHistoryIndex(db.diskdb, db.freezer, id); err != nil {
+		log.Crit("Failed to rewind state history index", "err", err)
+	}
 	pruned, err := truncateFromHead(db.diskdb, db.freezer, id)
 	if err != nil {
 		log.Crit("Failed to truncate extra state histories", "err", err)
@@ -422,6 +439,11 @@ func (db *Database) Enable(root common.Hash) error {
 		if err := db.freezer.Reset(); err != nil {
 			return err
 		}
+		if db.indexer != nil {
+			db.indexer.reset()
+		} else if rawdb.ReadStateHistoryIndexHead(db.diskdb) != nil {
+			rawdb.DeleteStateHistoryIndex(db.diskdb)
+		}
 	}
 	// Re-construct a new disk layer backed by persistent state
 	// with **empty clean cache and node buffer**.
@@ -434,6 +456,23 @@ func (db *Database) Enable(root common.Hash) error {
 	return nil
 }
 
+// Reload drops all the in-memory state layers and resets the state tree with
+// the persistent state, which was modified externally, e.g. replicated from
+// another node.
+func (db *Database) Reload() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	root, err := db.hasher(rawdb.ReadAccountTrieNode(db.diskdb, nil))
+	if err != nil {
+		return err
+	}
+	id := rawdb.ReadPersistentStateID(db.diskdb)
+	db.tree.reset(newDiskLayer(root, id, db, nil, NewTrieNodeBuffer(db.config.SyncFlush, db.config.WriteBufferSize, nil, nil, 0)))
+	log.Debug("Reloaded persistent state", "root", root, "id", id)
+	return nil
+}
+
 // Recover rollbacks the database to a specified historical point.
 // The state is supported as the rollback destination only if it's
 // canonical state and the corresponding trie histories are existent.
@@ -483,7 +522,16 @@ func (db *Database) Recover(root common.Hash) error {
 	if err := db.diskdb.SyncKeyValue(); err != nil {
 		return err
 	}
-	_, err := truncateFromHead(db.diskdb, db.freezer, dl.stateID())
+	var err error
+	if db.indexer != nil {
+		err = db.indexer.rewind(dl.stateID())
+	} else {
+		err = rewindHistoryIndex(db.diskdb, db.freezer, dl.stateID())
+	}
+	if err != nil {
+		return err
+	}
+	_, err = truncateFromHead(db.diskdb, db.freezer, dl.stateID())
 	if err != nil {
 		return err
 	}
@@ -537,6 +585,10 @@ func (db *Database) Close() error {
 	// Release the memory held by clean cache.
 	db.tree.bottom().resetCache()
 
+	// Terminate the state history indexing before closing the freezer.
+	if db.indexer != nil {
+		db.indexer.close()
+	}
 	// Close the attached state history freezer.
 	if db.freezer == nil {
 		return nil
@@ -287,15 +287,19 @@ func (dl *diskLayer) commit(bottom *diffLayer, force bool) (*diskLayer, error) {
 	var (
 		overflow bool
 		oldest   uint64
+		tail     uint64
 	)
 	if dl.db.freezer != nil {
 		err := writeHistory(dl.db.freezer, bottom)
 		if err != nil {
 			return nil, err
 		}
+		if dl.db.indexer != nil {
+			dl.db.indexer.notify()
+		}
 		// Determine if the persisted history object has exceeded the configured
 		// limitation, set the overflow as true if so.
-		tail, err := dl.db.freezer.Tail()
+		tail, err = dl.db.freezer.Tail()
 		if err != nil {
 			return nil, err
 		}
@@ -334,6 +338,11 @@ func (dl *diskLayer) commit(bottom *diffLayer, force bool) (*diskLayer, error) {
 	// To remove outdated history objects from the end, we set the 'tail' parameter
 	// to 'oldest-1' due to the offset between the freezer index and the history ID.
 	if overflow {
+		if ndl.db.indexer != nil {
+			if err := ndl.db.indexer.prune(tail+1, oldest-1); err != nil {
+				return nil, err
+			}
+		}
 		pruned, err := truncateFromTail(ndl.db.diskdb, ndl.db.freezer, oldest-1)
 		if err != nil {
 			return nil, err
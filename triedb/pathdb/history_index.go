@@ -0,0 +1,320 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// The state history index maps each account and storage slot to the ids of the
+// state histories in which it was modified. As a state history records the
+// values before the state transition, the value of an account or storage slot
+// in the historic state n is found in the first state history after n which
+// modified it, or in the current persistent state if none did.
+//
+// Every modification is a standalone key-value entry keyed by the address (and
+// the slot key) followed by the big-endian history id, so the first history
+// after n is found by a single seek.
+
+const (
+	// historyIndexBatch is the number of state histories indexed in a single
+	// database write.
+	historyIndexBatch = 256
+
+	// historyIndexRecheck is the interval to check for new state histories to
+	// index if not woken up explicitly.
+	historyIndexRecheck = 10 * time.Second
+)
+
+// historyIndexer indexes the state histories in the background, and maintains
+// the index as the state histories are pruned from the tail or truncated from
+// the head.
+type historyIndexer struct {
+	disk    ethdb.KeyValueStore
+	freezer ethdb.AncientReader
+	head    atomic.Uint64 // The id of the last indexed state history
+	lock    sync.Mutex    // Lock to serialize the index mutations
+
+	wake   chan struct{}
+	closed chan struct{}
+	wg     sync.WaitGroup
+}
+
+// newHistoryIndexer constructs the indexer of the state histories in the given
+// freezer, discarding the persisted index if it doesn't match the histories.
+func newHistoryIndexer(disk ethdb.KeyValueStore, freezer ethdb.AncientReader) (*historyIndexer, error) {
+	tail, err := freezer.Tail()
+	if err != nil {
+		return nil, err
+	}
+	head, err := freezer.Ancients()
+	if err != nil {
+		return nil, err
+	}
+	indexer := &historyIndexer{
+		disk:    disk,
+		freezer: freezer,
+		wake:    make(chan struct{}, 1),
+		closed:  make(chan struct{}),
+	}
+	indexed := rawdb.ReadStateHistoryIndexHead(disk)
+	switch {
+	case indexed == nil:
+		// Nothing is indexed yet, the histories before the tail are not needed
+		rawdb.WriteStateHistoryIndexHead(disk, tail)
+		indexer.head.Store(tail)
+
+	case *indexed < tail || *indexed > head:
+		// The histories were pruned or truncated while the indexing was off,
+		// the leftover index entries can't be resolved anymore.
+		log.Warn("Discarding stale state history index", "indexed", *indexed, "tail", tail, "head", head)
+		rawdb.DeleteStateHistoryIndex(disk)
+		rawdb.WriteStateHistoryIndexHead(disk, tail)
+		indexer.head.Store(tail)
+
+	default:
+		indexer.head.Store(*indexed)
+	}
+	return indexer, nil
+}
+
+// start launches the background indexing.
+func (i *historyIndexer) start() {
+	i.wg.Add(1)
+	go i.loop()
+}
+
+// close terminates the background indexing.
+func (i *historyIndexer) close() {
+	select {
+	case <-i.closed:
+	default:
+		close(i.closed)
+	}
+	i.wg.Wait()
+}
+
+// notify signals the indexer that new state histories are available.
+func (i *historyIndexer) notify() {
+	select {
+	case i.wake <- struct{}{}:
+	default:
+	}
+}
+
+// indexed returns the id of the last indexed state history.
+func (i *historyIndexer) indexed() uint64 {
+	return i.head.Load()
+}
+
+// loop indexes the new state histories as they are written.
+func (i *historyIndexer) loop() {
+	defer i.wg.Done()
+
+	recheck := time.NewTicker(historyIndexRecheck)
+	defer recheck.Stop()
+
+	for {
+		if err := i.index(); err != nil {
+			log.Error("Failed to index state histories", "err", err)
+		}
+		select {
+		case <-i.wake:
+		case <-recheck.C:
+		case <-i.closed:
+			return
+		}
+	}
+}
+
+// index indexes all the state histories not yet indexed, in batches.
+func (i *historyIndexer) index() error {
+	var (
+		start  = time.Now()
+		logged = time.Now()
+		done   int
+	)
+	for {
+		select {
+		case <-i.closed:
+			return nil
+		default:
+		}
+		n, last, err := i.indexBatch()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			break
+		}
+		done += n
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Indexing state histories", "indexed", done, "last", last, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if done > historyIndexBatch {
+		log.Info("Indexed state histories", "indexed", done, "elapsed", common.PrettyDuration(time.Since(start)))
+	}
+	return nil
+}
+
+// indexBatch indexes the next batch of state histories, returning the number of
+// histories indexed and the id of the last one.
+func (i *historyIndexer) indexBatch() (int, uint64, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	tail, err := i.freezer.Tail()
+	if err != nil {
+		return 0, 0, err
+	}
+	head, err := i.freezer.Ancients()
+	if err != nil {
+		return 0, 0, err
+	}
+	from := max(i.head.Load(), tail) + 1
+	if from > head {
+		return 0, 0, nil
+	}
+	to := min(from+historyIndexBatch-1, head)
+
+	batch := i.disk.NewBatch()
+	for id := from; id <= to; id++ {
+		h, err := readHistory(i.freezer, id)
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, addr := range h.accountList {
+			rawdb.WriteAccountHistoryIndex(batch, addr, id)
+			for _, slot := range h.storageList[addr] {
+				rawdb.WriteStorageHistoryIndex(batch, addr, slot, id)
+			}
+		}
+		// Entries beyond the indexed head left by an interrupted batch are
+		// rewritten later, or removed along with the truncated histories.
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return 0, 0, err
+			}
+			batch.Reset()
+		}
+	}
+	rawdb.WriteStateHistoryIndexHead(batch, to)
+	if err := batch.Write(); err != nil {
+		return 0, 0, err
+	}
+	i.head.Store(to)
+	return int(to - from + 1), to, nil
+}
+
+// prune removes the index entries of the state histories within [from, to],
+// which are about to be pruned from the tail. The histories not indexed yet
+// are skipped by moving the indexed head past them, so that they are not
+// indexed before being pruned, leaving entries behind. They are unindexed
+// too, as an interrupted batch may leave entries beyond the indexed head.
+func (i *historyIndexer) prune(from, to uint64) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.head.Load() >= to {
+		return unindexHistories(i.disk, i.freezer, from, to, nil)
+	}
+	if err := unindexHistories(i.disk, i.freezer, from, to, &to); err != nil {
+		return err
+	}
+	i.head.Store(to)
+	return nil
+}
+
+// rewind removes the index entries of the state histories after the given id,
+// which are about to be truncated from the head.
+func (i *historyIndexer) rewind(nhead uint64) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if err := rewindHistoryIndex(i.disk, i.freezer, nhead); err != nil {
+		return err
+	}
+	i.head.Store(min(i.head.Load(), nhead))
+	return nil
+}
+
+// reset discards the whole index, as all the state histories are removed.
+func (i *historyIndexer) reset() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	rawdb.DeleteStateHistoryIndex(i.disk)
+	rawdb.WriteStateHistoryIndexHead(i.disk, 0)
+	i.head.Store(0)
+}
+
+// unindexHistories removes the index entries of the state histories within
+// [from, to], updating the id of the last indexed history if head is given.
+func unindexHistories(disk ethdb.KeyValueStore, freezer ethdb.AncientReader, from, to uint64, head *uint64) error {
+	batch := disk.NewBatch()
+	for id := from; id <= to; id++ {
+		h, err := readHistory(freezer, id)
+		if err != nil {
+			return err
+		}
+		for _, addr := range h.accountList {
+			rawdb.DeleteAccountHistoryIndex(batch, addr, id)
+			for _, slot := range h.storageList[addr] {
+				rawdb.DeleteStorageHistoryIndex(batch, addr, slot, id)
+			}
+		}
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if head != nil {
+		rawdb.WriteStateHistoryIndexHead(batch, *head)
+	}
+	return batch.Write()
+}
+
+// rewindHistoryIndex removes the index entries of the state histories after
+// the given id, which are about to be truncated from the head. All of them are
+// unindexed, not only the ones below the indexed head, as an interrupted batch
+// may leave entries beyond it.
+func rewindHistoryIndex(disk ethdb.KeyValueStore, freezer ethdb.AncientReader, nhead uint64) error {
+	indexed := rawdb.ReadStateHistoryIndexHead(disk)
+	if indexed == nil {
+		return nil // Never indexed
+	}
+	head, err := freezer.Ancients()
+	if err != nil {
+		return err
+	}
+	if head <= nhead {
+		return nil
+	}
+	nindexed := min(*indexed, nhead)
+	return unindexHistories(disk, freezer, nhead+1, head, &nindexed)
+}
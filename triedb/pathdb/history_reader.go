@@ -0,0 +1,251 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// maxUnindexedHistories is the maximum number of state histories not yet indexed
+// which are scanned one by one when serving historic state reads.
+const maxUnindexedHistories = 128
+
+var (
+	// errHistoryIndexDisabled is returned if historic states are requested but
+	// the state histories are not indexed.
+	errHistoryIndexDisabled = errors.New("state history indexing is disabled")
+
+	// errHistoryIndexing is returned if historic states are requested while the
+	// indexing of the state histories is still in progress.
+	errHistoryIndexing = errors.New("state history indexing is in progress")
+)
+
+// HistoricalStateReader serves the flat state of a historic state, which is not
+// available in the layers anymore but is still covered by the state histories.
+//
+// The accounts and storage slots modified since the historic state are resolved
+// from the state histories, the others are left to be read from the state of
+// the disk layer at the time the reader was created, specified by Base.
+type HistoricalStateReader struct {
+	disk    ethdb.KeyValueStore
+	freezer ethdb.AncientReader
+	id      uint64      // State id of the historic state
+	base    common.Hash // Root of the disk layer the unmodified state is read from
+	baseID  uint64      // State id of the disk layer the unmodified state is read from
+	indexed uint64      // The id of the last indexed state history
+}
+
+// HistoricReader constructs a reader of the historic state with the given root.
+// The state must be older than the disk layer, and not pruned from the state
+// histories.
+func (db *Database) HistoricReader(root common.Hash) (*HistoricalStateReader, error) {
+	if db.indexer == nil {
+		return nil, errHistoryIndexDisabled
+	}
+	id := rawdb.ReadStateID(db.diskdb, root)
+	if id == nil {
+		return nil, fmt.Errorf("state %#x is not available", root)
+	}
+	dl := db.tree.bottom()
+	if *id >= dl.stateID() {
+		return nil, fmt.Errorf("state %#x is not historic", root)
+	}
+	tail, err := db.freezer.Tail()
+	if err != nil {
+		return nil, err
+	}
+	if *id < tail {
+		return nil, fmt.Errorf("state %#x is pruned from the state histories", root)
+	}
+	indexed := db.indexer.indexed()
+	if indexed+maxUnindexedHistories < dl.stateID() {
+		return nil, fmt.Errorf("%w: indexed %d, head %d", errHistoryIndexing, indexed, dl.stateID())
+	}
+	return &HistoricalStateReader{
+		disk:    db.diskdb,
+		freezer: db.freezer,
+		id:      *id,
+		base:    dl.rootHash(),
+		baseID:  dl.stateID(),
+		indexed: indexed,
+	}, nil
+}
+
+// Base returns the root of the state the accounts and storage slots not found
+// in the state histories should be read from.
+func (r *HistoricalStateReader) Base() common.Hash {
+	return r.base
+}
+
+// lookup returns the id of the first state history after the historic state in
+// which the entry was modified, resolved either from the index or by scanning
+// the histories not yet indexed.
+func (r *HistoricalStateReader) lookup(find func(from, to uint64) (uint64, bool), scan func(id uint64) (bool, error)) (uint64, bool, error) {
+	if r.indexed > r.id {
+		if id, ok := find(r.id+1, min(r.indexed, r.baseID)); ok {
+			return id, true, nil
+		}
+	}
+	for id := max(r.indexed, r.id) + 1; id <= r.baseID; id++ {
+		found, err := scan(id)
+		if err != nil {
+			return 0, false, err
+		}
+		if found {
+			return id, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// Account retrieves the account of the historic state in the slim RLP format,
+// empty if the account didn't exist. The flag reports whether the account was
+// resolved from the state histories; if not, it was never modified since and
+// should be read from the state specified by Base.
+func (r *HistoricalStateReader) Account(address common.Address) ([]byte, bool, error) {
+	id, found, err := r.lookup(
+		func(from, to uint64) (uint64, bool) {
+			return rawdb.ReadAccountHistoryIndex(r.disk, address, from, to)
+		},
+		func(id uint64) (bool, error) {
+			_, _, found, err := readHistoryAccount(r.freezer, id, address)
+			return found, err
+		},
+	)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	_, blob, _, err := readHistoryAccount(r.freezer, id, address)
+	if err != nil {
+		return nil, false, err
+	}
+	return blob, true, nil
+}
+
+// Storage retrieves the RLP-encoded storage slot of the historic state, empty
+// if the slot didn't exist. The flag reports whether the slot was resolved from
+// the state histories; if not, it was never modified since and should be read
+// from the state specified by Base.
+func (r *HistoricalStateReader) Storage(address common.Address, key common.Hash) ([]byte, bool, error) {
+	// The legacy state histories are keyed by the hash of the slot key, look
+	// up both to find the first modification.
+	keyHash := crypto.Keccak256Hash(key.Bytes())
+	id, found, err := r.lookup(
+		func(from, to uint64) (uint64, bool) {
+			id, ok := rawdb.ReadStorageHistoryIndex(r.disk, address, key, from, to)
+			if ok {
+				to = id
+			}
+			if legacy, exist := rawdb.ReadStorageHistoryIndex(r.disk, address, keyHash, from, to); exist {
+				return legacy, true
+			}
+			return id, ok
+		},
+		func(id uint64) (bool, error) {
+			_, found, err := readHistoryStorage(r.freezer, id, address, key)
+			return found, err
+		},
+	)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	blob, _, err := readHistoryStorage(r.freezer, id, address, key)
+	if err != nil {
+		return nil, false, err
+	}
+	return blob, true, nil
+}
+
+// readHistoryAccount retrieves the index and the data of the account recorded
+// in the specified state history. The flag reports whether the account was
+// modified in the state transition.
+func readHistoryAccount(freezer ethdb.AncientReader, id uint64, address common.Address) (accountIndex, []byte, bool, error) {
+	indexes := rawdb.ReadStateAccountIndex(freezer, id)
+	if len(indexes) == 0 || len(indexes)%accountIndexSize != 0 {
+		return accountIndex{}, nil, false, fmt.Errorf("invalid account index of state history %d, len: %d", id, len(indexes))
+	}
+	var (
+		n   = len(indexes) / accountIndexSize
+		pos = sort.Search(n, func(i int) bool {
+			return bytes.Compare(indexes[i*accountIndexSize:i*accountIndexSize+common.AddressLength], address.Bytes()) >= 0
+		})
+	)
+	if pos == n || !bytes.Equal(indexes[pos*accountIndexSize:pos*accountIndexSize+common.AddressLength], address.Bytes()) {
+		return accountIndex{}, nil, false, nil
+	}
+	var index accountIndex
+	index.decode(indexes[pos*accountIndexSize : (pos+1)*accountIndexSize])
+
+	data := rawdb.ReadStateAccountHistory(freezer, id)
+	last := index.offset + uint32(index.length)
+	if uint32(len(data)) < last {
+		return accountIndex{}, nil, false, fmt.Errorf("account data of state history %d is corrupted", id)
+	}
+	return index, data[index.offset:last], true, nil
+}
+
+// readHistoryStorage retrieves the data of the storage slot recorded in the
+// specified state history. The flag reports whether the slot was modified in
+// the state transition.
+func readHistoryStorage(freezer ethdb.AncientReader, id uint64, address common.Address, key common.Hash) ([]byte, bool, error) {
+	account, _, found, err := readHistoryAccount(freezer, id, address)
+	if err != nil || !found || account.storageSlots == 0 {
+		return nil, false, err
+	}
+	var m meta
+	if err := m.decode(rawdb.ReadStateHistoryMeta(freezer, id)); err != nil {
+		return nil, false, err
+	}
+	slot := key
+	if m.version == stateHistoryV0 {
+		slot = crypto.Keccak256Hash(key.Bytes())
+	}
+	var (
+		indexes = rawdb.ReadStateStorageIndex(freezer, id)
+		start   = int(account.storageOffset) * slotIndexSize
+		end     = int(account.storageOffset+account.storageSlots) * slotIndexSize
+	)
+	if len(indexes) < end {
+		return nil, false, fmt.Errorf("storage index of state history %d is corrupted", id)
+	}
+	indexes = indexes[start:end]
+
+	n := int(account.storageSlots)
+	pos := sort.Search(n, func(i int) bool {
+		return bytes.Compare(indexes[i*slotIndexSize:i*slotIndexSize+common.HashLength], slot.Bytes()) >= 0
+	})
+	if pos == n || !bytes.Equal(indexes[pos*slotIndexSize:pos*slotIndexSize+common.HashLength], slot.Bytes()) {
+		return nil, false, nil
+	}
+	var index slotIndex
+	index.decode(indexes[pos*slotIndexSize : (pos+1)*slotIndexSize])
+
+	data := rawdb.ReadStateStorageHistory(freezer, id)
+	last := index.offset + uint32(index.length)
+	if uint32(len(data)) < last {
+		return nil, false, fmt.Errorf("storage data of state history %d is corrupted", id)
+	}
+	return data[index.offset:last], true, nil
+}
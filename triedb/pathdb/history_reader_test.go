@@ -0,0 +1,197 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+// newIndexedTester creates a tester with all the layers committed to disk and
+// the produced state histories indexed, partially if unindexed is non-zero.
+func newIndexedTester(t *testing.T, layers int, unindexed int) *tester {
+	tester := newTester(t, 0, false, layers)
+
+	indexer, err := newHistoryIndexer(tester.db.diskdb, tester.db.freezer)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+	tester.db.indexer = indexer
+
+	if err := tester.db.Commit(tester.lastHash(), false); err != nil {
+		t.Fatalf("Failed to commit layers: %v", err)
+	}
+	if err := indexer.index(); err != nil {
+		t.Fatalf("Failed to index state histories: %v", err)
+	}
+	if unindexed > 0 {
+		if err := indexer.rewind(uint64(layers - unindexed)); err != nil {
+			t.Fatalf("Failed to rewind index: %v", err)
+		}
+	}
+	return tester
+}
+
+func (t *tester) verifyHistoricState(root common.Hash) error {
+	reader, err := t.db.HistoricReader(root)
+	if err != nil {
+		return err
+	}
+	// Collect all the accounts and slots ever existed, the ones absent in the
+	// historic state must be resolved as empty.
+	var (
+		accounts = make(map[common.Hash]struct{})
+		storages = make(map[common.Hash]map[common.Hash]struct{})
+	)
+	collect := func(accs map[common.Hash][]byte, slots map[common.Hash]map[common.Hash][]byte) {
+		for addrHash := range accs {
+			accounts[addrHash] = struct{}{}
+		}
+		for addrHash, subset := range slots {
+			if _, ok := storages[addrHash]; !ok {
+				storages[addrHash] = make(map[common.Hash]struct{})
+			}
+			for slotHash := range subset {
+				storages[addrHash][slotHash] = struct{}{}
+			}
+		}
+	}
+	for r := range t.snapAccounts {
+		collect(t.snapAccounts[r], t.snapStorages[r])
+	}
+	collect(t.accounts, t.storages)
+
+	for addrHash := range accounts {
+		blob, found, err := reader.Account(t.accountPreimage(addrHash))
+		if err != nil {
+			return err
+		}
+		if !found {
+			blob = t.accounts[addrHash]
+		}
+		if want := t.snapAccounts[root][addrHash]; !bytes.Equal(blob, want) {
+			return fmt.Errorf("account %x is mismatched, want: %x, got: %x", addrHash, want, blob)
+		}
+	}
+	for addrHash, slots := range storages {
+		for slotHash := range slots {
+			blob, found, err := reader.Storage(t.accountPreimage(addrHash), t.hashPreimage(slotHash))
+			if err != nil {
+				return err
+			}
+			if !found {
+				blob = t.storages[addrHash][slotHash]
+			}
+			if want := t.snapStorages[root][addrHash][slotHash]; !bytes.Equal(blob, want) {
+				return fmt.Errorf("slot %x of account %x is mismatched, want: %x, got: %x", slotHash, addrHash, want, blob)
+			}
+		}
+	}
+	return nil
+}
+
+func TestHistoricStateReader(t *testing.T) {
+	for _, unindexed := range []int{0, 5} {
+		tester := newIndexedTester(t, 16, unindexed)
+
+		if indexed := tester.db.indexer.indexed(); indexed != uint64(16-unindexed) {
+			t.Fatalf("Unexpected indexed head, want: %d, got: %d", 16-unindexed, indexed)
+		}
+		// The state of disk layer is not historic and can't be served
+		if _, err := tester.db.HistoricReader(tester.lastHash()); err == nil {
+			t.Fatal("Expected error for non-historic state")
+		}
+		for i := 0; i < len(tester.roots)-1; i++ {
+			if err := tester.verifyHistoricState(tester.roots[i]); err != nil {
+				t.Fatalf("Failed to verify historic state %d (unindexed %d): %v", i, unindexed, err)
+			}
+		}
+		tester.release()
+	}
+}
+
+func TestHistoryIndexRewind(t *testing.T) {
+	tester := newIndexedTester(t, 16, 0)
+	defer tester.release()
+
+	// Revert the database and ensure the index of truncated histories is gone
+	target := 9
+	if err := tester.db.Recover(tester.roots[target]); err != nil {
+		t.Fatalf("Failed to revert db: %v", err)
+	}
+	tester.accounts = tester.snapAccounts[tester.roots[target]]
+	tester.storages = tester.snapStorages[tester.roots[target]]
+
+	if indexed := tester.db.indexer.indexed(); indexed != uint64(target+1) {
+		t.Fatalf("Unexpected indexed head, want: %d, got: %d", target+1, indexed)
+	}
+	if head := rawdb.ReadStateHistoryIndexHead(tester.db.diskdb); head == nil || *head != uint64(target+1) {
+		t.Fatalf("Unexpected persisted indexed head: %v", head)
+	}
+	it := tester.db.diskdb.NewIterator(rawdb.StateHistoryAccountIndexPrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		id := binary.BigEndian.Uint64(it.Key()[len(it.Key())-8:])
+		if id > uint64(target+1) {
+			t.Fatalf("Dangling index entry of truncated history %d", id)
+		}
+	}
+	for i := 0; i < target; i++ {
+		if err := tester.verifyHistoricState(tester.roots[i]); err != nil {
+			t.Fatalf("Failed to verify historic state %d: %v", i, err)
+		}
+	}
+}
+
+func TestHistoryIndexPrune(t *testing.T) {
+	// The indexing lags behind the pruning, the histories about to be pruned
+	// must not be indexed before being truncated.
+	tester := newIndexedTester(t, 16, 10)
+	defer tester.release()
+
+	ntail := uint64(10)
+	if err := tester.db.indexer.prune(1, ntail); err != nil {
+		t.Fatalf("Failed to prune index: %v", err)
+	}
+	if indexed := tester.db.indexer.indexed(); indexed != ntail {
+		t.Fatalf("Unexpected indexed head, want: %d, got: %d", ntail, indexed)
+	}
+	if err := tester.db.indexer.index(); err != nil {
+		t.Fatalf("Failed to index state histories: %v", err)
+	}
+	if _, err := truncateFromTail(tester.db.diskdb, tester.db.freezer, ntail); err != nil {
+		t.Fatalf("Failed to truncate histories: %v", err)
+	}
+	if indexed := tester.db.indexer.indexed(); indexed != 16 {
+		t.Fatalf("Unexpected indexed head, want: %d, got: %d", 16, indexed)
+	}
+	it := tester.db.diskdb.NewIterator(rawdb.StateHistoryAccountIndexPrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		id := binary.BigEndian.Uint64(it.Key()[len(it.Key())-8:])
+		if id <= ntail {
+			t.Fatalf("Dangling index entry of pruned history %d", id)
+		}
+	}
+}